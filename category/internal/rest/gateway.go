@@ -6,14 +6,16 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jongyunha/lunchbox/category/categorypb"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 func RegisterGateway(ctx context.Context, mux *chi.Mux, grpcAddr string) error {
-	const apiRoot = "/api/v1/customers"
+	const apiRoot = "/api/v1/categories"
 
-	gateway := runtime.NewServeMux()
+	gateway := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(idempotency.GatewayHeaderMatcher(auth.GatewayHeaderMatcher)))
 	err := categorypb.RegisterCategoryServiceHandlerFromEndpoint(ctx, gateway, grpcAddr, []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	})
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jongyunha/lunchbox/crawling"
+	"github.com/jongyunha/lunchbox/internal/config"
+	"github.com/jongyunha/lunchbox/internal/system"
+)
+
+// crawling runs the crawling module on its own so the crawlers can be scaled
+// separately from the rest of lunchbox; restaurants are reached using the
+// address in RPC_SERVICES
+func main() {
+	if err := run(); err != nil {
+		fmt.Printf("crawling exited abnormally: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run() (err error) {
+	var cfg config.AppConfig
+	cfg, err = config.InitConfig()
+	if err != nil {
+		return err
+	}
+	s, err := system.NewSystem(cfg)
+	if err != nil {
+		return err
+	}
+	defer func(db *pgxpool.Pool) {
+		db.Close()
+	}(s.DB())
+
+	if err = s.StartupModules(&crawling.Module{}); err != nil {
+		return err
+	}
+
+	fmt.Println("started crawling service")
+	defer fmt.Println("stopped crawling service")
+
+	return s.Run()
+}
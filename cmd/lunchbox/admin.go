@@ -0,0 +1,466 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jongyunha/lunchbox/internal/config"
+	"github.com/jongyunha/lunchbox/internal/jetstream"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/nats-io/nats.go"
+	"github.com/stackus/errors"
+)
+
+const adminUsage = `usage:
+  lunchbox admin events MODULE STREAM_NAME STREAM_ID
+  lunchbox admin registry [MODULE]
+  lunchbox admin backlog
+  lunchbox admin outbox republish MODULE MESSAGE_ID...
+  lunchbox admin dlq list [-limit N]
+  lunchbox admin dlq redrive SEQUENCE...
+  lunchbox admin projections [MODULE]
+  lunchbox admin projections rebuild MODULE PROJECTION
+
+events prints the events and snapshot of an aggregate as JSON, e.g.
+"lunchbox admin events visits visits.Visit 3f1c...". The consumers and dead
+letters are those of the stream STREAM_BACKEND selects. A re-driven dead letter
+is only handed back to the consumer that gave up on it; with JetStream it is
+published to the redrive subject of that consumer, which a consumer provisioned
+before it had one has to be subscribed again to take in.`
+
+type admin struct {
+	cfg config.AppConfig
+	db  *pgxpool.Pool
+}
+
+// deadLetterQueue is the dead letters of the stream backend in use
+type deadLetterQueue interface {
+	list(ctx context.Context, limit int) ([]deadLetter, error)
+	redrive(ctx context.Context, seq uint64) error
+}
+
+type deadLetter struct {
+	Sequence    uint64
+	DeadAt      time.Time
+	Consumer    string
+	Deliveries  uint64
+	Reason      string
+	MessageName string
+	MessageID   string
+	Subject     string
+}
+
+type (
+	jetStreamDeadLetters struct{ deadLetters jetstream.DeadLetters }
+	postgresDeadLetters  struct{ deadLetters postgres.StreamDeadLetters }
+)
+
+// runAdmin looks into and repairs the state kept by the modules
+func runAdmin(args []string) error {
+	if len(args) == 0 {
+		return errors.ErrBadRequest.Msg(adminUsage)
+	}
+
+	cfg, err := config.InitConfig()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db, err := pgxpool.New(ctx, cfg.PG.DSN())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	a := admin{
+		cfg: cfg,
+		db:  db,
+	}
+
+	switch args[0] {
+	case "events":
+		if len(args) == 4 {
+			return a.events(ctx, args[1], args[2], args[3])
+		}
+	case "registry":
+		if len(args) <= 2 {
+			return a.registry(args[1:])
+		}
+	case "backlog":
+		if len(args) == 1 {
+			return a.backlog(ctx)
+		}
+	case "outbox":
+		if len(args) > 3 && args[1] == "republish" {
+			return a.republish(ctx, args[2], args[3:])
+		}
+	case "dlq":
+		if len(args) > 1 {
+			return a.deadLetters(ctx, args[1], args[2:])
+		}
+	case "projections":
+		if len(args) == 4 && args[1] == "rebuild" {
+			return a.rebuild(ctx, args[2], args[3])
+		}
+		if len(args) <= 2 {
+			return a.projections(args[1:])
+		}
+	}
+
+	return errors.ErrBadRequest.Msg(adminUsage)
+}
+
+func (a admin) events(ctx context.Context, moduleName, streamName, streamID string) error {
+	module, err := inspectableModule(moduleName)
+	if err != nil {
+		return err
+	}
+	reg, err := module.Registry()
+	if err != nil {
+		return err
+	}
+	schema := module.Migrations().Schema
+
+	events, err := postgres.NewEventStore(schema+".events", a.db, reg).LoadStream(ctx, streamID, streamName)
+	if err != nil {
+		return err
+	}
+	snapshot, err := postgres.NewSnapshotReader(schema+".snapshots", a.db, reg).Find(ctx, streamID, streamName)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 && snapshot == nil {
+		return errors.Wrapf(errors.ErrNotFound, "no stream %s %s in %s", streamName, streamID, schema)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		StreamName string                   `json:"streamName"`
+		StreamID   string                   `json:"streamId"`
+		Events     []postgres.StoredEvent   `json:"events"`
+		Snapshot   *postgres.StoredSnapshot `json:"snapshot"`
+	}{
+		StreamName: streamName,
+		StreamID:   streamID,
+		Events:     events,
+		Snapshot:   snapshot,
+	})
+}
+
+func (a admin) registry(args []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MODULE\tKEY")
+	for _, m := range modules {
+		if len(args) == 1 && args[0] != m.name {
+			continue
+		}
+		module, ok := m.module.(system.InspectableModule)
+		if !ok {
+			continue
+		}
+		reg, err := module.Registry()
+		if err != nil {
+			return err
+		}
+		for _, key := range reg.Keys() {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", m.name, key)
+		}
+	}
+
+	return w.Flush()
+}
+
+func (a admin) backlog(ctx context.Context) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MODULE\tTABLE\tMESSAGES\tSINCE")
+	for _, m := range modules {
+		module, ok := m.module.(system.InspectableModule)
+		if !ok {
+			continue
+		}
+		schema := module.Migrations().Schema
+
+		if exists, err := a.tableExists(ctx, schema+".outbox"); err != nil {
+			return err
+		} else if exists {
+			count, oldest, err := postgres.NewOutboxStore(schema+".outbox", a.db).Backlog(ctx)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(w, "%s\toutbox (unpublished)\t%d\t%s\n", m.name, count, formatTime(oldest))
+		}
+		if exists, err := a.tableExists(ctx, schema+".inbox"); err != nil {
+			return err
+		} else if exists {
+			count, latest, err := postgres.NewInboxStore(schema+".inbox", a.db).Received(ctx)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(w, "%s\tinbox (received)\t%d\t%s\n", m.name, count, formatTime(latest))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// the messages waiting to be received are still in the stream
+	_, _ = fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CONSUMER\tPENDING\tACK PENDING\tREDELIVERED")
+
+	switch a.cfg.Stream.Backend {
+	case config.JetStreamBackend:
+		js, closeNats, err := a.jetStream()
+		if err != nil {
+			return err
+		}
+		defer closeNats()
+
+		for info := range js.Consumers(a.cfg.Nats.Stream) {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", info.Name, info.NumPending, info.NumAckPending, info.NumRedelivered)
+		}
+	case config.PostgresBackend:
+		consumers, err := postgres.StreamConsumers(ctx, a.db)
+		if err != nil {
+			return err
+		}
+		for _, consumer := range consumers {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", consumer.Name, consumer.Pending, consumer.AckPending, consumer.Redelivered)
+		}
+	default:
+		fmt.Printf("the consumers of the %s stream are kept within the process running it\n", a.cfg.Stream.Backend)
+		return nil
+	}
+
+	return w.Flush()
+}
+
+func (a admin) republish(ctx context.Context, moduleName string, ids []string) error {
+	module, err := inspectableModule(moduleName)
+	if err != nil {
+		return err
+	}
+
+	count, err := postgres.NewOutboxStore(module.Migrations().Schema+".outbox", a.db).MarkUnpublished(ctx, ids...)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d of %d messages returned to the outbox\n", count, len(ids))
+
+	return nil
+}
+
+func (a admin) deadLetters(ctx context.Context, command string, args []string) error {
+	deadLetters, closeQueue, err := a.deadLetterQueue()
+	if err != nil {
+		return err
+	}
+	defer closeQueue()
+
+	switch command {
+	case "list":
+		var limit int
+		flags := flag.NewFlagSet("list", flag.ContinueOnError)
+		flags.IntVar(&limit, "limit", 100, "the most dead letters to list")
+		if err = flags.Parse(args); err != nil {
+			return err
+		}
+
+		list, err := deadLetters.list(ctx, limit)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "SEQUENCE\tDEAD AT\tCONSUMER\tDELIVERIES\tREASON\tMESSAGE\tSUBJECT")
+		for _, dl := range list {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s %s\t%s\n",
+				dl.Sequence, formatTime(&dl.DeadAt), dl.Consumer, dl.Deliveries, dl.Reason, dl.MessageName, dl.MessageID, dl.Subject,
+			)
+		}
+		return w.Flush()
+	case "redrive":
+		if len(args) == 0 {
+			break
+		}
+		for _, arg := range args {
+			seq, err := strconv.ParseUint(arg, 10, 64)
+			if err != nil {
+				return errors.Wrapf(errors.ErrBadRequest, "invalid sequence %q", arg)
+			}
+			if err = deadLetters.redrive(ctx, seq); err != nil {
+				return errors.Wrapf(err, "re-driving dead letter %d", seq)
+			}
+			fmt.Printf("re-drove dead letter %d\n", seq)
+		}
+		return nil
+	}
+
+	return errors.ErrBadRequest.Msg(adminUsage)
+}
+
+func (a admin) projections(args []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MODULE\tPROJECTION\tAGGREGATE\tTABLES")
+	for _, m := range modules {
+		if len(args) == 1 && args[0] != m.name {
+			continue
+		}
+		module, ok := m.module.(system.InspectableModule)
+		if !ok {
+			continue
+		}
+		for _, projection := range module.Projections() {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%v\n", m.name, projection.Name, projection.AggregateName, projection.Tables)
+		}
+	}
+
+	return w.Flush()
+}
+
+func (a admin) rebuild(ctx context.Context, moduleName, projectionName string) error {
+	module, err := inspectableModule(moduleName)
+	if err != nil {
+		return err
+	}
+	reg, err := module.Registry()
+	if err != nil {
+		return err
+	}
+
+	for _, projection := range module.Projections() {
+		if projection.Name != projectionName {
+			continue
+		}
+		started := time.Now()
+		err = postgres.RebuildProjection(ctx, a.db, module.Migrations().Schema+".events", reg, projection)
+		if err != nil {
+			return errors.Wrapf(err, "rebuilding the %s projection", projectionName)
+		}
+		fmt.Printf("rebuilt the %s projection in %s\n", projectionName, time.Since(started).Round(time.Millisecond))
+		return nil
+	}
+
+	return errors.Wrapf(errors.ErrNotFound, "the %s module has no %s projection", moduleName, projectionName)
+}
+
+func (a admin) tableExists(ctx context.Context, tableName string) (exists bool, err error) {
+	err = a.db.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", tableName).Scan(&exists)
+	return
+}
+
+// deadLetterQueue returns the dead letters of the stream STREAM_BACKEND
+// selects; the memory stream keeps its own within the process
+func (a admin) deadLetterQueue() (deadLetterQueue, func(), error) {
+	switch a.cfg.Stream.Backend {
+	case config.JetStreamBackend:
+		js, closeNats, err := a.jetStream()
+		if err != nil {
+			return nil, nil, err
+		}
+		return jetStreamDeadLetters{jetstream.NewDeadLetters(a.cfg.Nats.Stream, js)}, closeNats, nil
+	case config.PostgresBackend:
+		return postgresDeadLetters{postgres.NewStreamDeadLetters(a.db)}, func() {}, nil
+	}
+
+	return nil, nil, errors.Wrapf(errors.ErrFailedPrecondition, "the dead letters of the %s stream backend cannot be looked into", a.cfg.Stream.Backend)
+}
+
+func (a admin) jetStream() (nats.JetStreamContext, func(), error) {
+	nc, err := nats.Connect(a.cfg.Nats.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	js, err := nc.JetStream()
+	if err != nil {
+		nc.Close()
+		return nil, nil, err
+	}
+
+	return js, nc.Close, nil
+}
+
+func inspectableModule(name string) (system.InspectableModule, error) {
+	for _, m := range modules {
+		if m.name != name {
+			continue
+		}
+		if module, ok := m.module.(system.InspectableModule); ok {
+			return module, nil
+		}
+		break
+	}
+
+	return nil, errors.Wrapf(errors.ErrNotFound, "unknown module %q", name)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
+
+func (q jetStreamDeadLetters) list(_ context.Context, limit int) ([]deadLetter, error) {
+	list, err := q.deadLetters.List(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters := make([]deadLetter, len(list))
+	for i, dl := range list {
+		deadLetters[i] = deadLetter{
+			Sequence:    dl.Sequence,
+			DeadAt:      dl.DeadAt,
+			Consumer:    dl.Consumer,
+			Deliveries:  dl.Deliveries,
+			Reason:      dl.Reason,
+			MessageName: dl.MessageName,
+			MessageID:   dl.MessageID,
+			Subject:     dl.Subject,
+		}
+	}
+	return deadLetters, nil
+}
+
+func (q jetStreamDeadLetters) redrive(_ context.Context, seq uint64) error {
+	return q.deadLetters.Redrive(seq)
+}
+
+func (q postgresDeadLetters) list(ctx context.Context, limit int) ([]deadLetter, error) {
+	list, err := q.deadLetters.List(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters := make([]deadLetter, len(list))
+	for i, dl := range list {
+		deadLetters[i] = deadLetter{
+			Sequence:    dl.Sequence,
+			DeadAt:      dl.DeadAt,
+			Consumer:    dl.Consumer,
+			Deliveries:  uint64(dl.Deliveries),
+			Reason:      dl.Reason,
+			MessageName: dl.MessageName,
+			MessageID:   dl.MessageID,
+			Subject:     dl.Subject,
+		}
+	}
+	return deadLetters, nil
+}
+
+func (q postgresDeadLetters) redrive(ctx context.Context, seq uint64) error {
+	return q.deadLetters.Redrive(ctx, seq)
+}
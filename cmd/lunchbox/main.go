@@ -9,17 +9,31 @@ import (
 	"github.com/jongyunha/lunchbox/internal/config"
 	"github.com/jongyunha/lunchbox/internal/system"
 	"github.com/jongyunha/lunchbox/internal/web"
-	"github.com/jongyunha/lunchbox/restaurants"
 )
 
-type monolith struct {
-	*system.System
-	modules []system.Module
+// commands run in place of the application when named by the first argument
+var commands = map[string]func(args []string) error{
+	"admin":       runAdmin,
+	"migrate":     runMigrate,
+	"restaurants": runRestaurants,
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		command, exists := commands[os.Args[1]]
+		if !exists {
+			fmt.Printf("unknown command %q\n", os.Args[1])
+			os.Exit(2)
+		}
+		if err := command(os.Args[2:]); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := run(); err != nil {
-		fmt.Printf("mallbots exitted abnormally: %s\n", err.Error())
+		fmt.Printf("mallbots exited abnormally: %s\n", err.Error())
 		os.Exit(1)
 	}
 }
@@ -30,36 +44,28 @@ func run() (err error) {
 	if err != nil {
 		return err
 	}
-	s, err := system.NewSystem(cfg)
+	selected, err := selectModules(cfg.Modules)
 	if err != nil {
 		return err
 	}
-	m := monolith{
-		System: s,
-		modules: []system.Module{
-			&restaurants.Module{},
-		},
+	s, err := system.NewSystem(cfg)
+	if err != nil {
+		return err
 	}
 	defer func(db *pgxpool.Pool) {
 		db.Close()
-	}(m.DB())
+	}(s.DB())
 
-	if err = m.startupModules(); err != nil {
+	if err = s.StartupModules(selected...); err != nil {
 		return err
 	}
 
 	// Mount general web resources
-	m.Mux().Mount("/", http.FileServer(http.FS(web.WebUI)))
+	s.Mux().Mount("/", http.FileServer(http.FS(web.WebUI)))
 
 	fmt.Println("started mallbots application")
 	defer fmt.Println("stopped mallbots application")
 
-	m.Waiter().Add(
-		m.WaitForWeb,
-		m.WaitForRPC,
-		m.WaitForStream,
-	)
-
 	// go func() {
 	// 	for {
 	// 		var mem runtime.MemStats
@@ -69,16 +75,5 @@ func run() (err error) {
 	// 	}
 	// }()
 
-	return m.Waiter().Wait()
-}
-
-func (m *monolith) startupModules() error {
-	for _, module := range m.modules {
-		ctx := m.Waiter().Context()
-		if err := module.Startup(ctx, m); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return s.Run()
 }
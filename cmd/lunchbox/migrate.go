@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"text/tabwriter"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jongyunha/lunchbox/internal/config"
+	"github.com/jongyunha/lunchbox/internal/logger"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/stackus/errors"
+)
+
+const migrateUsage = `usage:
+  lunchbox migrate up
+  lunchbox migrate down SCHEMA
+  lunchbox migrate status
+
+The shared migrations and those of the modules in LUNCHBOX_MODULES, or every
+module when it is empty, are migrated. down rolls back the last migration
+applied to the one schema; "public" holds the shared migrations.`
+
+// runMigrate migrates the database without starting lunchbox
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.ErrBadRequest.Msg(migrateUsage)
+	}
+
+	cfg, err := config.InitConfig()
+	if err != nil {
+		return err
+	}
+	selected, err := selectModules(cfg.Modules)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("pgx", cfg.PG.DSN())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	migrator := postgres.NewMigrator(db, logger.New(logger.LogConfig{
+		Environment: cfg.Environment,
+		LogLevel:    logger.Level(cfg.LogLevel),
+	}), system.Migrations(selected...)...)
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		return migrator.Up(ctx)
+	case args[0] == "down" && len(args) == 2:
+		return migrator.Down(ctx, args[1])
+	case args[0] == "status" && len(args) == 1:
+		return printMigrationStatus(ctx, migrator)
+	}
+
+	return errors.ErrBadRequest.Msg(migrateUsage)
+}
+
+func printMigrationStatus(ctx context.Context, migrator postgres.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SCHEMA\tVERSION\tMIGRATION\tSTATE")
+	for _, status := range statuses {
+		state := "pending"
+		if status.Applied {
+			state = "applied"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", status.Schema, status.Version, path.Base(status.Name), state)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/jongyunha/lunchbox/crawling"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/jongyunha/lunchbox/polls"
+	"github.com/jongyunha/lunchbox/recommendations"
+	"github.com/jongyunha/lunchbox/restaurants"
+	"github.com/jongyunha/lunchbox/reviews"
+	"github.com/jongyunha/lunchbox/users"
+	"github.com/jongyunha/lunchbox/visits"
+	"github.com/stackus/errors"
+)
+
+type namedModule struct {
+	name   string
+	module system.Module
+}
+
+// modules lists every module in the order they are started
+var modules = []namedModule{
+	{"restaurants", &restaurants.Module{}},
+	{"reviews", &reviews.Module{}},
+	{"recommendations", &recommendations.Module{}},
+	{"polls", &polls.Module{}},
+	{"visits", &visits.Module{}},
+	{"users", &users.Module{}},
+	{"crawling", &crawling.Module{}},
+}
+
+// selectModules returns the named modules in startup order; no names selects
+// them all so that the monolith remains the default
+func selectModules(names []string) ([]system.Module, error) {
+	// a stray comma, as in "restaurants,reviews,", leaves an empty name behind
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = true
+		}
+	}
+
+	if len(wanted) == 0 {
+		selected := make([]system.Module, len(modules))
+		for i, m := range modules {
+			selected[i] = m.module
+		}
+		return selected, nil
+	}
+
+	var selected []system.Module
+	for _, m := range modules {
+		if wanted[m.name] {
+			selected = append(selected, m.module)
+			delete(wanted, m.name)
+		}
+	}
+	for name := range wanted {
+		return nil, errors.Wrapf(errors.ErrBadRequest, "unknown module %q", name)
+	}
+
+	return selected, nil
+}
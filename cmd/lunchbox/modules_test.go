@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/internal/system"
+)
+
+func TestSelectModules(t *testing.T) {
+	all := make([]string, len(modules))
+	for i, m := range modules {
+		all[i] = m.name
+	}
+
+	tests := map[string]struct {
+		names     []string
+		want      []string
+		wantError bool
+	}{
+		"no names selects every module": {
+			names: nil,
+			want:  all,
+		},
+		"the modules are started in order": {
+			names: []string{"reviews", "restaurants"},
+			want:  []string{"restaurants", "reviews"},
+		},
+		"names are trimmed": {
+			names: []string{" restaurants", "reviews "},
+			want:  []string{"restaurants", "reviews"},
+		},
+		"a trailing comma is ignored": {
+			names: []string{"restaurants", "reviews", ""},
+			want:  []string{"restaurants", "reviews"},
+		},
+		"only empty names selects every module": {
+			names: []string{"", " "},
+			want:  all,
+		},
+		"an unknown module is an error": {
+			names:     []string{"restaurants", "category"},
+			wantError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			selected, err := selectModules(tc.names)
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, moduleNames(selected)); diff != "" {
+				t.Errorf("the modules selected are not the ones expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func moduleNames(selected []system.Module) []string {
+	var names []string
+	for _, s := range selected {
+		for _, m := range modules {
+			if m.module == s {
+				names = append(names, m.name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/jongyunha/lunchbox/internal/rpc"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantsio"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/stackus/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const restaurantsUsage = `usage:
+  lunchbox restaurants import [-dry-run] [-format csv|jsonl|geojson] [-addr host:port] [-token jwt] FILE
+  lunchbox restaurants export [-format csv|jsonl|geojson] [-o FILE] [-addr host:port] [-token jwt]
+
+FILE may be - to import from stdin, in which case -format is required. The
+address and token default to LUNCHBOX_RPC_ADDRESS and LUNCHBOX_AUTH_TOKEN.`
+
+// runRestaurants imports and exports restaurants through a running lunchbox
+func runRestaurants(args []string) error {
+	if len(args) == 0 {
+		return errors.ErrBadRequest.Msg(restaurantsUsage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	switch args[0] {
+	case "import":
+		return importRestaurants(ctx, args[1:])
+	case "export":
+		return exportRestaurants(ctx, args[1:])
+	}
+
+	return errors.ErrBadRequest.Msg(restaurantsUsage)
+}
+
+type rpcFlags struct {
+	addr  string
+	token string
+}
+
+func (f *rpcFlags) register(flags *flag.FlagSet) {
+	addr := os.Getenv("LUNCHBOX_RPC_ADDRESS")
+	if addr == "" {
+		addr = "localhost:8085"
+	}
+	flags.StringVar(&f.addr, "addr", addr, "the gRPC address of lunchbox")
+	flags.StringVar(&f.token, "token", os.Getenv("LUNCHBOX_AUTH_TOKEN"), "the bearer token to call lunchbox with")
+}
+
+func (f *rpcFlags) client(ctx context.Context) (context.Context, restaurantspb.RestaurantsServiceClient, func(), error) {
+	conn, err := rpc.Dial(ctx, f.addr)
+	if err != nil {
+		return ctx, nil, nil, err
+	}
+	if f.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+f.token)
+	}
+
+	return ctx, restaurantspb.NewRestaurantsServiceClient(conn), func() { _ = conn.Close() }, nil
+}
+
+func importRestaurants(ctx context.Context, args []string) error {
+	var rpcFlags rpcFlags
+	var dryRun bool
+	var formatName string
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	rpcFlags.register(flags)
+	flags.BoolVar(&dryRun, "dry-run", false, "check every record without importing any of them")
+	flags.StringVar(&formatName, "format", "", "the file format; taken from the file extension when not given")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.ErrBadRequest.Msg(restaurantsUsage)
+	}
+	filename := flags.Arg(0)
+
+	format, err := chooseFormat(formatName, filename)
+	if err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if filename != "-" {
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	reader, err := restaurantsio.NewReader(in, format)
+	if err != nil {
+		return err
+	}
+
+	ctx, client, closeConn, err := rpcFlags.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	stream, err := client.ImportRestaurants(ctx)
+	if err != nil {
+		return err
+	}
+
+	// records are sent while the outcomes of earlier ones are received
+	unreadable := make(chan int, 1)
+	sendErr := make(chan error, 1)
+	go func() {
+		count := 0
+		defer func() { unreadable <- count }()
+		for {
+			row, record, err := reader.Read()
+			if err == io.EOF {
+				sendErr <- stream.CloseSend()
+				return
+			}
+			var rowErr *restaurantsio.RowError
+			if errors.As(err, &rowErr) {
+				count++
+				fmt.Fprintln(os.Stderr, rowErr.Error())
+				continue
+			}
+			if err != nil {
+				_ = stream.CloseSend()
+				sendErr <- err
+				return
+			}
+
+			err = stream.Send(&restaurantspb.ImportRestaurantsRequest{
+				Row:        int32(row),
+				Restaurant: record,
+				DryRun:     dryRun,
+			})
+			if err != nil {
+				// the reason is reported by Recv
+				sendErr <- nil
+				return
+			}
+		}
+	}()
+
+	var created, updated, failed int
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case resp.GetError() != "":
+			failed++
+			fmt.Fprintf(os.Stderr, "row %d: %s\n", resp.GetRow(), resp.GetError())
+		case resp.GetCreated():
+			created++
+		default:
+			updated++
+		}
+	}
+	if err = <-sendErr; err != nil {
+		return err
+	}
+	failed += <-unreadable
+
+	if dryRun {
+		fmt.Printf("dry run: %d new and %d existing restaurants would be imported, %d rows failed\n", created, updated, failed)
+	} else {
+		fmt.Printf("imported %d new and %d existing restaurants, %d rows failed\n", created, updated, failed)
+	}
+	if failed > 0 {
+		return errors.ErrBadRequest.Msgf("%d rows could not be imported", failed)
+	}
+
+	return nil
+}
+
+func exportRestaurants(ctx context.Context, args []string) error {
+	var rpcFlags rpcFlags
+	var formatName, filename string
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	rpcFlags.register(flags)
+	flags.StringVar(&formatName, "format", "", "the file format; taken from the file extension when not given")
+	flags.StringVar(&filename, "o", "-", "the file to write to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if formatName == "" && filename == "-" {
+		formatName = string(restaurantsio.CSV)
+	}
+	format, err := chooseFormat(formatName, filename)
+	if err != nil {
+		return err
+	}
+
+	ctx, client, closeConn, err := rpcFlags.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	stream, err := client.ExportRestaurants(ctx, &restaurantspb.ExportRestaurantsRequest{})
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if filename != "-" {
+		file, err := os.Create(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer, err := restaurantsio.NewWriter(out, format)
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err = writer.Write(resp.GetRestaurant()); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+func chooseFormat(formatName, filename string) (restaurantsio.Format, error) {
+	if formatName != "" {
+		return restaurantsio.ParseFormat(formatName)
+	}
+	if filename == "-" {
+		return "", errors.ErrBadRequest.Msg("-format is required when reading from stdin")
+	}
+	return restaurantsio.FormatFromFilename(filename)
+}
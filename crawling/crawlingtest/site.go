@@ -0,0 +1,199 @@
+// Package crawlingtest provides a stand-in listing site for exercising the
+// crawler without reaching out to the internet
+package crawlingtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+)
+
+// Restaurant is one entry on the stand-in site
+type Restaurant struct {
+	ID             string
+	Name           string
+	Latitude       float64
+	Longitude      float64
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+}
+
+// Site serves the same restaurants two ways, paginated PageSize at a time
+//
+//	/robots.txt                     Robots, with RobotsStatus
+//	/restaurants?page=N             HTML pages with schema.org JSON-LD
+//	/restaurants.json?page=N        JSON pages
+//	/private/restaurants            a listing the default Robots disallows
+//
+// Requests counts the requests made for each path.
+type Site struct {
+	*httptest.Server
+	Restaurants  []Restaurant
+	PageSize     int
+	Robots       string
+	RobotsStatus int
+	mu           sync.Mutex
+	requests     map[string]int
+}
+
+var dietTypes = map[string]string{
+	"gluten-free": "GlutenFreeDiet",
+	"halal":       "HalalDiet",
+	"kosher":      "KosherDiet",
+	"vegan":       "VeganDiet",
+	"vegetarian":  "VegetarianDiet",
+}
+
+// NewSite starts a stand-in site; Close it when done
+func NewSite(restaurants ...Restaurant) *Site {
+	site := &Site{
+		Restaurants: restaurants,
+		PageSize:    2,
+		Robots:      "User-agent: *\nDisallow: /private/\n",
+		requests:    make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", site.robots)
+	mux.HandleFunc("/restaurants", site.htmlPage)
+	mux.HandleFunc("/restaurants.json", site.jsonPage)
+	mux.HandleFunc("/private/restaurants", site.htmlPage)
+	site.Server = httptest.NewServer(site.count(mux))
+
+	return site
+}
+
+// Requests returns how many times the path has been requested
+func (s *Site) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[path]
+}
+
+func (s *Site) count(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		s.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Site) robots(w http.ResponseWriter, _ *http.Request) {
+	if s.RobotsStatus != 0 && s.RobotsStatus != http.StatusOK {
+		w.WriteHeader(s.RobotsStatus)
+		return
+	}
+	_, _ = fmt.Fprint(w, s.Robots)
+}
+
+// HTMLURL is the first page of the HTML listing
+func (s *Site) HTMLURL() string {
+	return s.URL + "/restaurants"
+}
+
+// JSONURL is the first page of the JSON listing
+func (s *Site) JSONURL() string {
+	return s.URL + "/restaurants.json"
+}
+
+func (s *Site) page(r *http.Request) (restaurants []Restaurant, page int, last bool) {
+	page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	start := min((page-1)*s.PageSize, len(s.Restaurants))
+	end := min(start+s.PageSize, len(s.Restaurants))
+
+	return s.Restaurants[start:end], page, end >= len(s.Restaurants)
+}
+
+func (s *Site) htmlPage(w http.ResponseWriter, r *http.Request) {
+	restaurants, page, last := s.page(r)
+
+	items := make([]map[string]any, 0, len(restaurants))
+	for i, restaurant := range restaurants {
+		diets := make([]string, 0, len(restaurant.DietaryTags))
+		for _, tag := range restaurant.DietaryTags {
+			if diet, exists := dietTypes[tag]; exists {
+				diets = append(diets, "https://schema.org/"+diet)
+			}
+		}
+		items = append(items, map[string]any{
+			"@type":    "ListItem",
+			"position": i + 1,
+			"item": map[string]any{
+				"@type": "Restaurant",
+				"@id":   "/restaurants/" + restaurant.ID,
+				"url":   "/restaurants/" + restaurant.ID,
+				"name":  restaurant.Name,
+				"geo": map[string]any{
+					"@type":     "GeoCoordinates",
+					"latitude":  restaurant.Latitude,
+					"longitude": restaurant.Longitude,
+				},
+				"priceRange":              fmt.Sprintf("₩%d", restaurant.PricePerPerson),
+				"suitableForDiet":         diets,
+				"maximumAttendeeCapacity": restaurant.Seats,
+			},
+		})
+	}
+	data, _ := json.Marshal(map[string]any{
+		"@context":        "https://schema.org",
+		"@type":           "ItemList",
+		"itemListElement": items,
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, "<!doctype html><html><head><title>Restaurants</title>\n")
+	_, _ = fmt.Fprintf(w, "<script type=\"application/ld+json\">%s</script>\n", data)
+	if !last {
+		_, _ = fmt.Fprintf(w, "<link rel=\"next\" href=\"?page=%d\">\n", page+1)
+	}
+	_, _ = fmt.Fprint(w, "</head><body></body></html>\n")
+}
+
+func (s *Site) jsonPage(w http.ResponseWriter, r *http.Request) {
+	restaurants, page, last := s.page(r)
+
+	type listing struct {
+		ID             string   `json:"id"`
+		URL            string   `json:"url"`
+		Name           string   `json:"name"`
+		Latitude       float64  `json:"latitude"`
+		Longitude      float64  `json:"longitude"`
+		PricePerPerson int      `json:"price_per_person"`
+		DietaryTags    []string `json:"dietary_tags"`
+		Seats          int      `json:"seats"`
+	}
+	resp := struct {
+		Restaurants []listing `json:"restaurants"`
+		Next        string    `json:"next,omitempty"`
+	}{
+		Restaurants: make([]listing, 0, len(restaurants)),
+	}
+	for _, restaurant := range restaurants {
+		resp.Restaurants = append(resp.Restaurants, listing{
+			ID:             restaurant.ID,
+			URL:            "/restaurants/" + restaurant.ID,
+			Name:           restaurant.Name,
+			Latitude:       restaurant.Latitude,
+			Longitude:      restaurant.Longitude,
+			PricePerPerson: restaurant.PricePerPerson,
+			DietaryTags:    restaurant.DietaryTags,
+			Seats:          restaurant.Seats,
+		})
+	}
+	if !last {
+		resp.Next = fmt.Sprintf("/restaurants.json?page=%d", page+1)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/application/commands"
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+)
+
+type (
+	App interface {
+		Commands
+	}
+
+	Commands interface {
+		IngestListing(ctx context.Context, cmd commands.IngestListing) error
+	}
+
+	Application struct {
+		appCommands
+	}
+
+	appCommands struct {
+		commands.IngestListingHandler
+	}
+)
+
+var _ App = (*Application)(nil)
+
+func New(
+	listings domain.CrawledListingRepository,
+	restaurants domain.KnownRestaurantRepository,
+	client domain.RestaurantClient,
+	matchDistance float64,
+) *Application {
+	return &Application{
+		appCommands: appCommands{
+			IngestListingHandler: commands.NewIngestListingHandler(listings, restaurants, client, matchDistance),
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type (
+	IngestListing struct {
+		Listing domain.Listing
+	}
+
+	IngestListingHandler struct {
+		listings      domain.CrawledListingRepository
+		restaurants   domain.KnownRestaurantRepository
+		client        domain.RestaurantClient
+		matchDistance float64
+	}
+)
+
+func NewIngestListingHandler(
+	listings domain.CrawledListingRepository,
+	restaurants domain.KnownRestaurantRepository,
+	client domain.RestaurantClient,
+	matchDistance float64,
+) IngestListingHandler {
+	return IngestListingHandler{
+		listings:      listings,
+		restaurants:   restaurants,
+		client:        client,
+		matchDistance: matchDistance,
+	}
+}
+
+// IngestListing submits a discovered restaurant to the restaurants module
+//
+// Listings seen before update the restaurant they were linked to when their
+// details change. New listings are first matched against the known
+// restaurants by name and distance so the same restaurant found on several
+// sites is only registered once.
+func (h IngestListingHandler) IngestListing(ctx context.Context, cmd IngestListing) error {
+	listing := cmd.Listing
+	if err := listing.Validate(); err != nil {
+		return err
+	}
+
+	crawled, err := h.listings.Find(ctx, listing.Source, listing.Key())
+	switch {
+	case err == nil:
+		if crawled.Fingerprint == listing.Fingerprint() {
+			return nil
+		}
+		if err = h.client.Update(ctx, crawled.RestaurantID, listing); err != nil {
+			return err
+		}
+		return h.link(ctx, listing, crawled.RestaurantID)
+	case !errors.Is(err, domain.ErrCrawledListingNotFound):
+		return err
+	}
+
+	match, err := h.findMatch(ctx, listing)
+	if err != nil {
+		return err
+	}
+
+	var restaurantID string
+	if match != nil {
+		restaurantID = match.ID
+		if !match.Matches(listing) {
+			if err = h.client.Update(ctx, restaurantID, listing); err != nil {
+				return err
+			}
+		}
+	} else {
+		if restaurantID, err = h.client.Register(ctx, listing); err != nil {
+			return err
+		}
+	}
+
+	// remember the restaurant right away; the integration events arrive later
+	// and listings for it may follow in the same crawl
+	err = h.restaurants.Save(ctx, &domain.KnownRestaurant{
+		ID:             restaurantID,
+		Name:           listing.Name,
+		Location:       listing.Location,
+		PricePerPerson: listing.PricePerPerson,
+		DietaryTags:    listing.DietaryTags,
+		Seats:          listing.Seats,
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.link(ctx, listing, restaurantID)
+}
+
+// findMatch returns the closest known restaurant with the same name; when
+// either side has no coordinates the name alone decides
+func (h IngestListingHandler) findMatch(ctx context.Context, listing domain.Listing) (*domain.KnownRestaurant, error) {
+	candidates, err := h.restaurants.FindByName(ctx, listing.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *domain.KnownRestaurant
+	closest := h.matchDistance
+	for _, candidate := range candidates {
+		if listing.Location.IsZero() || candidate.Location.IsZero() {
+			if match == nil {
+				match = candidate
+			}
+			continue
+		}
+		if distance := listing.Location.DistanceTo(candidate.Location); distance <= closest {
+			match, closest = candidate, distance
+		}
+	}
+
+	return match, nil
+}
+
+func (h IngestListingHandler) link(ctx context.Context, listing domain.Listing, restaurantID string) error {
+	return h.listings.Save(ctx, &domain.CrawledListing{
+		Source:       listing.Source,
+		ExternalID:   listing.Key(),
+		RestaurantID: restaurantID,
+		Fingerprint:  listing.Fingerprint(),
+		CrawledAt:    time.Now(),
+	})
+}
@@ -0,0 +1,23 @@
+package constants
+
+// ServiceName The name of this module/service
+const ServiceName = "crawling"
+
+// Dependency Injection Keys
+const (
+	RegistryKey                 = "registry"
+	DatabaseTransactionKey      = "tx"
+	MessageSubscriberKey        = "messageSubscriber"
+	ApplicationKey              = "app"
+	IntegrationEventHandlersKey = "integrationEventHandlers"
+
+	KnownRestaurantsRepoKey = "knownRestaurantsRepo"
+	CrawledListingsRepoKey  = "crawledListingsRepo"
+	RestaurantClientKey     = "restaurantClient"
+	InboxStoreKey           = "inboxStore"
+)
+
+// Names of the modules this module calls
+const (
+	RestaurantsServiceName = "restaurants"
+)
@@ -0,0 +1,149 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/stackus/errors"
+	"golang.org/x/time/rate"
+)
+
+// maxPageSize keeps a misbehaving site from exhausting memory
+const maxPageSize = 10 << 20
+
+var ErrDisallowedByRobots = errors.Wrap(errors.ErrPermissionDenied, "robots.txt does not allow crawling the page")
+
+type (
+	// Fetcher is a polite HTTP client
+	//
+	// Every host gets its own rate limit of one request per delay, which a
+	// robots.txt Crawl-delay can only make slower, and pages the robots.txt
+	// disallows are never requested.
+	Fetcher struct {
+		client    *http.Client
+		userAgent string
+		delay     time.Duration
+		mu        sync.Mutex
+		hosts     map[string]*host
+	}
+
+	host struct {
+		mu      sync.Mutex
+		robots  *robots
+		limiter *rate.Limiter
+	}
+)
+
+var _ domain.Fetcher = (*Fetcher)(nil)
+
+func NewFetcher(client *http.Client, userAgent string, delay time.Duration) *Fetcher {
+	return &Fetcher{
+		client:    client,
+		userAgent: userAgent,
+		delay:     delay,
+		hosts:     make(map[string]*host),
+	}
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, pageURL string) ([]byte, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, errors.ErrBadRequest.Err(err)
+	}
+
+	h, err := f.host(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if !h.robots.allowed(u.EscapedPath()) {
+		return nil, errors.Wrap(ErrDisallowedByRobots, pageURL)
+	}
+
+	status, body, err := f.get(ctx, h, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.ErrUnavailable.Msgf("%s responded with %d", pageURL, status)
+	}
+
+	return body, nil
+}
+
+// host returns the robots.txt rules and rate limiter of the host, reading the
+// robots.txt the first time the host is seen
+func (f *Fetcher) host(ctx context.Context, u *url.URL) (*host, error) {
+	f.mu.Lock()
+	h, exists := f.hosts[u.Host]
+	if !exists {
+		h = &host{limiter: rate.NewLimiter(rate.Every(f.delay), 1)}
+		f.hosts[u.Host] = h
+	}
+	f.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.robots == nil {
+		robots := f.fetchRobots(ctx, h, u)
+		// a cancelled crawl says nothing about the robots.txt; try again next time
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if robots.crawlDelay > f.delay {
+			h.limiter.SetLimit(rate.Every(robots.crawlDelay))
+		}
+		h.robots = robots
+	}
+
+	return h, nil
+}
+
+// fetchRobots treats a missing robots.txt as allowing everything and an
+// unreachable one as allowing nothing
+func (f *Fetcher) fetchRobots(ctx context.Context, h *host, u *url.URL) *robots {
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", u.Scheme, u.Host)
+
+	status, body, err := f.get(ctx, h, robotsURL)
+	switch {
+	case err != nil:
+		return disallowAll
+	case status >= 200 && status < 300:
+		return parseRobots(body, f.userAgent)
+	case status >= 400 && status < 500:
+		return allowAll
+	default:
+		return disallowAll
+	}
+}
+
+func (f *Fetcher) get(ctx context.Context, h *host, pageURL string) (int, []byte, error) {
+	if err := h.limiter.Wait(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, body, nil
+}
@@ -0,0 +1,162 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jongyunha/lunchbox/crawling/crawlingtest"
+	"github.com/stackus/errors"
+)
+
+const testUserAgent = "Lunchbox/1.0"
+
+func TestFetcherRobots(t *testing.T) {
+	tests := map[string]struct {
+		robots       string
+		robotsStatus int
+		path         string
+		allowed      bool
+	}{
+		"an allowed page": {
+			path:    "/restaurants",
+			allowed: true,
+		},
+		"a disallowed page": {
+			path:    "/private/restaurants",
+			allowed: false,
+		},
+		"an allow longer than the disallow": {
+			robots:  "User-agent: *\nDisallow: /private/\nAllow: /private/restaurants\n",
+			path:    "/private/restaurants",
+			allowed: true,
+		},
+		"a missing robots.txt allows everything": {
+			robotsStatus: http.StatusNotFound,
+			path:         "/private/restaurants",
+			allowed:      true,
+		},
+		"a forbidden robots.txt allows everything": {
+			robotsStatus: http.StatusForbidden,
+			path:         "/private/restaurants",
+			allowed:      true,
+		},
+		"a failing robots.txt allows nothing": {
+			robotsStatus: http.StatusServiceUnavailable,
+			path:         "/restaurants",
+			allowed:      false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			site := crawlingtest.NewSite()
+			defer site.Close()
+			if tc.robots != "" {
+				site.Robots = tc.robots
+			}
+			site.RobotsStatus = tc.robotsStatus
+
+			fetcher := NewFetcher(site.Client(), testUserAgent, time.Millisecond)
+			_, err := fetcher.Fetch(context.Background(), site.URL+tc.path)
+
+			if tc.allowed {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if site.Requests(tc.path) != 1 {
+					t.Errorf("expected the page to be requested once, it was requested %d times", site.Requests(tc.path))
+				}
+				return
+			}
+			if !errors.Is(err, ErrDisallowedByRobots) {
+				t.Fatalf("expected ErrDisallowedByRobots, got %v", err)
+			}
+			if site.Requests(tc.path) != 0 {
+				t.Errorf("expected the page not to be requested, it was requested %d times", site.Requests(tc.path))
+			}
+		})
+	}
+}
+
+func TestFetcherUnreachableRobots(t *testing.T) {
+	site := crawlingtest.NewSite()
+	url := site.HTMLURL()
+	site.Close()
+
+	fetcher := NewFetcher(http.DefaultClient, testUserAgent, time.Millisecond)
+	if _, err := fetcher.Fetch(context.Background(), url); !errors.Is(err, ErrDisallowedByRobots) {
+		t.Fatalf("expected ErrDisallowedByRobots, got %v", err)
+	}
+}
+
+func TestFetcherReadsRobotsOncePerHost(t *testing.T) {
+	site := crawlingtest.NewSite()
+	defer site.Close()
+
+	fetcher := NewFetcher(site.Client(), testUserAgent, time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if _, err := fetcher.Fetch(context.Background(), site.HTMLURL()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if site.Requests("/robots.txt") != 1 {
+		t.Errorf("expected the robots.txt to be read once, it was read %d times", site.Requests("/robots.txt"))
+	}
+}
+
+func TestFetcherCrawlDelay(t *testing.T) {
+	const crawlDelay = 200 * time.Millisecond
+
+	tests := map[string]struct {
+		robots  string
+		delay   time.Duration
+		atLeast time.Duration
+		within  time.Duration
+	}{
+		"a crawl delay slows the host down": {
+			robots:  "User-agent: *\nCrawl-delay: 0.2\n",
+			delay:   time.Millisecond,
+			atLeast: 2*crawlDelay - 20*time.Millisecond,
+		},
+		"a crawl delay shorter than the delay is ignored": {
+			robots:  "User-agent: *\nCrawl-delay: 0.001\n",
+			delay:   crawlDelay,
+			atLeast: 2*crawlDelay - 20*time.Millisecond,
+		},
+		"without a crawl delay the delay is used": {
+			robots: "User-agent: *\n",
+			delay:  time.Millisecond,
+			within: crawlDelay,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			site := crawlingtest.NewSite()
+			defer site.Close()
+			site.Robots = tc.robots
+
+			fetcher := NewFetcher(site.Client(), testUserAgent, tc.delay)
+			// the first fetch reads the robots.txt and uses up the burst
+			if _, err := fetcher.Fetch(context.Background(), site.HTMLURL()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			start := time.Now()
+			for i := 0; i < 2; i++ {
+				if _, err := fetcher.Fetch(context.Background(), site.HTMLURL()); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			elapsed := time.Since(start)
+
+			if tc.atLeast != 0 && elapsed < tc.atLeast {
+				t.Errorf("expected the fetches to take at least %s, they took %s", tc.atLeast, elapsed)
+			}
+			if tc.within != 0 && elapsed > tc.within {
+				t.Errorf("expected the fetches to take no more than %s, they took %s", tc.within, elapsed)
+			}
+		})
+	}
+}
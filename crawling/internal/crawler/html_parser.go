@@ -0,0 +1,210 @@
+package crawler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"golang.org/x/net/html"
+)
+
+// HTMLParser reads listing pages that describe their restaurants with
+// schema.org JSON-LD, and follows their rel="next" links
+//
+// Restaurants may appear on their own, in an "@graph" or as the items of an
+// "ItemList". The price per person is the first number in "priceRange", the
+// dietary tags come from "suitableForDiet" and the seats from
+// "maximumAttendeeCapacity".
+type HTMLParser struct{}
+
+var _ domain.Parser = (*HTMLParser)(nil)
+
+var restaurantTypes = []string{
+	"Restaurant", "FoodEstablishment", "FastFoodRestaurant", "CafeOrCoffeeShop", "Bakery", "BarOrPub",
+}
+
+var dietTags = map[string]string{
+	"DiabeticDiet":   "diabetic",
+	"GlutenFreeDiet": "gluten-free",
+	"HalalDiet":      "halal",
+	"HinduDiet":      "hindu",
+	"KosherDiet":     "kosher",
+	"LowCalorieDiet": "low-calorie",
+	"LowFatDiet":     "low-fat",
+	"LowLactoseDiet": "low-lactose",
+	"LowSaltDiet":    "low-salt",
+	"VeganDiet":      "vegan",
+	"VegetarianDiet": "vegetarian",
+}
+
+func (HTMLParser) Parse(page []byte, pageURL *url.URL) ([]domain.Listing, string, error) {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var listings []domain.Listing
+	var next string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script":
+				if strings.EqualFold(attr(n, "type"), "application/ld+json") && n.FirstChild != nil {
+					var data any
+					// one broken block should not hide the rest of the page
+					if json.Unmarshal([]byte(n.FirstChild.Data), &data) == nil {
+						listings = append(listings, listingsFromJSONLD(data, pageURL)...)
+					}
+				}
+			case "a", "link":
+				if next == "" && slices.Contains(strings.Fields(strings.ToLower(attr(n, "rel"))), "next") {
+					next = resolve(pageURL, attr(n, "href"))
+				}
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	return listings, next, nil
+}
+
+func listingsFromJSONLD(data any, pageURL *url.URL) []domain.Listing {
+	switch v := data.(type) {
+	case []any:
+		var listings []domain.Listing
+		for _, item := range v {
+			listings = append(listings, listingsFromJSONLD(item, pageURL)...)
+		}
+		return listings
+	case map[string]any:
+		if isRestaurant(v["@type"]) {
+			return []domain.Listing{listingFromJSONLD(v, pageURL)}
+		}
+		var listings []domain.Listing
+		for _, key := range []string{"@graph", "itemListElement", "item"} {
+			if nested, exists := v[key]; exists {
+				listings = append(listings, listingsFromJSONLD(nested, pageURL)...)
+			}
+		}
+		return listings
+	}
+
+	return nil
+}
+
+func listingFromJSONLD(v map[string]any, pageURL *url.URL) domain.Listing {
+	listing := domain.Listing{
+		URL:            resolve(pageURL, stringValue(v["url"])),
+		Name:           strings.TrimSpace(stringValue(v["name"])),
+		PricePerPerson: int(firstNumber(stringValue(v["priceRange"]))),
+		Seats:          int(numberValue(v["maximumAttendeeCapacity"])),
+	}
+
+	switch {
+	case stringValue(v["identifier"]) != "":
+		listing.ExternalID = stringValue(v["identifier"])
+	case stringValue(v["@id"]) != "":
+		listing.ExternalID = resolve(pageURL, stringValue(v["@id"]))
+	default:
+		listing.ExternalID = listing.URL
+	}
+
+	if geo, ok := v["geo"].(map[string]any); ok {
+		listing.Location = domain.Location{
+			Latitude:  numberValue(geo["latitude"]),
+			Longitude: numberValue(geo["longitude"]),
+		}
+	}
+
+	for _, diet := range stringValues(v["suitableForDiet"]) {
+		diet = diet[strings.LastIndex(diet, "/")+1:]
+		if tag, exists := dietTags[diet]; exists {
+			listing.DietaryTags = append(listing.DietaryTags, tag)
+		}
+	}
+
+	return listing
+}
+
+func isRestaurant(t any) bool {
+	for _, value := range stringValues(t) {
+		if slices.Contains(restaurantTypes, value[strings.LastIndex(value, "/")+1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func stringValue(v any) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
+
+func stringValues(v any) []string {
+	if values, ok := v.([]any); ok {
+		var strs []string
+		for _, value := range values {
+			if str := stringValue(value); str != "" {
+				strs = append(strs, str)
+			}
+		}
+		return strs
+	}
+	if str := stringValue(v); str != "" {
+		return []string{str}
+	}
+	return nil
+}
+
+func numberValue(v any) float64 {
+	switch value := v.(type) {
+	case float64:
+		return value
+	case string:
+		number, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return number
+	}
+	return 0
+}
+
+// firstNumber reads the first run of digits, ignoring thousands separators,
+// so "₩12,000 - ₩15,000" is 12000
+func firstNumber(s string) int64 {
+	var digits strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case r == ',' && digits.Len() > 0:
+		case digits.Len() > 0:
+			number, _ := strconv.ParseInt(digits.String(), 10, 64)
+			return number
+		}
+	}
+	number, _ := strconv.ParseInt(digits.String(), 10, 64)
+	return number
+}
@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type (
+	// JSONParser reads listing pages served as JSON
+	//
+	//	{
+	//	  "restaurants": [
+	//	    {"id": "42", "name": "Kim's Kitchen", "latitude": 37.5, "longitude": 127.0,
+	//	     "price_per_person": 9000, "dietary_tags": ["vegan"], "seats": 24}
+	//	  ],
+	//	  "next": "/restaurants.json?page=2"
+	//	}
+	JSONParser struct{}
+
+	jsonListingPage struct {
+		Restaurants []jsonListing `json:"restaurants"`
+		Next        string        `json:"next"`
+	}
+
+	jsonListing struct {
+		ID             json.Number `json:"id"`
+		URL            string      `json:"url"`
+		Name           string      `json:"name"`
+		Latitude       float64     `json:"latitude"`
+		Longitude      float64     `json:"longitude"`
+		PricePerPerson int         `json:"price_per_person"`
+		DietaryTags    []string    `json:"dietary_tags"`
+		Seats          int         `json:"seats"`
+	}
+)
+
+var _ domain.Parser = (*JSONParser)(nil)
+
+func (JSONParser) Parse(page []byte, pageURL *url.URL) ([]domain.Listing, string, error) {
+	var decoded jsonListingPage
+
+	decoder := json.NewDecoder(strings.NewReader(string(page)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, "", errors.ErrBadRequest.Err(err)
+	}
+
+	listings := make([]domain.Listing, 0, len(decoded.Restaurants))
+	for _, restaurant := range decoded.Restaurants {
+		listings = append(listings, domain.Listing{
+			ExternalID: restaurant.ID.String(),
+			URL:        resolve(pageURL, restaurant.URL),
+			Name:       strings.TrimSpace(restaurant.Name),
+			Location: domain.Location{
+				Latitude:  restaurant.Latitude,
+				Longitude: restaurant.Longitude,
+			},
+			PricePerPerson: restaurant.PricePerPerson,
+			DietaryTags:    restaurant.DietaryTags,
+			Seats:          restaurant.Seats,
+		})
+	}
+
+	return listings, resolve(pageURL, decoded.Next), nil
+}
+
+// resolve makes links found on a page absolute
+func resolve(pageURL *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := pageURL.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,77 @@
+package crawler
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/stackus/errors"
+)
+
+// ListingSource crawls a paginated listing starting from a single page
+type ListingSource struct {
+	name     string
+	url      string
+	interval time.Duration
+	maxPages int
+	parser   domain.Parser
+}
+
+var _ domain.Source = (*ListingSource)(nil)
+
+func NewListingSource(name, startURL string, interval time.Duration, maxPages int, parser domain.Parser) *ListingSource {
+	return &ListingSource{
+		name:     name,
+		url:      startURL,
+		interval: interval,
+		maxPages: maxPages,
+		parser:   parser,
+	}
+}
+
+func (s ListingSource) Name() string {
+	return s.name
+}
+
+func (s ListingSource) Interval() time.Duration {
+	return s.interval
+}
+
+// Crawl follows the listing from page to page until there is no next page,
+// a page repeats or maxPages have been read
+func (s ListingSource) Crawl(ctx context.Context, fetcher domain.Fetcher) ([]domain.Listing, error) {
+	var listings []domain.Listing
+
+	visited := make(map[string]struct{})
+	next := s.url
+	for page := 0; next != "" && (s.maxPages <= 0 || page < s.maxPages); page++ {
+		if _, exists := visited[next]; exists {
+			break
+		}
+		visited[next] = struct{}{}
+
+		pageURL, err := url.Parse(next)
+		if err != nil {
+			return listings, errors.Wrapf(errors.ErrBadRequest, "invalid page url %q", next)
+		}
+
+		data, err := fetcher.Fetch(ctx, next)
+		if err != nil {
+			return listings, err
+		}
+
+		var pageListings []domain.Listing
+		pageListings, next, err = s.parser.Parse(data, pageURL)
+		if err != nil {
+			return listings, errors.Wrapf(err, "parsing page %q", pageURL)
+		}
+
+		for _, listing := range pageListings {
+			listing.Source = s.name
+			listings = append(listings, listing)
+		}
+	}
+
+	return listings, nil
+}
@@ -0,0 +1,101 @@
+package crawler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/crawling/crawlingtest"
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+)
+
+var testRestaurants = []crawlingtest.Restaurant{
+	{ID: "1", Name: "Kim's Kitchen", Latitude: 37.5, Longitude: 127.0, PricePerPerson: 9000, DietaryTags: []string{"vegan"}, Seats: 24},
+	{ID: "2", Name: "Noodle Bar", Latitude: 37.51, Longitude: 127.01, PricePerPerson: 8000, Seats: 12},
+	{ID: "3", Name: "Green Table", Latitude: 37.52, Longitude: 127.02, PricePerPerson: 12000, DietaryTags: []string{"vegetarian", "gluten-free"}, Seats: 40},
+	{ID: "4", Name: "Halal Grill", Latitude: 37.53, Longitude: 127.03, PricePerPerson: 11000, DietaryTags: []string{"halal"}, Seats: 30},
+	{ID: "5", Name: "Corner Cafe", Latitude: 37.54, Longitude: 127.04, PricePerPerson: 6000, Seats: 8},
+}
+
+func TestListingSourceCrawl(t *testing.T) {
+	site := crawlingtest.NewSite(testRestaurants...)
+	defer site.Close()
+
+	tests := map[string]struct {
+		url        string
+		parser     domain.Parser
+		externalID func(restaurant crawlingtest.Restaurant) string
+	}{
+		"html": {
+			url:    site.HTMLURL(),
+			parser: HTMLParser{},
+			externalID: func(restaurant crawlingtest.Restaurant) string {
+				return site.URL + "/restaurants/" + restaurant.ID
+			},
+		},
+		"json": {
+			url:    site.JSONURL(),
+			parser: JSONParser{},
+			externalID: func(restaurant crawlingtest.Restaurant) string {
+				return restaurant.ID
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := NewListingSource("test", tc.url, time.Hour, 0, tc.parser)
+			fetcher := NewFetcher(site.Client(), testUserAgent, time.Millisecond)
+
+			listings, err := source.Crawl(context.Background(), fetcher)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := make([]domain.Listing, len(testRestaurants))
+			for i, restaurant := range testRestaurants {
+				want[i] = domain.Listing{
+					Source:         "test",
+					ExternalID:     tc.externalID(restaurant),
+					URL:            site.URL + "/restaurants/" + restaurant.ID,
+					Name:           restaurant.Name,
+					Location:       domain.Location{Latitude: restaurant.Latitude, Longitude: restaurant.Longitude},
+					PricePerPerson: restaurant.PricePerPerson,
+					DietaryTags:    restaurant.DietaryTags,
+					Seats:          restaurant.Seats,
+				}
+			}
+			if diff := cmp.Diff(want, listings, cmp.Comparer(equalTags)); diff != "" {
+				t.Errorf("unexpected listings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListingSourceMaxPages(t *testing.T) {
+	site := crawlingtest.NewSite(testRestaurants...)
+	defer site.Close()
+
+	source := NewListingSource("test", site.HTMLURL(), time.Hour, 2, HTMLParser{})
+	fetcher := NewFetcher(site.Client(), testUserAgent, time.Millisecond)
+
+	listings, err := source.Crawl(context.Background(), fetcher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(listings) != 2*site.PageSize {
+		t.Errorf("expected %d listings from the first two pages, got %d", 2*site.PageSize, len(listings))
+	}
+	if site.Requests("/restaurants") != 2 {
+		t.Errorf("expected two pages to be requested, %d were", site.Requests("/restaurants"))
+	}
+}
+
+// equalTags treats no tags and an empty list of tags the same
+func equalTags(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return cmp.Equal(a, b)
+}
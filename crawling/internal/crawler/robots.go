@@ -0,0 +1,130 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// robots are the rules of a robots.txt file that apply to one user agent
+	robots struct {
+		rules      []robotsRule
+		crawlDelay time.Duration
+	}
+
+	robotsRule struct {
+		allow   bool
+		length  int
+		pattern *regexp.Regexp
+	}
+
+	robotsGroup struct {
+		agents []string
+		robots robots
+	}
+)
+
+var (
+	allowAll    = &robots{}
+	disallowAll = &robots{rules: []robotsRule{{allow: false, length: 1, pattern: compileRobotsPattern("/")}}}
+)
+
+// parseRobots reads the group of rules for the user agent, falling back to
+// the "*" group, following RFC 9309
+func parseRobots(data []byte, userAgent string) *robots {
+	var groups []*robotsGroup
+	var current *robotsGroup
+	inAgents := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+				inAgents = true
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			continue
+		case "allow", "disallow":
+			if current != nil && value != "" {
+				current.robots.rules = append(current.robots.rules, robotsRule{
+					allow:   key == "allow",
+					length:  len(value),
+					pattern: compileRobotsPattern(value),
+				})
+			}
+		case "crawl-delay":
+			if current != nil {
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+					current.robots.crawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+		inAgents = false
+	}
+
+	product := strings.ToLower(userAgent)
+	product, _, _ = strings.Cut(product, "/")
+
+	var fallback *robots
+	for _, group := range groups {
+		for _, agent := range group.agents {
+			switch {
+			case agent == "*":
+				if fallback == nil {
+					fallback = &group.robots
+				}
+			case agent == product:
+				return &group.robots
+			}
+		}
+	}
+	if fallback != nil {
+		return fallback
+	}
+
+	return allowAll
+}
+
+// allowed applies the longest matching rule, with allow winning ties
+func (r *robots) allowed(path string) bool {
+	allowed := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > longest || (rule.length == longest && rule.allow) {
+			allowed, longest = rule.allow, rule.length
+		}
+	}
+
+	return allowed
+}
+
+// compileRobotsPattern turns a path pattern where "*" matches any characters
+// and a trailing "$" anchors the end into a regular expression
+func compileRobotsPattern(value string) *regexp.Regexp {
+	value, anchored := strings.CutSuffix(value, "$")
+
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(value), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+
+	return regexp.MustCompile(expr)
+}
@@ -0,0 +1,75 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRobots(t *testing.T) {
+	tests := map[string]struct {
+		robots  string
+		path    string
+		allowed bool
+	}{
+		"no rules allow everything": {
+			robots:  "User-agent: *\n",
+			path:    "/restaurants",
+			allowed: true,
+		},
+		"a disallowed prefix": {
+			robots:  "User-agent: *\nDisallow: /private/\n",
+			path:    "/private/restaurants",
+			allowed: false,
+		},
+		"the longer allow wins": {
+			robots:  "User-agent: *\nDisallow: /private/\nAllow: /private/restaurants\n",
+			path:    "/private/restaurants",
+			allowed: true,
+		},
+		"the longer disallow wins": {
+			robots:  "User-agent: *\nAllow: /private/\nDisallow: /private/restaurants\n",
+			path:    "/private/restaurants",
+			allowed: false,
+		},
+		"allow wins a tie": {
+			robots:  "User-agent: *\nDisallow: /restaurants\nAllow: /restaurants\n",
+			path:    "/restaurants",
+			allowed: true,
+		},
+		"wildcards and anchors": {
+			robots:  "User-agent: *\nDisallow: /*.json$\n",
+			path:    "/restaurants.json",
+			allowed: false,
+		},
+		"an anchored pattern only matches the end": {
+			robots:  "User-agent: *\nDisallow: /*.json$\n",
+			path:    "/restaurants.json/1",
+			allowed: true,
+		},
+		"the group of the crawler replaces the fallback": {
+			robots:  "User-agent: *\nDisallow: /\n\nUser-agent: lunchbox\nDisallow: /private/\n",
+			path:    "/restaurants",
+			allowed: true,
+		},
+		"the groups of other crawlers are ignored": {
+			robots:  "User-agent: otherbot\nDisallow: /\n",
+			path:    "/restaurants",
+			allowed: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			robots := parseRobots([]byte(tc.robots), "Lunchbox/1.0")
+			if got := robots.allowed(tc.path); got != tc.allowed {
+				t.Errorf("expected allowed to be %t for %s", tc.allowed, tc.path)
+			}
+		})
+	}
+}
+
+func TestParseRobotsCrawlDelay(t *testing.T) {
+	robots := parseRobots([]byte("User-agent: *\nCrawl-delay: 1.5\n"), "Lunchbox/1.0")
+	if robots.crawlDelay != 1500*time.Millisecond {
+		t.Errorf("expected a crawl delay of 1.5s, got %s", robots.crawlDelay)
+	}
+}
@@ -0,0 +1,88 @@
+package crawler
+
+import (
+	"os"
+	"time"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/stackus/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultInterval = 6 * time.Hour
+	defaultMaxPages = 20
+)
+
+type (
+	// SourcesConfig is the file listing the sites to crawl
+	//
+	//	sources:
+	//	  - name: eatdirectory
+	//	    url: https://eat.example.com/restaurants
+	//	    format: html
+	//	    interval: 6h
+	//	    max_pages: 10
+	SourcesConfig struct {
+		Sources []SourceConfig `yaml:"sources"`
+	}
+
+	SourceConfig struct {
+		Name     string        `yaml:"name"`
+		URL      string        `yaml:"url"`
+		Format   string        `yaml:"format"`
+		Interval time.Duration `yaml:"interval"`
+		MaxPages int           `yaml:"max_pages"`
+	}
+)
+
+func LoadSources(filename string) ([]domain.Source, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSources(data)
+}
+
+func ParseSources(data []byte) ([]domain.Source, error) {
+	var cfg SourcesConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	sources := make([]domain.Source, 0, len(cfg.Sources))
+	names := make(map[string]struct{}, len(cfg.Sources))
+	for _, source := range cfg.Sources {
+		if source.Name == "" || source.URL == "" {
+			return nil, errors.Wrap(errors.ErrBadRequest, "every source needs a name and a url")
+		}
+		if _, exists := names[source.Name]; exists {
+			return nil, errors.Wrapf(errors.ErrBadRequest, "source %q is listed more than once", source.Name)
+		}
+		names[source.Name] = struct{}{}
+
+		var parser domain.Parser
+		switch source.Format {
+		case "", "html":
+			parser = HTMLParser{}
+		case "json":
+			parser = JSONParser{}
+		default:
+			return nil, errors.Wrapf(errors.ErrBadRequest, "source %q has an unknown format %q", source.Name, source.Format)
+		}
+
+		interval := source.Interval
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+		maxPages := source.MaxPages
+		if maxPages <= 0 {
+			maxPages = defaultMaxPages
+		}
+
+		sources = append(sources, NewListingSource(source.Name, source.URL, interval, maxPages, parser))
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/stackus/errors"
+)
+
+var ErrCrawledListingNotFound = errors.Wrap(errors.ErrNotFound, "the listing has not been crawled before")
+
+// CrawledListing links a listing to the restaurant it was submitted as
+type CrawledListing struct {
+	Source       string
+	ExternalID   string
+	RestaurantID string
+	Fingerprint  string
+	CrawledAt    time.Time
+}
+
+type CrawledListingRepository interface {
+	Find(ctx context.Context, source, externalID string) (*CrawledListing, error)
+	Save(ctx context.Context, listing *CrawledListing) error
+	// MoveRestaurant points the listings of one restaurant at another
+	MoveRestaurant(ctx context.Context, restaurantID, survivorID string) error
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// KnownRestaurant is a restaurant that has already been registered
+type KnownRestaurant struct {
+	ID             string
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+}
+
+// Matches reports whether the listing would not change any of the details
+func (r KnownRestaurant) Matches(listing Listing) bool {
+	tags := slices.Clone(r.DietaryTags)
+	listingTags := slices.Clone(listing.DietaryTags)
+	slices.Sort(tags)
+	slices.Sort(listingTags)
+
+	return r.Name == listing.Name &&
+		r.Location == listing.Location &&
+		r.PricePerPerson == listing.PricePerPerson &&
+		r.Seats == listing.Seats &&
+		slices.Equal(tags, listingTags)
+}
+
+type KnownRestaurantRepository interface {
+	Save(ctx context.Context, restaurant *KnownRestaurant) error
+	Remove(ctx context.Context, restaurantID string) error
+	// FindByName returns the restaurants whose normalized name matches
+	FindByName(ctx context.Context, name string) ([]*KnownRestaurant, error)
+}
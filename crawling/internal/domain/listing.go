@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/stackus/errors"
+)
+
+var (
+	ErrListingNameIsBlank   = errors.Wrap(errors.ErrBadRequest, "the listing has no restaurant name")
+	ErrListingSourceIsBlank = errors.Wrap(errors.ErrBadRequest, "the listing has no source")
+)
+
+// Listing is a restaurant as it was found on an external listing page
+type Listing struct {
+	Source         string
+	ExternalID     string
+	URL            string
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+}
+
+func (l Listing) Validate() error {
+	if l.Source == "" {
+		return ErrListingSourceIsBlank
+	}
+	if strings.TrimSpace(l.Name) == "" {
+		return ErrListingNameIsBlank
+	}
+
+	return nil
+}
+
+// Key identifies the listing within its source; listings without an id of
+// their own are identified by their name
+func (l Listing) Key() string {
+	if l.ExternalID != "" {
+		return l.ExternalID
+	}
+	return NormalizeName(l.Name)
+}
+
+// Fingerprint changes whenever any of the details submitted to the
+// restaurants module change
+func (l Listing) Fingerprint() string {
+	tags := slices.Clone(l.DietaryTags)
+	slices.Sort(tags)
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%f|%f|%d|%s|%d",
+		strings.TrimSpace(l.Name), l.Location.Latitude, l.Location.Longitude, l.PricePerPerson, strings.Join(tags, ","), l.Seats,
+	)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeName reduces a restaurant name to lowercase letters and digits so
+// "Kim's Kitchen" and "KIMS kitchen" compare equal
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"context"
+)
+
+// RestaurantClient submits listings to the restaurants module
+type RestaurantClient interface {
+	Register(ctx context.Context, listing Listing) (string, error)
+	Update(ctx context.Context, restaurantID string, listing Listing) error
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+type (
+	// Source is anywhere restaurants can be discovered
+	Source interface {
+		Name() string
+		// Interval is how long to wait between crawls of the source
+		Interval() time.Duration
+		Crawl(ctx context.Context, fetcher Fetcher) ([]Listing, error)
+	}
+
+	// Fetcher downloads pages on behalf of a Source while honoring robots.txt
+	// and the rate limits of each host
+	Fetcher interface {
+		Fetch(ctx context.Context, pageURL string) ([]byte, error)
+	}
+
+	// Parser extracts the listings from one page, and the address of the next
+	// page when the listing is paginated
+	Parser interface {
+		Parse(page []byte, pageURL *url.URL) (listings []Listing, next string, err error)
+	}
+)
@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RestaurantClient submits listings to the restaurants module over gRPC
+//
+// When authentication is enabled the crawler calls the module with its own
+// token, which needs a role that may register and update restaurants.
+type RestaurantClient struct {
+	conn   *grpc.ClientConn
+	client restaurantspb.RestaurantsServiceClient
+	token  string
+}
+
+var _ domain.RestaurantClient = (*RestaurantClient)(nil)
+
+func NewRestaurantClient(conn *grpc.ClientConn, token string) RestaurantClient {
+	return RestaurantClient{
+		conn:   conn,
+		client: restaurantspb.NewRestaurantsServiceClient(conn),
+		token:  token,
+	}
+}
+
+// Register sends an idempotency key made from the listing so a registration
+// that is retried after timing out does not add the restaurant twice
+func (c RestaurantClient) Register(ctx context.Context, listing domain.Listing) (string, error) {
+	key := sha256.Sum256([]byte(listing.Source + "|" + listing.Key() + "|" + listing.Fingerprint()))
+	ctx = metadata.AppendToOutgoingContext(ctx, idempotency.MetadataKey, hex.EncodeToString(key[:]))
+
+	resp, err := c.client.RegisterRestaurant(c.outgoing(ctx), &restaurantspb.RegisterRestaurantRequest{
+		Name: listing.Name,
+		Location: &restaurantspb.Location{
+			Latitude:  listing.Location.Latitude,
+			Longitude: listing.Location.Longitude,
+		},
+		PricePerPerson: int64(listing.PricePerPerson),
+		DietaryTags:    listing.DietaryTags,
+		Seats:          int32(listing.Seats),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetId(), nil
+}
+
+func (c RestaurantClient) Update(ctx context.Context, restaurantID string, listing domain.Listing) error {
+	_, err := c.client.UpdateRestaurant(c.outgoing(ctx), &restaurantspb.UpdateRestaurantRequest{
+		Id:   restaurantID,
+		Name: listing.Name,
+		Location: &restaurantspb.Location{
+			Latitude:  listing.Location.Latitude,
+			Longitude: listing.Location.Longitude,
+		},
+		PricePerPerson: int64(listing.PricePerPerson),
+		DietaryTags:    listing.DietaryTags,
+		Seats:          int32(listing.Seats),
+	})
+
+	return err
+}
+
+// Close closes the connection to the restaurants module
+func (c RestaurantClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c RestaurantClient) outgoing(ctx context.Context) context.Context {
+	if c.token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+}
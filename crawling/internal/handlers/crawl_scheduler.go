@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/application"
+	"github.com/jongyunha/lunchbox/crawling/internal/application/commands"
+	"github.com/jongyunha/lunchbox/crawling/internal/constants"
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/rs/zerolog"
+)
+
+// RunCrawlSchedulerTx crawls every source right away and then again each
+// time its interval passes, until the context is cancelled
+//
+// Each listing is ingested in its own transaction so one bad listing does not
+// hold back the rest of the crawl.
+func RunCrawlSchedulerTx(ctx context.Context, container di.Container, sources []domain.Source, fetcher domain.Fetcher, logger zerolog.Logger) error {
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source domain.Source) {
+			defer wg.Done()
+			runSource(ctx, container, source, fetcher, logger)
+		}(source)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func runSource(ctx context.Context, container di.Container, source domain.Source, fetcher domain.Fetcher, logger zerolog.Logger) {
+	ticker := time.NewTicker(source.Interval())
+	defer ticker.Stop()
+
+	for {
+		crawlSource(ctx, container, source, fetcher, logger)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func crawlSource(ctx context.Context, container di.Container, source domain.Source, fetcher domain.Fetcher, logger zerolog.Logger) {
+	listings, err := source.Crawl(ctx, fetcher)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		// ingest whatever was found on the pages before the failure
+		logger.Error().Err(err).Str("Source", source.Name()).Msg("failed to crawl the source")
+	}
+
+	for _, listing := range listings {
+		if ctx.Err() != nil {
+			return
+		}
+		err = di.WithinScope(ctx, container, func(ctx context.Context) error {
+			return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey).IngestListing(ctx, commands.IngestListing{
+				Listing: listing,
+			})
+		})
+		if err != nil {
+			logger.Error().Err(err).
+				Str("Source", source.Name()).
+				Str("Listing", listing.Key()).
+				Msg("failed to ingest the listing")
+		}
+	}
+
+	logger.Debug().Str("Source", source.Name()).Int("Listings", len(listings)).Msg("crawled the source")
+}
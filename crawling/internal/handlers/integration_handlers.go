@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/constants"
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type integrationHandlers[T ddd.Event] struct {
+	restaurants domain.KnownRestaurantRepository
+	listings    domain.CrawledListingRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*integrationHandlers[ddd.Event])(nil)
+
+func NewIntegrationEventHandlers(restaurants domain.KnownRestaurantRepository, listings domain.CrawledListingRepository) ddd.EventHandler[ddd.Event] {
+	return integrationHandlers[ddd.Event]{
+		restaurants: restaurants,
+		listings:    listings,
+	}
+}
+
+// RegisterIntegrationEventHandlers has the events of different restaurants
+// handled in parallel while those of each restaurant are handled in order
+func RegisterIntegrationEventHandlers(subscriber am.MessageSubscriber, handlers am.MessageHandler) (err error) {
+	_, err = subscriber.Subscribe(restaurantspb.RestaurantAggregateChannel, handlers, am.MessageFilter{
+		restaurantspb.RestaurantRegisteredEvent,
+		restaurantspb.RestaurantUpdatedEvent,
+		restaurantspb.RestaurantMergedEvent,
+	}, am.GroupName("crawling-restaurants"), am.Ordered{}, am.PullConsumer{Workers: 4})
+	return err
+}
+
+func RegisterIntegrationEventHandlersTx(container di.Container) error {
+	evtMsgHandler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		return di.WithinScope(ctx, container, func(ctx context.Context) error {
+			return di.MustGet[am.MessageHandler](di.FromContext(ctx), constants.IntegrationEventHandlersKey).HandleMessage(ctx, msg)
+		})
+	})
+
+	subscriber := di.MustGet[am.MessageSubscriber](container, constants.MessageSubscriberKey)
+
+	return RegisterIntegrationEventHandlers(subscriber, evtMsgHandler)
+}
+
+func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling integration event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled integration event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	span.AddEvent("Handling integration event", trace.WithAttributes(
+		attribute.String("Event", event.EventName()),
+	))
+
+	switch event.EventName() {
+	case restaurantspb.RestaurantRegisteredEvent:
+		return h.onRestaurantRegistered(ctx, event)
+	case restaurantspb.RestaurantUpdatedEvent:
+		return h.onRestaurantUpdated(ctx, event)
+	case restaurantspb.RestaurantMergedEvent:
+		return h.onRestaurantMerged(ctx, event)
+	}
+
+	return nil
+}
+
+func (h integrationHandlers[T]) onRestaurantRegistered(ctx context.Context, event T) error {
+	payload := event.Payload().(*restaurantspb.RestaurantRegistered)
+	return h.restaurants.Save(ctx, &domain.KnownRestaurant{
+		ID:   payload.GetId(),
+		Name: payload.GetName(),
+		Location: domain.Location{
+			Latitude:  payload.GetLatitude(),
+			Longitude: payload.GetLongitude(),
+		},
+		PricePerPerson: int(payload.GetPricePerPerson()),
+		DietaryTags:    payload.GetDietaryTags(),
+		Seats:          int(payload.GetSeats()),
+	})
+}
+
+func (h integrationHandlers[T]) onRestaurantUpdated(ctx context.Context, event T) error {
+	payload := event.Payload().(*restaurantspb.RestaurantUpdated)
+	return h.restaurants.Save(ctx, &domain.KnownRestaurant{
+		ID:   payload.GetId(),
+		Name: payload.GetName(),
+		Location: domain.Location{
+			Latitude:  payload.GetLatitude(),
+			Longitude: payload.GetLongitude(),
+		},
+		PricePerPerson: int(payload.GetPricePerPerson()),
+		DietaryTags:    payload.GetDietaryTags(),
+		Seats:          int(payload.GetSeats()),
+	})
+}
+
+// onRestaurantMerged keeps the listings of the merged restaurant updating the
+// survivor and stops new listings from matching the merged restaurant
+func (h integrationHandlers[T]) onRestaurantMerged(ctx context.Context, event T) error {
+	payload := event.Payload().(*restaurantspb.RestaurantMerged)
+
+	if err := h.listings.MoveRestaurant(ctx, payload.GetId(), payload.GetSurvivorId()); err != nil {
+		return err
+	}
+
+	return h.restaurants.Remove(ctx, payload.GetId())
+}
@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE crawling.restaurants (
+  id               text             NOT NULL,
+  name             text             NOT NULL,
+  normalized_name  text             NOT NULL,
+  latitude         double precision NOT NULL DEFAULT 0,
+  longitude        double precision NOT NULL DEFAULT 0,
+  price_per_person int              NOT NULL DEFAULT 0,
+  dietary_tags     text[]           NOT NULL DEFAULT '{}',
+  seats            int              NOT NULL DEFAULT 0,
+  created_at       timestamptz      NOT NULL DEFAULT NOW(),
+  updated_at       timestamptz      NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX restaurants_normalized_name_idx ON crawling.restaurants (normalized_name);
+
+CREATE TRIGGER created_at_restaurants_trgr
+  BEFORE UPDATE
+  ON crawling.restaurants
+  FOR EACH ROW EXECUTE PROCEDURE created_at_trigger();
+
+CREATE TRIGGER updated_at_restaurants_trgr
+  BEFORE UPDATE
+  ON crawling.restaurants
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE crawling.listings (
+  source        text        NOT NULL,
+  external_id   text        NOT NULL,
+  restaurant_id text        NOT NULL,
+  fingerprint   text        NOT NULL,
+  crawled_at    timestamptz NOT NULL,
+  PRIMARY KEY (source, external_id)
+);
+
+CREATE TABLE crawling.inbox (
+  id          text        NOT NULL,
+  name        text        NOT NULL,
+  subject     text        NOT NULL,
+  data        bytea       NOT NULL,
+  metadata    bytea       NOT NULL,
+  sent_at     timestamptz NOT NULL,
+  received_at timestamptz NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- +goose Down
+DROP TABLE crawling.inbox, crawling.listings, crawling.restaurants;
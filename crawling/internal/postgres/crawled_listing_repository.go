@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/stackus/errors"
+)
+
+type CrawledListingRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.CrawledListingRepository = (*CrawledListingRepository)(nil)
+
+func NewCrawledListingRepository(tableName string, db postgres.DBTX) CrawledListingRepository {
+	return CrawledListingRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r CrawledListingRepository) Find(ctx context.Context, source, externalID string) (*domain.CrawledListing, error) {
+	const query = `SELECT restaurant_id, fingerprint, crawled_at FROM %s WHERE source = $1 AND external_id = $2`
+
+	listing := &domain.CrawledListing{
+		Source:     source,
+		ExternalID: externalID,
+	}
+
+	err := r.db.QueryRow(ctx, r.table(query), source, externalID).Scan(
+		&listing.RestaurantID, &listing.Fingerprint, &listing.CrawledAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCrawledListingNotFound
+		}
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+func (r CrawledListingRepository) Save(ctx context.Context, listing *domain.CrawledListing) error {
+	const query = `INSERT INTO %s (source, external_id, restaurant_id, fingerprint, crawled_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (source, external_id) DO UPDATE SET
+  restaurant_id = EXCLUDED.restaurant_id, fingerprint = EXCLUDED.fingerprint, crawled_at = EXCLUDED.crawled_at`
+
+	_, err := r.db.Exec(ctx, r.table(query),
+		listing.Source, listing.ExternalID, listing.RestaurantID, listing.Fingerprint, listing.CrawledAt,
+	)
+
+	return err
+}
+
+func (r CrawledListingRepository) MoveRestaurant(ctx context.Context, restaurantID, survivorID string) error {
+	const query = "UPDATE %s SET restaurant_id = $2 WHERE restaurant_id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), restaurantID, survivorID)
+
+	return err
+}
+
+func (r CrawledListingRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
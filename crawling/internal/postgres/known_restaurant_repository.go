@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+)
+
+type KnownRestaurantRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.KnownRestaurantRepository = (*KnownRestaurantRepository)(nil)
+
+func NewKnownRestaurantRepository(tableName string, db postgres.DBTX) KnownRestaurantRepository {
+	return KnownRestaurantRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r KnownRestaurantRepository) Save(ctx context.Context, restaurant *domain.KnownRestaurant) error {
+	const query = `INSERT INTO %s (id, name, normalized_name, latitude, longitude, price_per_person, dietary_tags, seats)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (id) DO UPDATE SET
+  name = EXCLUDED.name, normalized_name = EXCLUDED.normalized_name, latitude = EXCLUDED.latitude,
+  longitude = EXCLUDED.longitude, price_per_person = EXCLUDED.price_per_person,
+  dietary_tags = EXCLUDED.dietary_tags, seats = EXCLUDED.seats`
+
+	_, err := r.db.Exec(ctx, r.table(query),
+		restaurant.ID, restaurant.Name, domain.NormalizeName(restaurant.Name),
+		restaurant.Location.Latitude, restaurant.Location.Longitude,
+		restaurant.PricePerPerson, nonNil(restaurant.DietaryTags), restaurant.Seats,
+	)
+
+	return err
+}
+
+func (r KnownRestaurantRepository) Remove(ctx context.Context, restaurantID string) error {
+	const query = "DELETE FROM %s WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), restaurantID)
+
+	return err
+}
+
+func (r KnownRestaurantRepository) FindByName(ctx context.Context, name string) ([]*domain.KnownRestaurant, error) {
+	const query = `SELECT id, name, latitude, longitude, price_per_person, dietary_tags, seats
+FROM %s WHERE normalized_name = $1`
+
+	rows, err := r.db.Query(ctx, r.table(query), domain.NormalizeName(name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurants []*domain.KnownRestaurant
+	for rows.Next() {
+		restaurant := &domain.KnownRestaurant{}
+		err := rows.Scan(
+			&restaurant.ID, &restaurant.Name, &restaurant.Location.Latitude, &restaurant.Location.Longitude,
+			&restaurant.PricePerPerson, &restaurant.DietaryTags, &restaurant.Seats,
+		)
+		if err != nil {
+			return nil, err
+		}
+		restaurants = append(restaurants, restaurant)
+	}
+
+	return restaurants, rows.Err()
+}
+
+func (r KnownRestaurantRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
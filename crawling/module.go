@@ -2,13 +2,171 @@ package crawling
 
 import (
 	"context"
+	"net/http"
+	"time"
 
-	"github.com/jongyunha/lunchbox/internal/monolith"
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/crawling/internal/application"
+	"github.com/jongyunha/lunchbox/crawling/internal/constants"
+	"github.com/jongyunha/lunchbox/crawling/internal/crawler"
+	"github.com/jongyunha/lunchbox/crawling/internal/domain"
+	"github.com/jongyunha/lunchbox/crawling/internal/grpc"
+	"github.com/jongyunha/lunchbox/crawling/internal/handlers"
+	"github.com/jongyunha/lunchbox/crawling/internal/migrations"
+	"github.com/jongyunha/lunchbox/crawling/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/amotel"
+	"github.com/jongyunha/lunchbox/internal/amprom"
+	"github.com/jongyunha/lunchbox/internal/di"
+	pg "github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/postgresotel"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/rpc"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/jongyunha/lunchbox/internal/tm"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/rs/zerolog"
 )
 
+// fetchTimeout bounds how long a single page may take to download
+const fetchTimeout = 30 * time.Second
+
 type Module struct{}
 
-func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) error {
-	//domainDispatcher := ddd.NewEventDispatcher[ddd.Event]()
+func (m *Module) Startup(ctx context.Context, svc system.Service) (err error) {
+	return Root(ctx, svc)
+}
+
+// Migrations returns the migrations of the module schema
+func (m *Module) Migrations() pg.Migrations {
+	return pg.Migrations{
+		Schema: constants.ServiceName,
+		FS:     migrations.FS,
+	}
+}
+
+// Registry returns a registry with everything the module serializes
+func (m *Module) Registry() (registry.Registry, error) {
+	return newRegistry()
+}
+
+// Projections returns the read models that can be rebuilt from the event store
+func (m *Module) Projections() []pg.Projection {
+	return nil
+}
+
+func Root(ctx context.Context, svc system.Service) (err error) {
+	container := di.New()
+	cfg := svc.Config().Crawling
+
+	// setup Driven adapters
+	di.Register(container, di.Singleton, constants.RegistryKey, func(c di.Container) (registry.Registry, error) {
+		return newRegistry()
+	})
+
+	stream := svc.Stream()
+
+	di.Register(container, di.Singleton, constants.MessageSubscriberKey, func(c di.Container) (am.MessageSubscriber, error) {
+		return am.NewMessageSubscriber(
+			stream,
+			amotel.OtelMessageContextExtractor(),
+			amprom.ReceivedMessagesCounter(constants.ServiceName),
+		), nil
+	})
+
+	di.Register(container, di.Singleton, constants.RestaurantClientKey, func(c di.Container) (domain.RestaurantClient, error) {
+		conn, err := rpc.Dial(ctx, svc.Config().Rpc.Service(constants.RestaurantsServiceName))
+		if err != nil {
+			return nil, err
+		}
+		return grpc.NewRestaurantClient(conn, cfg.AuthToken), nil
+	}, di.Finalizer(func(_ context.Context, client any, _ error) error {
+		return client.(grpc.RestaurantClient).Close()
+	}))
+
+	di.Register(container, di.Scoped, constants.DatabaseTransactionKey, func(c di.Container) (pgx.Tx, error) {
+		return svc.DB().Begin(context.Background())
+	}, di.Finalizer(pg.EndTransaction(svc.Logger())))
+
+	di.Register(container, di.Scoped, constants.InboxStoreKey, func(c di.Container) (tm.InboxStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewInboxStore(constants.ServiceName+".inbox", tx), nil
+	})
+
+	di.Register(container, di.Scoped, constants.KnownRestaurantsRepoKey, func(c di.Container) (domain.KnownRestaurantRepository, error) {
+		return postgres.NewKnownRestaurantRepository(
+			constants.ServiceName+".restaurants",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.CrawledListingsRepoKey, func(c di.Container) (domain.CrawledListingRepository, error) {
+		return postgres.NewCrawledListingRepository(
+			constants.ServiceName+".listings",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.ApplicationKey, func(c di.Container) (application.App, error) {
+		return application.New(
+			di.MustGet[domain.CrawledListingRepository](c, constants.CrawledListingsRepoKey),
+			di.MustGet[domain.KnownRestaurantRepository](c, constants.KnownRestaurantsRepoKey),
+			di.MustGet[domain.RestaurantClient](c, constants.RestaurantClientKey),
+			cfg.MatchDistance,
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.IntegrationEventHandlersKey, func(c di.Container) (am.MessageHandler, error) {
+		return am.NewEventHandler(
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			handlers.NewIntegrationEventHandlers(
+				di.MustGet[domain.KnownRestaurantRepository](c, constants.KnownRestaurantsRepoKey),
+				di.MustGet[domain.CrawledListingRepository](c, constants.CrawledListingsRepoKey),
+			),
+			tm.InboxHandler(di.MustGet[tm.InboxStore](c, constants.InboxStoreKey)),
+		), nil
+	})
+
+	if err = container.Validate(ctx); err != nil {
+		return err
+	}
+	svc.Waiter().Cleanup(func() {
+		_ = container.Close(context.Background())
+	})
+
+	// setup Driver adapters
+	if err = handlers.RegisterIntegrationEventHandlersTx(container); err != nil {
+		return err
+	}
+
+	// nothing is crawled until the sites to crawl have been configured
+	if cfg.SourcesFile == "" {
+		return nil
+	}
+	sources, err := crawler.LoadSources(cfg.SourcesFile)
+	if err != nil {
+		return err
+	}
+	fetcher := crawler.NewFetcher(&http.Client{Timeout: fetchTimeout}, cfg.UserAgent, cfg.RequestDelay)
+	startCrawlScheduler(ctx, container, sources, fetcher, svc.Logger())
+
 	return nil
 }
+
+func startCrawlScheduler(ctx context.Context, container di.Container, sources []domain.Source, fetcher domain.Fetcher, logger zerolog.Logger) {
+	go func() {
+		err := handlers.RunCrawlSchedulerTx(ctx, container, sources, fetcher, logger)
+		if err != nil {
+			logger.Error().Err(err).Msg("crawling scheduler encountered an error")
+		}
+	}()
+}
+
+// newRegistry registers everything the module serializes
+func newRegistry() (reg registry.Registry, err error) {
+	reg = registry.New()
+	if err = restaurantspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
@@ -1,7 +1,10 @@
 package am
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
 )
 
 type AckType int
@@ -13,6 +16,7 @@ const (
 
 var defaultAckWait = 30 * time.Second
 var defaultMaxRedeliver = 5
+var defaultPullBatchSize = 10
 
 type SubscriberConfig struct {
 	msgFilter    []string
@@ -20,6 +24,8 @@ type SubscriberConfig struct {
 	ackType      AckType
 	ackWait      time.Duration
 	maxRedeliver int
+	pull         *PullConsumer
+	ordered      *Ordered
 }
 
 func NewSubscriberConfig(options []SubscriberOption) SubscriberConfig {
@@ -62,6 +68,24 @@ func (c SubscriberConfig) MaxRedeliver() int {
 	return c.maxRedeliver
 }
 
+// PullConsumer returns the settings of a pull consumer, or false when the
+// messages are to be pushed to the subscriber
+func (c SubscriberConfig) PullConsumer() (PullConsumer, bool) {
+	if c.pull == nil {
+		return PullConsumer{}, false
+	}
+	return *c.pull, true
+}
+
+// Ordered returns how the messages are partitioned, or false when they may
+// be handled in any order
+func (c SubscriberConfig) Ordered() (Ordered, bool) {
+	if c.ordered == nil {
+		return Ordered{}, false
+	}
+	return *c.ordered, true
+}
+
 type MessageFilter []string
 
 func (s MessageFilter) configureSubscriberConfig(cfg *SubscriberConfig) {
@@ -89,3 +113,82 @@ type MaxRedeliver int
 func (i MaxRedeliver) configureSubscriberConfig(cfg *SubscriberConfig) {
 	cfg.maxRedeliver = int(i)
 }
+
+// PullConsumer has the subscriber fetch its messages in batches and handle
+// them with a fixed number of workers, rather than have every message pushed
+// to it handled as soon as it arrives
+//
+// No more than MaxInFlight messages are fetched and not yet handled at any
+// time; the messages of a handler that takes longer than the AckWait are kept
+// from being delivered again for as long as it runs. Zero values take the
+// defaults: a BatchSize of 10, a single worker and as many messages in flight
+// as the larger of the two. Streams that have no pull consumers only take the
+// number of workers.
+type PullConsumer struct {
+	BatchSize   int
+	MaxInFlight int
+	Workers     int
+}
+
+func (p PullConsumer) configureSubscriberConfig(cfg *SubscriberConfig) {
+	if p.BatchSize <= 0 {
+		p.BatchSize = defaultPullBatchSize
+	}
+	if p.Workers <= 0 {
+		p.Workers = 1
+	}
+	if p.MaxInFlight <= 0 {
+		p.MaxInFlight = max(p.BatchSize, p.Workers)
+	}
+	cfg.pull = &p
+}
+
+// GapPolicy is what becomes of a message that skips one or more versions of
+// its partition
+type GapPolicy int
+
+const (
+	// GapRetry NAcks the message after a short wait so it is delivered again,
+	// hopefully after the versions missing
+	GapRetry GapPolicy = iota
+	// GapPark kills the message straight away, leaving it with the dead
+	// letters to be re-driven
+	GapPark
+)
+
+// Ordered has the messages that share the value of the PartitionKey metadata
+// handled one at a time in the order they are delivered, while the messages
+// of different partitions are still handled in parallel; messages without the
+// key are handled in any order
+//
+// A message that is NAck'd, or not Ack'd within its AckWait, is handled again
+// before the rest of its partition, until it runs out of redeliveries. The
+// JetStream stream only takes Ordered along with a PullConsumer and keeps the
+// order within each subscriber; the subscribers of a group each fetch their
+// own share of a partition.
+//
+// The PartitionKey defaults to the aggregate id. When a VersionKey is given as
+// well, the versions of each partition are checked by VersionCheck; leave it
+// blank for subscriptions with a MessageFilter, as the versions filtered out
+// would show up as gaps.
+type Ordered struct {
+	PartitionKey string
+	VersionKey   string
+	Gaps         GapPolicy
+}
+
+func (o Ordered) configureSubscriberConfig(cfg *SubscriberConfig) {
+	if o.PartitionKey == "" {
+		o.PartitionKey = ddd.AggregateIDKey
+	}
+	cfg.ordered = &o
+}
+
+// Partition returns the partition of the message; messages without the
+// partition key are each given a partition of their own
+func (o Ordered) Partition(msg MessageBase) string {
+	if key := msg.Metadata().Get(o.PartitionKey); key != nil && key != "" {
+		return fmt.Sprintf("key:%v", key)
+	}
+	return "id:" + msg.ID()
+}
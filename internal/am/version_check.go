@@ -0,0 +1,105 @@
+package am
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stackus/errors"
+)
+
+// versionGapRetryDelay is how long a message with a gap before it is held
+// before it is NAck'd, giving the versions missing a chance to be handled
+const versionGapRetryDelay = time.Second
+
+var ErrVersionGap = errors.Wrap(errors.ErrFailedPrecondition, "the message skips one or more versions")
+
+type versionCheck struct {
+	ordered Ordered
+	next    MessageHandler
+	mu      sync.Mutex
+	// the version expected next for each partition
+	expected map[string]int
+}
+
+// VersionCheck has the handler see the versions of each partition in order
+// when the Ordered subscription has a VersionKey; without one it does nothing
+//
+// A partition expects the version of the first message of it that is
+// received, then each version after the one last handled. A version that has
+// already been handled is Ack'd without being handled again; one that skips
+// versions is NAck'd or killed according to the GapPolicy. Messages without a
+// version are handled as they come. The versions are only tracked for as long
+// as the subscriber runs.
+func VersionCheck(ordered Ordered) MessageHandlerMiddleware {
+	return func(next MessageHandler) MessageHandler {
+		if ordered.VersionKey == "" {
+			return next
+		}
+		return &versionCheck{
+			ordered:  ordered,
+			next:     next,
+			expected: make(map[string]int),
+		}
+	}
+}
+
+func (h *versionCheck) HandleMessage(ctx context.Context, msg IncomingMessage) error {
+	version, ok := messageVersion(msg.Metadata().Get(h.ordered.VersionKey))
+	if !ok {
+		return h.next.HandleMessage(ctx, msg)
+	}
+
+	partition := h.ordered.Partition(msg)
+
+	h.mu.Lock()
+	expected, exists := h.expected[partition]
+	if !exists {
+		expected = version
+		h.expected[partition] = version
+	}
+	h.mu.Unlock()
+
+	switch {
+	case version < expected:
+		return nil
+	case version > expected:
+		if h.ordered.Gaps == GapPark {
+			return msg.Kill()
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(versionGapRetryDelay):
+		}
+		return errors.Wrapf(ErrVersionGap, "expected version %d of %s, got version %d", expected, partition, version)
+	}
+
+	if err := h.next.HandleMessage(ctx, msg); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	if h.expected[partition] == version {
+		h.expected[partition] = version + 1
+	}
+	h.mu.Unlock()
+
+	return nil
+}
+
+// messageVersion reads a version that may have come through JSON or
+// protobuf as a float
+func messageVersion(v any) (int, bool) {
+	switch version := v.(type) {
+	case int:
+		return version, true
+	case int32:
+		return int(version), true
+	case int64:
+		return int(version), true
+	case float64:
+		return int(version), true
+	default:
+		return 0, false
+	}
+}
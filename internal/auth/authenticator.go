@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stackus/errors"
+)
+
+type Authenticator struct {
+	enabled bool
+	keys    *KeySet
+	policy  Policy
+	parser  *jwt.Parser
+}
+
+// New creates an Authenticator; when auth is disabled every call is allowed
+func New(cfg AuthConfig) (*Authenticator, error) {
+	if !cfg.Enabled {
+		return &Authenticator{}, nil
+	}
+
+	if cfg.JWKSFile == "" {
+		return nil, errors.ErrBadRequest.Msg("AUTH_JWKS_FILE is required when auth is enabled")
+	}
+	keys, err := LoadKeySet(cfg.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Policy
+	if cfg.PolicyFile != "" {
+		if policy, err = LoadPolicy(cfg.PolicyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewAuthenticator(keys, policy, cfg.Issuer, cfg.Audience), nil
+}
+
+func NewAuthenticator(keys *KeySet, policy Policy, issuer, audience string) *Authenticator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &Authenticator{
+		enabled: true,
+		keys:    keys,
+		policy:  policy,
+		parser:  jwt.NewParser(options...),
+	}
+}
+
+// Authenticate verifies an Authorization header value of the form "Bearer <token>"
+func (a *Authenticator) Authenticate(authorization string) (*Claims, error) {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, errors.ErrUnauthenticated.Msg("expected a bearer token")
+	}
+
+	claims := &Claims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.keys.Keyfunc); err != nil {
+		return nil, errors.ErrUnauthenticated.Err(err)
+	}
+
+	return claims, nil
+}
+
+// Authorize checks the caller against the policy for the method and returns
+// a context carrying the caller's claims
+func (a *Authenticator) Authorize(ctx context.Context, fullMethod, authorization string) (context.Context, error) {
+	if !a.enabled {
+		return ctx, nil
+	}
+
+	if authorization == "" {
+		if a.policy.IsPublic(fullMethod) {
+			return ctx, nil
+		}
+		return ctx, errors.ErrUnauthenticated.Msg("a bearer token is required")
+	}
+
+	claims, err := a.Authenticate(authorization)
+	if err != nil {
+		return ctx, err
+	}
+
+	if !a.policy.IsPublic(fullMethod) {
+		if roles := a.policy.Roles(fullMethod); len(roles) > 0 && !claims.HasAnyRole(roles...) {
+			return ctx, errors.ErrPermissionDenied.Msgf("%s requires one of the roles %s", fullMethod, strings.Join(roles, ", "))
+		}
+	}
+
+	return WithClaims(ctx, claims), nil
+}
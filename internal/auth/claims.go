@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stackus/errors"
+)
+
+// AdminRole may act on behalf of other users
+const AdminRole = "admin"
+
+type contextKey int
+
+const claimsKey contextKey = iota
+
+// Claims are the verified contents of a bearer token
+type Claims struct {
+	jwt.RegisteredClaims
+	Name  string   `json:"name,omitempty"`
+	Email string   `json:"email,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// HasAnyRole reports whether the claims carry at least one of the roles
+func (c Claims) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		if slices.Contains(c.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// ClaimsFromContext returns the claims of the authenticated caller if there is one
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*Claims)
+	return claims, ok
+}
+
+// ActingUser returns the user a call is made by
+//
+// An authenticated caller acts as the subject of their token; a userID from
+// the request that names someone else is only accepted from an admin. Without
+// claims, auth is disabled or the method is public, and the userID is used as
+// it is.
+func ActingUser(ctx context.Context, userID string) (string, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.Subject == "" {
+		return userID, nil
+	}
+
+	if userID == "" || userID == claims.Subject {
+		return claims.Subject, nil
+	}
+	if claims.HasAnyRole(AdminRole) {
+		return userID, nil
+	}
+
+	return "", errors.ErrPermissionDenied.Msgf("cannot act on behalf of user %q", userID)
+}
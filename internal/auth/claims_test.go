@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stackus/errors"
+)
+
+func TestActingUser(t *testing.T) {
+	user := &Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}}
+	admin := &Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "admin-1"}, Roles: []string{AdminRole}}
+
+	tests := map[string]struct {
+		claims  *Claims
+		userID  string
+		want    string
+		wantErr error
+	}{
+		"without claims the user id is used as is":     {userID: "user-2", want: "user-2"},
+		"the subject is used when no user id is given": {claims: user, want: "user-1"},
+		"the subject may name themselves":              {claims: user, userID: "user-1", want: "user-1"},
+		"another user is rejected":                     {claims: user, userID: "user-2", wantErr: errors.ErrPermissionDenied},
+		"an admin may act for another user":            {claims: admin, userID: "user-2", want: "user-2"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.claims != nil {
+				ctx = WithClaims(ctx, tc.claims)
+			}
+
+			got, err := ActingUser(ctx, tc.userID)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
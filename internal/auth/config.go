@@ -0,0 +1,9 @@
+package auth
+
+type AuthConfig struct {
+	Enabled    bool   `default:"false" envconfig:"AUTH_ENABLED"`
+	JWKSFile   string `envconfig:"AUTH_JWKS_FILE"`
+	PolicyFile string `envconfig:"AUTH_POLICY_FILE"`
+	Issuer     string `envconfig:"AUTH_ISSUER"`
+	Audience   string `envconfig:"AUTH_AUDIENCE"`
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stackus/errors"
+)
+
+// Middleware rejects requests carrying an invalid bearer token and puts the
+// claims of valid ones into the request context
+//
+// Requests without a token pass through; the RPCs behind the gateway apply
+// the policy themselves, and Protect guards any route served outside of it.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if !a.enabled || authorization == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := a.Authenticate(authorization)
+		if err != nil {
+			http.Error(w, err.Error(), errors.HTTPCode(err))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+	})
+}
+
+// Protect applies the policy of an RPC to a route that does not go through the gateway
+func (a *Authenticator) Protect(fullMethod string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := a.Authorize(r.Context(), fullMethod, r.Header.Get("Authorization"))
+			if err != nil {
+				http.Error(w, err.Error(), errors.HTTPCode(err))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GatewayHeaderMatcher forwards headers to the RPCs behind the gateway
+//
+// The gateway always passes the Authorization header on as "authorization"
+// metadata, which is what the interceptors read; this keeps it from also
+// being copied as "grpcgateway-authorization".
+func GatewayHeaderMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == "Authorization" {
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stackus/errors"
+)
+
+type (
+	jsonWebKey struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		K   string `json:"k"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}
+
+	jsonWebKeySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	verificationKey struct {
+		alg string
+		key any
+	}
+
+	// KeySet holds the keys tokens may be signed with
+	//
+	// Symmetric "oct" keys verify HS256 tokens and "RSA" keys verify RS256 tokens.
+	KeySet struct {
+		keys map[string]verificationKey
+	}
+)
+
+// LoadKeySet reads a JSON Web Key Set from a local file
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading the jwks file")
+	}
+
+	return ParseKeySet(data)
+}
+
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, errors.Wrap(err, "decoding the jwks file")
+	}
+
+	ks := &KeySet{keys: make(map[string]verificationKey, len(set.Keys))}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.verificationKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "key %q", jwk.Kid)
+		}
+		ks.keys[jwk.Kid] = key
+	}
+	if len(ks.keys) == 0 {
+		return nil, errors.ErrBadRequest.Msg("the jwks file does not contain any signing keys")
+	}
+
+	return ks, nil
+}
+
+// Keyfunc picks the key for a token using its "kid" header
+//
+// Tokens without a "kid" are accepted when the set holds a single key.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, exists := ks.keys[kid]
+	if !exists && kid == "" && len(ks.keys) == 1 {
+		for _, key = range ks.keys {
+			exists = true
+		}
+	}
+	if !exists {
+		return nil, errors.ErrUnauthenticated.Msgf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.alg {
+		return nil, errors.ErrUnauthenticated.Msgf("key %q cannot verify %s tokens", kid, token.Method.Alg())
+	}
+
+	return key.key, nil
+}
+
+func (jwk jsonWebKey) verificationKey() (verificationKey, error) {
+	switch jwk.Kty {
+	case "oct":
+		if jwk.Alg != "" && jwk.Alg != jwt.SigningMethodHS256.Alg() {
+			return verificationKey{}, errors.ErrBadRequest.Msgf("unsupported algorithm %s", jwk.Alg)
+		}
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil {
+			return verificationKey{}, errors.Wrap(err, "decoding the secret")
+		}
+		return verificationKey{alg: jwt.SigningMethodHS256.Alg(), key: secret}, nil
+	case "RSA":
+		if jwk.Alg != "" && jwk.Alg != jwt.SigningMethodRS256.Alg() {
+			return verificationKey{}, errors.ErrBadRequest.Msgf("unsupported algorithm %s", jwk.Alg)
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return verificationKey{}, errors.Wrap(err, "decoding the modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return verificationKey{}, errors.Wrap(err, "decoding the exponent")
+		}
+		return verificationKey{
+			alg: jwt.SigningMethodRS256.Alg(),
+			key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		}, nil
+	default:
+		return verificationKey{}, errors.ErrBadRequest.Msgf("unsupported key type %q", jwk.Kty)
+	}
+}
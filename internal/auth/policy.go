@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"os"
+	"path"
+	"slices"
+
+	"github.com/stackus/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy decides which roles may call each RPC
+//
+// Methods are full gRPC method names such as
+// "/restaurantspb.RestaurantsService/RegisterRestaurant"; a method of "*" such
+// as "/restaurantspb.RestaurantsService/*" covers a whole service. Public
+// methods may be called without a token. Any other method requires a valid
+// token, and when roles are listed for it, one of those roles.
+//
+//	public:
+//	  - /restaurantspb.RestaurantsService/GetRestaurant
+//	methods:
+//	  /restaurantspb.RestaurantsService/RegisterRestaurant: [admin]
+type Policy struct {
+	Public  []string            `yaml:"public"`
+	Methods map[string][]string `yaml:"methods"`
+}
+
+// alwaysPublic are the methods every deployment leaves open; the health
+// checks are probed without a token
+var alwaysPublic = []string{
+	"/grpc.health.v1.Health/*",
+	"/grpc.reflection.v1.ServerReflection/*",
+	"/grpc.reflection.v1alpha.ServerReflection/*",
+}
+
+func LoadPolicy(filename string) (Policy, error) {
+	var policy Policy
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return policy, errors.Wrap(err, "reading the policy file")
+	}
+	if err = yaml.Unmarshal(data, &policy); err != nil {
+		return policy, errors.Wrap(err, "decoding the policy file")
+	}
+
+	return policy, nil
+}
+
+func (p Policy) IsPublic(fullMethod string) bool {
+	return slices.Contains(p.Public, fullMethod) ||
+		slices.Contains(p.Public, serviceWildcard(fullMethod)) ||
+		slices.Contains(alwaysPublic, serviceWildcard(fullMethod))
+}
+
+// Roles returns the roles allowed to call the method; none means any caller
+func (p Policy) Roles(fullMethod string) []string {
+	if roles, exists := p.Methods[fullMethod]; exists {
+		return roles
+	}
+	return p.Methods[serviceWildcard(fullMethod)]
+}
+
+func serviceWildcard(fullMethod string) string {
+	return path.Join(path.Dir(fullMethod), "*")
+}
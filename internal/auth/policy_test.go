@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestPolicyIsPublic(t *testing.T) {
+	policy := Policy{
+		Public: []string{
+			"/restaurantspb.RestaurantsService/GetRestaurant",
+			"/reviewspb.ReviewsService/*",
+		},
+	}
+
+	tests := map[string]bool{
+		"/restaurantspb.RestaurantsService/GetRestaurant":           true,
+		"/restaurantspb.RestaurantsService/RegisterRestaurant":      false,
+		"/reviewspb.ReviewsService/ListRestaurantReviews":           true,
+		"/grpc.health.v1.Health/Check":                              true,
+		"/grpc.health.v1.Health/Watch":                              true,
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo": true,
+	}
+	for method, want := range tests {
+		if got := policy.IsPublic(method); got != want {
+			t.Errorf("expected IsPublic(%q) to be %t", method, want)
+		}
+	}
+}
@@ -1,15 +1,25 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"time"
 
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/health"
 	"github.com/jongyunha/lunchbox/internal/rpc"
 	"github.com/jongyunha/lunchbox/internal/web"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/stackus/dotenv"
 )
 
+// the stream backends STREAM_BACKEND can be set to
+const (
+	JetStreamBackend = "jetstream"
+	PostgresBackend  = "postgres"
+	MemoryBackend    = "memory"
+)
+
 type (
 	PGConfig struct {
 		User              string `required:"true" envconfig:"PG_USER"`
@@ -23,24 +33,86 @@ type (
 		MaxConnLifetime   int    `default:"3600" envconfig:"PG_MAX_CONN_LIFETIME"`
 		MaxConnIdleTime   int    `default:"1800" envconfig:"PG_MAX_CONN_IDLE_TIME"`
 		HealthCheckPeriod int    `default:"60" envconfig:"PG_HEALTH_CHECK_PERIOD"`
+		AutoMigrate       bool   `default:"true" envconfig:"PG_AUTO_MIGRATE"`
 	}
 
 	NatsConfig struct {
-		URL    string `required:"true"`
+		// URL is required unless the server is embedded
+		URL    string
 		Stream string `default:"lunchbox"`
+		// Embedded starts a NATS server within the process instead of
+		// connecting to the one at URL; the streams are kept in StoreDir, or
+		// in a temporary directory when it is blank
+		Embedded     bool   `envconfig:"NATS_EMBEDDED"`
+		EmbeddedPort int    `default:"-1" envconfig:"NATS_EMBEDDED_PORT"`
+		StoreDir     string `envconfig:"NATS_STORE_DIR"`
+		// SpecsFile describes the stream and the durable consumers of the
+		// groups; FailOnDrift stops the start when either differs from its
+		// spec in a way that cannot be changed in place, rather than logging it
+		SpecsFile   string `envconfig:"NATS_SPECS_FILE"`
+		FailOnDrift bool   `envconfig:"NATS_FAIL_ON_DRIFT"`
+		// WireFormat is "headers", or "envelope" to keep publishing messages
+		// wrapped in a StreamMessage until every subscriber decodes headers
+		WireFormat string `default:"headers" envconfig:"NATS_WIRE_FORMAT"`
+	}
+
+	StreamConfig struct {
+		// Backend is "jetstream", "postgres" or "memory"; the memory stream
+		// only carries messages between the modules running in the same process
+		Backend string `default:"jetstream" envconfig:"STREAM_BACKEND"`
+	}
+
+	RestaurantsConfig struct {
+		DuplicateThreshold   float64 `default:"0.8" envconfig:"RESTAURANTS_DUPLICATE_THRESHOLD"`
+		DuplicateMaxDistance float64 `default:"200" envconfig:"RESTAURANTS_DUPLICATE_MAX_DISTANCE"`
+	}
+
+	RecommendationsConfig struct {
+		RatingWeight     float64 `default:"0.5" envconfig:"RECOMMENDATIONS_RATING_WEIGHT"`
+		DistanceWeight   float64 `default:"0.3" envconfig:"RECOMMENDATIONS_DISTANCE_WEIGHT"`
+		BudgetWeight     float64 `default:"0.2" envconfig:"RECOMMENDATIONS_BUDGET_WEIGHT"`
+		MaxDistance      float64 `default:"2000" envconfig:"RECOMMENDATIONS_MAX_DISTANCE"`
+		AvoidVisitedDays int     `default:"7" envconfig:"RECOMMENDATIONS_AVOID_VISITED_DAYS"`
+	}
+
+	CrawlingConfig struct {
+		SourcesFile   string        `envconfig:"CRAWLING_SOURCES_FILE"`
+		UserAgent     string        `default:"lunchbox-crawler/1.0" envconfig:"CRAWLING_USER_AGENT"`
+		RequestDelay  time.Duration `default:"1s" envconfig:"CRAWLING_REQUEST_DELAY"`
+		MatchDistance float64       `default:"100" envconfig:"CRAWLING_MATCH_DISTANCE"`
+		AuthToken     string        `envconfig:"CRAWLING_AUTH_TOKEN"`
 	}
 
 	AppConfig struct {
 		Environment     string
-		LogLevel        string `envconfig:"LOG_LEVEL" default:"DEBUG"`
+		LogLevel        string   `envconfig:"LOG_LEVEL" default:"DEBUG"`
+		Modules         []string `envconfig:"LUNCHBOX_MODULES"`
 		PG              PGConfig
 		Nats            NatsConfig
+		Stream          StreamConfig
 		Web             web.WebConfig
 		Rpc             rpc.RpcConfig
+		Auth            auth.AuthConfig
+		Health          health.HealthConfig
+		Restaurants     RestaurantsConfig
+		Recommendations RecommendationsConfig
+		Crawling        CrawlingConfig
 		ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 	}
 )
 
+func (c PGConfig) DSN() string {
+	return fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		c.User,
+		c.Password,
+		c.Host,
+		c.Port,
+		c.DBName,
+		c.SSLMode,
+	)
+}
+
 func InitConfig() (cfg AppConfig, err error) {
 	if err = dotenv.Load(dotenv.EnvironmentFiles(os.Getenv("ENVIRONMENT"))); err != nil {
 		return
@@ -1,12 +1,50 @@
 package di
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
-func Get(ctx context.Context, key string) any {
+var errValidation = errors.New("the scope was only built to validate the container")
+
+// FromContext returns the scope attached to the context
+func FromContext(ctx context.Context) Container {
+	ctn, ok := ctx.Value(containerKey).(*container)
+	if !ok {
+		panic("container does not exist on context")
+	}
+
+	return ctn
+}
+
+// EndScope runs the finalizers of the dependencies built in the scope on the
+// context, most recently built first, passing each the outcome of the work
+// done in the scope
+func EndScope(ctx context.Context, err error) error {
 	ctn, ok := ctx.Value(containerKey).(*container)
 	if !ok {
 		panic("container does not exist on context")
 	}
 
-	return ctn.Get(key)
+	return ctn.finalizers.run(ctx, err)
+}
+
+// WithinScope runs fn in a new scope of the container and ends the scope with
+// the error fn returns; when fn panics the scope ends with the panic before it
+// is passed on
+func WithinScope(ctx context.Context, container Container, fn func(ctx context.Context) error) (err error) {
+	ctx = container.Scoped(ctx)
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = EndScope(ctx, fmt.Errorf("panic: %v", p))
+			panic(p)
+		}
+		if endErr := EndScope(ctx, err); err == nil {
+			err = endErr
+		}
+	}()
+
+	return fn(ctx)
 }
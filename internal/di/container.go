@@ -2,7 +2,10 @@ package di
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"sync"
 )
 
@@ -19,52 +22,94 @@ const containerKey contextKey = 1
 
 type DepFactoryFunc func(c Container) (any, error)
 
+// FinalizerFunc ends the life of a dependency; err is the outcome of the work
+// done in the scope, or nil when the container itself is closed
+type FinalizerFunc func(ctx context.Context, value any, err error) error
+
+type DepOption func(info *depInfo)
+
 type tempValue = chan struct{}
 
 type Container interface {
-	AddSingleton(key string, fn DepFactoryFunc)
-	AddScoped(key string, fn DepFactoryFunc)
+	AddSingleton(key string, fn DepFactoryFunc, options ...DepOption)
+	AddScoped(key string, fn DepFactoryFunc, options ...DepOption)
 	Scoped(ctx context.Context) context.Context
 	Get(key string) any
+	// Validate builds every dependency once, reporting the ones that are
+	// missing, cyclic or fail to build
+	Validate(ctx context.Context) error
+	// Close runs the finalizers of the singletons that have been built
+	Close(ctx context.Context) error
+	// Graph returns the registered dependencies and the dependencies seen
+	// being used to build each of them
+	Graph() Graph
+
+	typeOf(key string) reflect.Type
 }
 
 type depInfo struct {
-	key     string
-	scope   Scope
-	factory DepFactoryFunc
+	key       string
+	scope     Scope
+	typ       reflect.Type
+	factory   DepFactoryFunc
+	finalizer FinalizerFunc
+}
+
+// Finalizer runs fn with the dependency when the scope it was built in ends;
+// singletons are finalized when the container is closed
+func Finalizer(fn FinalizerFunc) DepOption {
+	return func(info *depInfo) {
+		info.finalizer = fn
+	}
 }
 
 var _ Container = (*container)(nil)
 
 type container struct {
-	parent  *container
-	deps    map[string]depInfo
-	vals    map[string]any
-	tracked tracked
-	mu      sync.Mutex
+	parent     *container
+	deps       map[string]depInfo
+	vals       map[string]any
+	finalizers *finalizers
+	wiring     *wiring
+	tracked    tracked
+	mu         *sync.Mutex
 }
 
 func New() Container {
 	return &container{
-		deps: make(map[string]depInfo),
-		vals: make(map[string]any),
+		deps:       make(map[string]depInfo),
+		vals:       make(map[string]any),
+		finalizers: &finalizers{},
+		wiring:     newWiring(),
+		mu:         &sync.Mutex{},
 	}
 }
 
-func (c *container) AddSingleton(key string, fn DepFactoryFunc) {
-	c.deps[key] = depInfo{
-		key:     key,
-		scope:   Singleton,
-		factory: fn,
-	}
+func (c *container) AddSingleton(key string, fn DepFactoryFunc, options ...DepOption) {
+	c.add(key, Singleton, fn, options)
 }
 
-func (c *container) AddScoped(key string, fn DepFactoryFunc) {
-	c.deps[key] = depInfo{
+func (c *container) AddScoped(key string, fn DepFactoryFunc, options ...DepOption) {
+	c.add(key, Scoped, fn, options)
+}
+
+func (c *container) add(key string, scope Scope, fn DepFactoryFunc, options []DepOption) {
+	info := depInfo{
 		key:     key,
-		scope:   Scoped,
+		scope:   scope,
 		factory: fn,
 	}
+	for _, option := range options {
+		option(&info)
+	}
+
+	// a dependency may be replaced, but not by one of another type, as the
+	// existing uses of it expect the type it was first registered with
+	if existing, exists := c.deps[key]; exists && existing.typ != nil && existing.typ != info.typ {
+		panic(fmt.Sprintf("`%s` is registered as %s and cannot be replaced by %s", key, existing.typ, typeName(info.typ)))
+	}
+
+	c.deps[key] = info
 }
 
 func (c *container) Scoped(ctx context.Context) context.Context {
@@ -77,6 +122,10 @@ func (c *container) Get(key string) any {
 		panic(fmt.Sprintf("there is no dependency registered with `%s`", key))
 	}
 
+	if requester, building := c.tracked.last(); building {
+		c.wiring.use(requester, key)
+	}
+
 	// catch cases of: building Foo needs Bar and building Bar needs Foo :boom:
 	if _, exists := c.tracked[info.key]; exists {
 		panic(fmt.Sprintf("cyclic dependencies encountered while building `%s`, tracked: %s", info.key, c.tracked))
@@ -119,38 +168,105 @@ func (c *container) get(info depInfo) any {
 	return c.get(info)
 }
 
-func (c *container) build(info depInfo, tv tempValue) any {
-	v, err := info.factory(c.builder(info))
-
-	c.mu.Lock()
+func (c *container) build(info depInfo, tv tempValue) (v any) {
+	built := false
+	defer func() {
+		// a factory that panics, such as one asking for a missing dependency,
+		// must not leave others waiting on the value forever
+		if !built {
+			c.mu.Lock()
+			delete(c.vals, info.key)
+			c.mu.Unlock()
+			close(tv)
+		}
+	}()
 
+	v, err := info.factory(c.builder(info))
 	if err != nil {
-		delete(c.vals, info.key)
-		c.mu.Unlock()
-		close(tv)
 		panic(fmt.Sprintf("error building dependency `%s`: %s", info.key, err))
 	}
 
+	c.mu.Lock()
 	c.vals[info.key] = v
 	c.mu.Unlock()
+	built = true
 	close(tv)
 
+	if info.finalizer != nil {
+		c.finalizers.add(info, v)
+	}
+
 	return v
 }
 
+func (c *container) Validate(ctx context.Context) (err error) {
+	ctx = c.Scoped(ctx)
+	scope := ctx.Value(containerKey).(*container)
+
+	keys := make([]string, 0, len(c.deps))
+	for key := range c.deps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []error
+	for _, key := range keys {
+		if problem := scope.try(key); problem != nil {
+			problems = append(problems, problem)
+		}
+	}
+	err = errors.Join(problems...)
+
+	// nothing built for the scope was meant to be kept
+	_ = EndScope(ctx, errValidation)
+
+	return err
+}
+
+func (c *container) try(key string) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("validating `%s`: %v", key, p)
+		}
+	}()
+
+	c.Get(key)
+
+	return nil
+}
+
+func (c *container) Close(ctx context.Context) error {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	return root.finalizers.run(ctx, nil)
+}
+
+func (c *container) typeOf(key string) reflect.Type {
+	return c.deps[key].typ
+}
+
 func (c *container) scoped() *container {
 	return &container{
-		parent: c,
-		deps:   c.deps,
-		vals:   make(map[string]any),
+		parent:     c,
+		deps:       c.deps,
+		vals:       make(map[string]any),
+		finalizers: &finalizers{},
+		wiring:     c.wiring,
+		mu:         &sync.Mutex{},
 	}
 }
 
 func (c *container) builder(info depInfo) *container {
 	return &container{
-		parent:  c.parent,
-		deps:    c.deps,
-		vals:    c.vals,
-		tracked: c.tracked.add(info),
+		parent:     c.parent,
+		deps:       c.deps,
+		vals:       c.vals,
+		finalizers: c.finalizers,
+		wiring:     c.wiring,
+		tracked:    c.tracked.add(info),
+		mu:         c.mu,
 	}
 }
@@ -0,0 +1,43 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type finalizer struct {
+	info  depInfo
+	value any
+}
+
+type finalizers struct {
+	list []finalizer
+	mu   sync.Mutex
+}
+
+func (f *finalizers) add(info depInfo, value any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.list = append(f.list, finalizer{info: info, value: value})
+}
+
+// run finalizes in reverse order so dependencies outlive what was built from
+// them; each dependency is only ever finalized once
+func (f *finalizers) run(ctx context.Context, err error) error {
+	f.mu.Lock()
+	list := f.list
+	f.list = nil
+	f.mu.Unlock()
+
+	var errs []error
+	for i := len(list) - 1; i >= 0; i-- {
+		if ferr := list[i].info.finalizer(ctx, list[i].value, err); ferr != nil {
+			errs = append(errs, fmt.Errorf("error finalizing dependency `%s`: %w", list[i].info.key, ferr))
+		}
+	}
+
+	return errors.Join(errs...)
+}
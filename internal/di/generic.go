@@ -0,0 +1,71 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Register adds a dependency built by fn and records T as its type, so uses
+// of the dependency as another type are caught by Get and Validate
+//
+// Registering a key again with another type is a programming error and
+// panics, the same as asking for a dependency that was never registered.
+func Register[T any](c Container, scope Scope, key string, fn func(c Container) (T, error), options ...DepOption) {
+	factory := func(c Container) (any, error) {
+		v, err := fn(c)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	options = append(options, func(info *depInfo) {
+		info.typ = typeFor[T]()
+	})
+
+	switch scope {
+	case Singleton:
+		c.AddSingleton(key, factory, options...)
+	default:
+		c.AddScoped(key, factory, options...)
+	}
+}
+
+// Get returns the dependency as a T, or an error when it was registered as a
+// type that is not a T
+func Get[T any](c Container, key string) (T, error) {
+	var zero T
+
+	if registered := c.typeOf(key); registered != nil && !registered.AssignableTo(typeFor[T]()) {
+		return zero, fmt.Errorf("`%s` is registered as %s, not %s", key, registered, typeFor[T]())
+	}
+
+	v, ok := c.Get(key).(T)
+	if !ok {
+		return zero, fmt.Errorf("`%s` is not a %s", key, typeFor[T]())
+	}
+
+	return v, nil
+}
+
+// MustGet is Get for wiring code, where a dependency of the wrong type is a
+// programming error; it panics instead of returning the error
+func MustGet[T any](c Container, key string) T {
+	v, err := Get[T](c, key)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return v
+}
+
+func typeFor[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func typeName(typ reflect.Type) string {
+	if typ == nil {
+		return "an untyped dependency"
+	}
+	return typ.String()
+}
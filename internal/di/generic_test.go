@@ -0,0 +1,67 @@
+package di
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type (
+	greeter interface{ Greet() string }
+	english struct{}
+	korean  struct{}
+)
+
+func (english) Greet() string { return "hello" }
+func (korean) Greet() string  { return "annyeong" }
+
+func TestRegisterReplacingWithTheSameType(t *testing.T) {
+	c := New()
+	Register(c, Singleton, "greeter", func(Container) (greeter, error) { return english{}, nil })
+	Register(c, Singleton, "greeter", func(Container) (greeter, error) { return korean{}, nil })
+
+	if got := MustGet[greeter](c, "greeter").Greet(); got != "annyeong" {
+		t.Errorf("expected the replacement to be used, got %q", got)
+	}
+}
+
+func TestRegisterReplacingWithAnotherTypePanics(t *testing.T) {
+	c := New()
+	Register(c, Singleton, "greeter", func(Container) (greeter, error) { return english{}, nil })
+
+	defer func() {
+		p := recover()
+		if p == nil {
+			t.Fatal("expected registering another type to panic")
+		}
+		if msg, _ := p.(string); !strings.Contains(msg, "cannot be replaced by di.korean") {
+			t.Errorf("unexpected panic: %v", p)
+		}
+	}()
+
+	Register(c, Singleton, "greeter", func(Container) (korean, error) { return korean{}, nil })
+}
+
+func TestGetOfAnotherType(t *testing.T) {
+	c := New()
+	Register(c, Singleton, "greeter", func(Container) (english, error) { return english{}, nil })
+
+	if _, err := Get[greeter](c, "greeter"); err != nil {
+		t.Errorf("expected an english to be usable as a greeter: %v", err)
+	}
+	if _, err := Get[korean](c, "greeter"); err == nil {
+		t.Error("expected an english not to be usable as a korean")
+	}
+}
+
+func TestValidateReportsFailingDependencies(t *testing.T) {
+	c := New()
+	Register(c, Singleton, "greeter", func(c Container) (greeter, error) {
+		return MustGet[greeter](c, "missing"), nil
+	})
+
+	err := c.Validate(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "validating `greeter`") {
+		t.Errorf("expected the greeter to fail validation, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Graph is the wiring of a container: every registered dependency and the
+// dependencies each was seen asking for while being built
+//
+// Uses are only seen once a dependency has been built; calling Validate first
+// builds all of them.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	Key   string `json:"key"`
+	Scope string `json:"scope"`
+	Type  string `json:"type,omitempty"`
+}
+
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (s Scope) String() string {
+	switch s {
+	case Singleton:
+		return "singleton"
+	case Scoped:
+		return "scoped"
+	default:
+		return "unknown"
+	}
+}
+
+// WriteJSON writes the graph as a JSON document
+func (g Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language; singletons are drawn
+// as boxes and scoped dependencies as ellipses
+func (g Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph dependencies {"); err != nil {
+		return err
+	}
+	for _, node := range g.Nodes {
+		shape := "ellipse"
+		if node.Scope == Singleton.String() {
+			shape = "box"
+		}
+		// DOT reads \n in a label as a line break
+		label := node.Key
+		if node.Type != "" {
+			label += `\n` + node.Type
+		}
+		if _, err := fmt.Fprintf(w, "\t%q [shape=%s, label=\"%s\"];\n", node.Key, shape, label); err != nil {
+			return err
+		}
+	}
+	for _, edge := range g.Edges {
+		if _, err := fmt.Fprintf(w, "\t%q -> %q;\n", edge.From, edge.To); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func (c *container) Graph() Graph {
+	g := Graph{
+		Nodes: make([]GraphNode, 0, len(c.deps)),
+		Edges: c.wiring.edges(),
+	}
+	for _, info := range c.deps {
+		node := GraphNode{
+			Key:   info.key,
+			Scope: info.scope.String(),
+		}
+		if info.typ != nil {
+			node.Type = info.typ.String()
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].Key < g.Nodes[j].Key
+	})
+
+	return g
+}
+
+// wiring is what the container has learned about how its dependencies fit
+// together, shared by all of its scopes
+type wiring struct {
+	uses map[GraphEdge]struct{}
+	mu   sync.Mutex
+}
+
+func newWiring() *wiring {
+	return &wiring{
+		uses: make(map[GraphEdge]struct{}),
+	}
+}
+
+func (w *wiring) use(from, to string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.uses[GraphEdge{From: from, To: to}] = struct{}{}
+}
+
+func (w *wiring) edges() []GraphEdge {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	edges := make([]GraphEdge, 0, len(w.uses))
+	for edge := range w.uses {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+
+	return edges
+}
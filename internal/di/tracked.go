@@ -15,6 +15,17 @@ func (s tracked) add(info depInfo) tracked {
 	return newList
 }
 
+// last returns the dependency being built most recently
+func (s tracked) last() (string, bool) {
+	for key, i := range s {
+		if i == len(s)-1 {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
 func (s tracked) ordered() []string {
 	keys := make([]string, len(s))
 
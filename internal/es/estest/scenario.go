@@ -0,0 +1,253 @@
+// Package estest runs given/when/then scenarios against event sourced
+// aggregates
+//
+//	estest.For[*domain.Restaurant](t, reg, domain.RestaurantAggregate, "restaurant-id").
+//		Given(ddd.NewEvent(domain.RestaurantRegisteredEvent, &domain.RestaurantRegistered{Name: "Noodles"})).
+//		When(func(r *domain.Restaurant) error {
+//			_, err := r.MergeInto("survivor-id")
+//			return err
+//		}).
+//		Then(ddd.NewEvent(domain.RestaurantMergedEvent, &domain.RestaurantMerged{SurvivorID: "survivor-id"}))
+//
+// Besides the events or error expected, a scenario checks what the stores
+// will rely on later: every event goes through the registry and comes back
+// unchanged, the aggregate rebuilt from its events has the same state as the
+// one the command ran on, and so does the aggregate loaded from its snapshot.
+// The events are saved to and loaded from an in-memory event store through an
+// aggregate repository, the same as the modules do with theirs.
+package estest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/jongyunha/lunchbox/internal/memory"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/stackus/errors"
+)
+
+type Scenario[T es.EventSourcedAggregate] struct {
+	t             testing.TB
+	registry      registry.Registry
+	aggregateName string
+	aggregateID   string
+	repository    es.AggregateRepository[T]
+	aggregate     T
+	err           error
+}
+
+// For starts a scenario for a new aggregate loaded by a repository with an
+// empty event store
+func For[T es.EventSourcedAggregate](t testing.TB, reg registry.Registry, aggregateName, aggregateID string) *Scenario[T] {
+	t.Helper()
+
+	repository := es.NewAggregateRepository[T](aggregateName, reg, memory.NewEventStore(reg))
+	aggregate, err := repository.Load(context.Background(), aggregateID)
+	if err != nil {
+		t.Fatalf("loading the %s aggregate: %v", aggregateName, err)
+	}
+
+	return &Scenario[T]{
+		t:             t,
+		registry:      reg,
+		aggregateName: aggregateName,
+		aggregateID:   aggregateID,
+		repository:    repository,
+		aggregate:     aggregate,
+	}
+}
+
+// Given applies the events that happened before the command
+func (s *Scenario[T]) Given(events ...ddd.Event) *Scenario[T] {
+	s.t.Helper()
+
+	for _, event := range events {
+		s.aggregate.AddEvent(event.EventName(), RoundTrip(s.t, s.registry, event))
+	}
+	s.save()
+
+	return s
+}
+
+// When runs the command; the events it adds are checked by Then
+func (s *Scenario[T]) When(command func(aggregate T) error) *Scenario[T] {
+	s.t.Helper()
+
+	s.err = command(s.aggregate)
+
+	return s
+}
+
+// Then expects the command to have added exactly these events and returns the
+// aggregate once they have been applied for any further checks
+func (s *Scenario[T]) Then(expected ...ddd.Event) T {
+	s.t.Helper()
+
+	if s.err != nil {
+		s.t.Fatalf("expected the events %v, got the error: %v", eventNames(expected), s.err)
+	}
+
+	added := s.aggregate.Events()
+	if len(added) != len(expected) {
+		s.t.Fatalf("expected the events %v, got %v", eventNames(expected), eventNames(added))
+	}
+	for i, event := range added {
+		if event.EventName() != expected[i].EventName() {
+			s.t.Fatalf("expected the events %v, got %v", eventNames(expected), eventNames(added))
+		}
+		if diff := diff(expected[i].Payload(), event.Payload()); diff != "" {
+			s.t.Fatalf("the %s event is not the one expected (-want +got):\n%s", event.EventName(), diff)
+		}
+		if event.AggregateVersion() != s.aggregate.Version()+i+1 {
+			s.t.Fatalf("expected the %s event to be version %d, got %d", event.EventName(), s.aggregate.Version()+i+1, event.AggregateVersion())
+		}
+		RoundTrip(s.t, s.registry, event)
+	}
+
+	s.save()
+
+	s.assertRebuilds()
+	AssertSnapshot(s.t, s.registry, s.aggregateName, s.aggregate)
+
+	return s.aggregate
+}
+
+// ThenError expects the command to have failed with the error, or one that
+// wraps it, without adding any events
+func (s *Scenario[T]) ThenError(target error) {
+	s.t.Helper()
+
+	if s.err == nil {
+		s.t.Fatalf("expected the error %q, got the events %v", target, eventNames(s.aggregate.Events()))
+	}
+	if !errors.Is(s.err, target) {
+		s.t.Fatalf("expected the error %q, got %q", target, s.err)
+	}
+	if added := s.aggregate.Events(); len(added) > 0 {
+		s.t.Fatalf("expected no events with the error %q, got %v", target, eventNames(added))
+	}
+}
+
+// RoundTrip serializes the payload of the event with the registry and returns
+// it deserialized; the test fails when the event has not been registered or
+// does not come back the same
+func RoundTrip(t testing.TB, reg registry.Registry, event ddd.Event) ddd.EventPayload {
+	t.Helper()
+
+	data, err := reg.Serialize(event.EventName(), event.Payload())
+	if err != nil {
+		t.Fatalf("serializing the %s event: %v", event.EventName(), err)
+	}
+	payload, err := reg.Deserialize(event.EventName(), data)
+	if err != nil {
+		t.Fatalf("deserializing the %s event: %v", event.EventName(), err)
+	}
+	if diff := diff(event.Payload(), payload); diff != "" {
+		t.Fatalf("the %s event changed going through the registry (-sent +received):\n%s", event.EventName(), diff)
+	}
+
+	return payload
+}
+
+// AssertSnapshot checks that an aggregate loaded from the snapshot of the
+// given aggregate ends up with the same state; aggregates without snapshots
+// pass as is
+func AssertSnapshot(t testing.TB, reg registry.Registry, aggregateName string, aggregate es.EventSourcedAggregate) {
+	t.Helper()
+
+	snapshotter, ok := aggregate.(es.Snapshotter)
+	if !ok {
+		return
+	}
+
+	snapshot := snapshotter.ToSnapshot()
+	data, err := reg.Serialize(snapshot.SnapshotName(), snapshot)
+	if err != nil {
+		t.Fatalf("serializing the %s snapshot: %v", snapshot.SnapshotName(), err)
+	}
+	v, err := reg.Deserialize(snapshot.SnapshotName(), data, registry.ValidateImplements((*es.Snapshot)(nil)))
+	if err != nil {
+		t.Fatalf("deserializing the %s snapshot: %v", snapshot.SnapshotName(), err)
+	}
+
+	loaded := build[es.EventSourcedAggregate](t, reg, aggregateName, aggregate.ID())
+	if err = es.LoadSnapshot(loaded, v.(es.Snapshot), aggregate.Version()); err != nil {
+		t.Fatalf("loading the %s snapshot: %v", snapshot.SnapshotName(), err)
+	}
+	commit(t, loaded)
+
+	if diff := diff(aggregate, loaded); diff != "" {
+		t.Fatalf("the aggregate loaded from the %s snapshot differs (-events +snapshot):\n%s", snapshot.SnapshotName(), diff)
+	}
+}
+
+// save applies and commits the pending events by saving the aggregate
+func (s *Scenario[T]) save() {
+	s.t.Helper()
+
+	if err := s.repository.Save(context.Background(), s.aggregate); err != nil {
+		s.t.Fatalf("saving the %s aggregate: %v", s.aggregateName, err)
+	}
+}
+
+// assertRebuilds checks that loading the aggregate from every event saved
+// gives the state the command left behind
+func (s *Scenario[T]) assertRebuilds() {
+	s.t.Helper()
+
+	rebuilt, err := s.repository.Load(context.Background(), s.aggregateID)
+	if err != nil {
+		s.t.Fatalf("loading the %s aggregate: %v", s.aggregateName, err)
+	}
+
+	if diff := diff(s.aggregate, rebuilt); diff != "" {
+		s.t.Fatalf("the aggregate rebuilt from its events differs (-command +rebuilt):\n%s", diff)
+	}
+}
+
+func build[T es.EventSourcedAggregate](t testing.TB, reg registry.Registry, aggregateName, aggregateID string) T {
+	t.Helper()
+
+	v, err := reg.Build(aggregateName, ddd.SetID(aggregateID), ddd.SetName(aggregateName))
+	if err != nil {
+		t.Fatalf("building the %s aggregate: %v", aggregateName, err)
+	}
+	aggregate, ok := v.(T)
+	if !ok {
+		var want T
+		t.Fatalf("%T is not the expected type %T", v, want)
+	}
+
+	return aggregate
+}
+
+// commit applies the pending events and commits them the way the aggregate
+// repository does when saving
+func commit(t testing.TB, aggregate es.EventSourcedAggregate) {
+	t.Helper()
+
+	for _, event := range aggregate.Events() {
+		if err := aggregate.ApplyEvent(event); err != nil {
+			t.Fatalf("applying the %s event: %v", event.EventName(), err)
+		}
+	}
+	aggregate.CommitEvents()
+}
+
+// diff compares everything, unexported fields included; times are compared
+// with Equal since they lose their monotonic clock reading when serialized
+func diff(want, got any) string {
+	return cmp.Diff(want, got, cmp.Exporter(func(reflect.Type) bool { return true }))
+}
+
+func eventNames[E ddd.Event](events []E) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.EventName()
+	}
+	return names
+}
@@ -0,0 +1,10 @@
+package health
+
+import "time"
+
+type HealthConfig struct {
+	Interval      time.Duration `default:"10s" envconfig:"HEALTH_CHECK_INTERVAL"`
+	Timeout       time.Duration `default:"5s" envconfig:"HEALTH_CHECK_TIMEOUT"`
+	OutboxBacklog int           `default:"1000" envconfig:"HEALTH_OUTBOX_BACKLOG"`
+	ConsumerLag   uint64        `default:"1000" envconfig:"HEALTH_CONSUMER_LAG"`
+}
@@ -0,0 +1,185 @@
+// Package health reports whether the application is ready to take traffic
+//
+// Modules and infrastructure register checks, which are run together in the
+// background. The latest results are served at the readiness endpoint and
+// through the grpc.health.v1 service, so a probe never waits on the checks
+// themselves. Until the first round of checks has passed, and again once
+// shutdown has begun, the application reports that it is not ready.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// CheckFunc returns an error describing why a dependency is not usable
+type CheckFunc func(ctx context.Context) error
+
+type Result struct {
+	Name     string `json:"name"`
+	Status   Status `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type Report struct {
+	Status    Status     `json:"status"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	Checks    []Result   `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+type Health struct {
+	cfg    HealthConfig
+	checks []check
+	report Report
+	server *grpchealth.Server
+	mu     sync.RWMutex
+}
+
+var _ http.Handler = (*Health)(nil)
+
+func New(cfg HealthConfig) *Health {
+	server := grpchealth.NewServer()
+	server.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	return &Health{
+		cfg:    cfg,
+		report: Report{Status: StatusDown, Checks: []Result{}},
+		server: server,
+	}
+}
+
+// Register adds a check; checks registered after Run has started are
+// included from the next round on
+func (h *Health) Register(name string, fn CheckFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, check{name: name, fn: fn})
+}
+
+// RegisterServer adds the grpc.health.v1 service to the server
+func (h *Health) RegisterServer(registrar grpc.ServiceRegistrar) {
+	grpc_health_v1.RegisterHealthServer(registrar, h.server)
+}
+
+// Report returns the results of the latest round of checks
+func (h *Health) Report() Report {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.report
+}
+
+// Run checks every interval until the context is done, after which the
+// application is reported as not ready for good
+func (h *Health) Run(ctx context.Context) error {
+	defer h.server.Shutdown()
+
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		h.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs every check at once and records the results
+func (h *Health) Check(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := append([]check(nil), h.checks...)
+	h.mu.RUnlock()
+
+	checkedAt := time.Now()
+	report := Report{
+		Status:    StatusUp,
+		CheckedAt: &checkedAt,
+		Checks:    make([]Result, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			report.Checks[i] = h.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	h.mu.Lock()
+	h.report = report
+	h.mu.Unlock()
+	h.server.SetServingStatus("", status)
+
+	return report
+}
+
+func (h *Health) run(ctx context.Context, c check) (result Result) {
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	defer func() {
+		result.Duration = time.Since(started).String()
+		if p := recover(); p != nil {
+			result.Status, result.Error = StatusDown, "check panicked"
+		}
+	}()
+
+	result = Result{Name: c.name, Status: StatusUp}
+	if err := c.fn(ctx); err != nil {
+		result.Status, result.Error = StatusDown, err.Error()
+	}
+
+	return result
+}
+
+// ServeHTTP writes the latest report; the status is 200 when every check
+// passed and 503 otherwise
+func (h *Health) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	report := h.Report()
+
+	code := http.StatusOK
+	if report.Status != StatusUp {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,19 @@
+package idempotency
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// GatewayHeaderMatcher passes the Idempotency-Key header on to the RPCs
+// behind the gateway as idempotency-key metadata and leaves every other header
+// to the next matcher
+func GatewayHeaderMatcher(next runtime.HeaderMatcherFunc) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		if http.CanonicalHeaderKey(key) == HeaderName {
+			return MetadataKey, true
+		}
+		return next(key)
+	}
+}
@@ -0,0 +1,192 @@
+// Package idempotency lets clients safely retry requests that create or
+// change something
+//
+// A client sends a key of its choosing with the request, as the
+// Idempotency-Key header over HTTP or the idempotency-key metadata over gRPC.
+// The first request made with a key is handled normally and its response is
+// stored under the key in the same transaction as the change it made. Any
+// later request with the same key gets the stored response back without being
+// handled again, unless it is a different request, which is rejected.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"path"
+	"strings"
+
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/stackus/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	HeaderName  = "Idempotency-Key"
+	MetadataKey = "idempotency-key"
+
+	maxKeyLength = 255
+)
+
+var (
+	ErrKeyTooLong = errors.Wrap(errors.ErrBadRequest, "the idempotency key cannot be longer than 255 characters")
+	ErrKeyReused  = errors.Wrap(errors.ErrUnprocessableEntity, "the idempotency key has already been used for a different request")
+)
+
+// Record is a request made with an idempotency key and, once it has been
+// handled, its response
+type Record struct {
+	Key         string
+	Method      string
+	RequestHash []byte
+	Response    []byte
+}
+
+type Store interface {
+	// Claim records the request under its key; when the key has already been
+	// claimed the existing record is returned instead
+	//
+	// Claims made at the same time with the same key wait on each other, so
+	// the record returned is always of a request that has been handled.
+	Claim(ctx context.Context, record Record) (*Record, error)
+	// Complete stores the response of the claimed request
+	Complete(ctx context.Context, key string, response []byte) error
+}
+
+// UnaryServerInterceptor handles the given methods unless the request carries
+// a key that has been used before, in which case the response to the first
+// request is returned
+//
+// The store is looked up under storeKey in the scoped container of the
+// request; it must share the transaction of the handler so that the response
+// is only kept when the changes made by the handler are.
+func UnaryServerInterceptor(storeKey string, methods ...string) grpc.UnaryServerInterceptor {
+	idempotent := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		idempotent[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		request, ok := req.(proto.Message)
+		if _, found := idempotent[info.FullMethod]; !found || !ok {
+			return handler(ctx, req)
+		}
+
+		return handle(ctx, di.MustGet[Store](di.FromContext(ctx), storeKey), info.FullMethod, request, handler)
+	}
+}
+
+// KeyFromContext returns the idempotency key sent with the request, if any
+func KeyFromContext(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, MetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func handle(ctx context.Context, store Store, method string, request proto.Message, handler grpc.UnaryHandler) (any, error) {
+	key := KeyFromContext(ctx)
+	if key == "" {
+		return handler(ctx, request)
+	}
+	if len(key) > maxKeyLength {
+		return nil, ErrKeyTooLong
+	}
+
+	requestHash, err := hash(request)
+	if err != nil {
+		return nil, err
+	}
+
+	record := Record{
+		Key:         scopedKey(ctx, key),
+		Method:      method,
+		RequestHash: requestHash,
+	}
+
+	existing, err := store.Claim(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return replay(record, existing)
+	}
+
+	resp, err := handler(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := proto.Marshal(resp.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, store.Complete(ctx, record.Key, response)
+}
+
+func replay(record Record, existing *Record) (proto.Message, error) {
+	if existing.Method != record.Method || string(existing.RequestHash) != string(record.RequestHash) {
+		return nil, ErrKeyReused
+	}
+
+	resp, err := newResponse(record.Method)
+	if err != nil {
+		return nil, err
+	}
+	if err = proto.Unmarshal(existing.Response, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// newResponse creates an empty response of the method from its descriptor,
+// the method being the full name as in "/package.Service/Method"
+func newResponse(method string) (proto.Message, error) {
+	serviceName, methodName := path.Split(method)
+	serviceName = strings.Trim(serviceName, "/")
+
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, err
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.ErrInternal.Msgf("`%s` is not a service", serviceName)
+	}
+	methodDescriptor := service.Methods().ByName(protoreflect.Name(methodName))
+	if methodDescriptor == nil {
+		return nil, errors.ErrInternal.Msgf("`%s` is not a method", method)
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(methodDescriptor.Output().FullName())
+	if err != nil {
+		return nil, err
+	}
+
+	return messageType.New().Interface(), nil
+}
+
+func hash(request proto.Message) ([]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+// scopedKey keeps callers from replaying each other's responses by guessing
+// their keys
+func scopedKey(ctx context.Context, key string) string {
+	if claims, ok := auth.ClaimsFromContext(ctx); ok && claims.Subject != "" {
+		return claims.Subject + ":" + key
+	}
+	return key
+}
@@ -0,0 +1,128 @@
+package idempotency_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/internal/memory"
+	"github.com/stackus/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	storeKey = "idempotencyStore"
+	// the health service stands in for the services of the modules; any
+	// registered method will do
+	testMethod = "/grpc.health.v1.Health/Check"
+)
+
+// call is a request made with a key by the subject of the claims, if any
+type call struct {
+	subject string
+	key     string
+	service string
+	// wantStatus is the status handed out by the handler for the call that is
+	// handled or replayed; the handler hands out a new status every time
+	wantStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+	wantErr    error
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const (
+		first  = grpc_health_v1.HealthCheckResponse_SERVING
+		second = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	)
+
+	tests := map[string]struct {
+		calls       []call
+		wantHandled int
+	}{
+		"a retry gets the stored response": {
+			calls: []call{
+				{key: "key", service: "a", wantStatus: first},
+				{key: "key", service: "a", wantStatus: first},
+			},
+			wantHandled: 1,
+		},
+		"a different request with the key is rejected": {
+			calls: []call{
+				{key: "key", service: "a", wantStatus: first},
+				{key: "key", service: "b", wantErr: idempotency.ErrKeyReused},
+			},
+			wantHandled: 1,
+		},
+		"the keys of each subject are their own": {
+			calls: []call{
+				{subject: "alice", key: "key", service: "a", wantStatus: first},
+				{subject: "bob", key: "key", service: "a", wantStatus: second},
+				{subject: "alice", key: "key", service: "a", wantStatus: first},
+			},
+			wantHandled: 2,
+		},
+		"requests without a key are always handled": {
+			calls: []call{
+				{service: "a", wantStatus: first},
+				{service: "a", wantStatus: second},
+			},
+			wantHandled: 2,
+		},
+		"a key that is too long is rejected": {
+			calls: []call{
+				{key: strings.Repeat("k", 256), service: "a", wantErr: idempotency.ErrKeyTooLong},
+			},
+			wantHandled: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			container := di.New()
+			store := memory.NewIdempotencyStore()
+			di.Register(container, di.Singleton, storeKey, func(di.Container) (idempotency.Store, error) {
+				return store, nil
+			})
+
+			handled := 0
+			handler := func(ctx context.Context, req any) (any, error) {
+				handled++
+				return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_ServingStatus(handled)}, nil
+			}
+			interceptor := idempotency.UnaryServerInterceptor(storeKey, testMethod)
+
+			for i, c := range tc.calls {
+				ctx := container.Scoped(context.Background())
+				if c.key != "" {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(idempotency.MetadataKey, c.key))
+				}
+				if c.subject != "" {
+					ctx = auth.WithClaims(ctx, &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: c.subject}})
+				}
+
+				resp, err := interceptor(ctx, &grpc_health_v1.HealthCheckRequest{Service: c.service},
+					&grpc.UnaryServerInfo{FullMethod: testMethod}, handler)
+				if c.wantErr != nil {
+					if !errors.Is(err, c.wantErr) {
+						t.Errorf("call %d: expected %v, got %v", i, c.wantErr, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("call %d: %v", i, err)
+				}
+				if status := resp.(*grpc_health_v1.HealthCheckResponse).GetStatus(); status != c.wantStatus {
+					t.Errorf("call %d: expected the status %v, got %v", i, c.wantStatus, status)
+				}
+			}
+
+			if handled != tc.wantHandled {
+				t.Errorf("expected the handler to be called %d times, got %d", tc.wantHandled, handled)
+			}
+		})
+	}
+}
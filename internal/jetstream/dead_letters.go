@@ -0,0 +1,207 @@
+package jetstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stackus/errors"
+)
+
+// DeadLetter is a message a consumer gave up on, either because it was
+// delivered too many times or because the handler terminated it
+type DeadLetter struct {
+	// Sequence identifies the dead letter within the dead letter stream
+	Sequence    uint64
+	Consumer    string
+	StreamSeq   uint64
+	Deliveries  uint64
+	Reason      string
+	Subject     string
+	MessageID   string
+	MessageName string
+	DeadAt      time.Time
+}
+
+// DeadLetters keeps the JetStream advisories raised for the messages of a
+// stream that were given up on, so they can be looked at and re-driven
+type DeadLetters struct {
+	streamName string
+	js         nats.JetStreamContext
+}
+
+// the fields used from the max deliveries and terminated message advisories
+type consumerAdvisory struct {
+	Type       string    `json:"type"`
+	Timestamp  time.Time `json:"timestamp"`
+	Consumer   string    `json:"consumer"`
+	StreamSeq  uint64    `json:"stream_seq"`
+	Deliveries uint64    `json:"deliveries"`
+	Reason     string    `json:"reason"`
+}
+
+// advisoryReasons stand in for the reason of the advisories that have none;
+// messages terminated without a reason are among them
+var advisoryReasons = map[string]string{
+	"io.nats.jetstream.advisory.v1.max_deliver": "max deliveries reached",
+	"io.nats.jetstream.advisory.v1.terminated":  "terminated",
+}
+
+func NewDeadLetters(streamName string, js nats.JetStreamContext) DeadLetters {
+	return DeadLetters{
+		streamName: streamName,
+		js:         js,
+	}
+}
+
+func (d DeadLetters) StreamName() string {
+	return d.streamName + "_dead_letters"
+}
+
+// Provision creates the stream that captures the advisories
+func (d DeadLetters) Provision() error {
+	_, err := d.js.AddStream(&nats.StreamConfig{
+		Name: d.StreamName(),
+		Subjects: []string{
+			fmt.Sprintf("$JS.EVENT.ADVISORY.CONSUMER.MAX_DELIVERIES.%s.*", d.streamName),
+			fmt.Sprintf("$JS.EVENT.ADVISORY.CONSUMER.MSG_TERMINATED.%s.*", d.streamName),
+		},
+	})
+
+	return err
+}
+
+// List returns up to limit dead letters, oldest first
+func (d DeadLetters) List(limit int) ([]DeadLetter, error) {
+	info, err := d.js.StreamInfo(d.StreamName())
+	if err != nil {
+		return nil, err
+	}
+
+	var deadLetters []DeadLetter
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && len(deadLetters) < limit; seq++ {
+		deadLetter, err := d.Find(seq)
+		if err != nil {
+			if errors.Is(err, nats.ErrMsgNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		deadLetters = append(deadLetters, *deadLetter)
+	}
+
+	return deadLetters, nil
+}
+
+func (d DeadLetters) Find(seq uint64) (*DeadLetter, error) {
+	raw, err := d.js.GetMsg(d.StreamName(), seq)
+	if err != nil {
+		return nil, err
+	}
+
+	var advisory consumerAdvisory
+	if err = json.Unmarshal(raw.Data, &advisory); err != nil {
+		return nil, err
+	}
+
+	deadLetter := &DeadLetter{
+		Sequence:   seq,
+		Consumer:   advisory.Consumer,
+		StreamSeq:  advisory.StreamSeq,
+		Deliveries: advisory.Deliveries,
+		Reason:     advisory.Reason,
+		DeadAt:     advisory.Timestamp,
+	}
+	if deadLetter.Reason == "" {
+		deadLetter.Reason = advisoryReasons[advisory.Type]
+	}
+
+	// the original may have since been removed from the stream
+	msg, err := d.js.GetMsg(d.streamName, advisory.StreamSeq)
+	if err != nil {
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			return deadLetter, nil
+		}
+		return nil, err
+	}
+	deadLetter.Subject = msg.Subject
+
+	if decoded, err := decode(&nats.Msg{Subject: msg.Subject, Header: msg.Header, Data: msg.Data}); err == nil {
+		deadLetter.MessageID = decoded.ID()
+		deadLetter.MessageName = decoded.MessageName()
+	}
+
+	return deadLetter, nil
+}
+
+// Redrive publishes the original message again to the redrive subject of the
+// consumer that gave up on it, which no other consumer takes in, and then
+// removes the dead letter
+//
+// A dead letter of a consumer that no longer exists, or that was provisioned
+// before it took in a redrive subject, is not re-driven.
+func (d DeadLetters) Redrive(seq uint64) error {
+	deadLetter, err := d.Find(seq)
+	if err != nil {
+		return err
+	}
+	if deadLetter.Subject == "" {
+		return errors.Wrapf(errors.ErrNotFound, "the message of dead letter %d is no longer in the stream", seq)
+	}
+
+	subject := redriveSubject(d.streamName, deadLetter.Consumer)
+	info, err := d.js.ConsumerInfo(d.streamName, deadLetter.Consumer)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		return errors.Wrapf(errors.ErrNotFound, "the %s consumer of dead letter %d no longer exists", deadLetter.Consumer, seq)
+	}
+	if err != nil {
+		return err
+	}
+	if !takesIn(filterSubjects(&info.Config), subject) {
+		return errors.Wrapf(errors.ErrFailedPrecondition, "the %s consumer does not take in re-driven messages until it is provisioned again", deadLetter.Consumer)
+	}
+
+	msg, err := d.js.GetMsg(d.streamName, deadLetter.StreamSeq)
+	if err != nil {
+		return err
+	}
+	// a new Nats-Msg-Id keeps the stream from dropping the message as a
+	// duplicate; the id of the message itself is left as it was, and so is its
+	// subject, which is carried in a header
+	header := nats.Header{}
+	for key, values := range msg.Header {
+		header[key] = append([]string(nil), values...)
+	}
+	header.Set(subjectHdr, msg.Subject)
+	if _, err = d.js.PublishMsg(&nats.Msg{
+		Subject: subject,
+		Header:  header,
+		Data:    msg.Data,
+	}, nats.MsgId(fmt.Sprintf("%s.redrive.%d", deadLetter.MessageID, seq))); err != nil {
+		return err
+	}
+
+	return d.js.DeleteMsg(d.StreamName(), seq)
+}
+
+// redriveSubject is where the dead letters of a durable consumer are published
+// again; the provisioner adds it to the filter subjects of the consumer
+func redriveSubject(streamName, consumer string) string {
+	return fmt.Sprintf("%s.redrive.%s", streamName, consumer)
+}
+
+// takesIn reports whether a consumer with the filter subjects receives the
+// messages published to the subject
+func takesIn(filters []string, subject string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if server.SubjectsCollide(filter, subject) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package jetstream
+
+import (
+	"sync"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+)
+
+// partitions queues the messages fetched for the workers; the messages of a
+// partition are handed out one at a time in the order they were fetched,
+// while the workers take turns with the partitions that have messages waiting
+//
+// A partition whose message is to be delivered again is held until it is, or
+// until the hold is released; the messages of the partition fetched in the
+// meantime wait behind it.
+type partitions struct {
+	ordered *am.Ordered
+	mu      sync.Mutex
+	cond    *sync.Cond
+	// the messages of each partition, the first of which is being handled
+	// when the partition is neither in ready nor held
+	queues map[string][]*rawMessage
+	ready  []string
+	held   map[string]hold
+	holds  uint64
+	closed bool
+}
+
+// hold is a partition waiting for the message with the id; the token tells
+// the holds of a partition apart
+type hold struct {
+	id    string
+	token uint64
+}
+
+// newPartitions gives every message a partition of its own when the
+// subscription is not ordered
+func newPartitions(ordered *am.Ordered) *partitions {
+	p := &partitions{
+		ordered: ordered,
+		queues:  make(map[string][]*rawMessage),
+		held:    make(map[string]hold),
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	return p
+}
+
+func (p *partitions) partition(msg *rawMessage) string {
+	if p.ordered != nil {
+		return p.ordered.Partition(msg)
+	}
+	return msg.ID()
+}
+
+// push queues the message; it reports whether the message has to wait for its
+// partition to be released, and returns the messages that waited when the
+// message is the one its partition was held for
+func (p *partitions) push(msg *rawMessage) (bool, []*rawMessage) {
+	partition := p.partition(msg)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	queue, exists := p.queues[partition]
+	if h, held := p.held[partition]; held {
+		if msg.ID() != h.id {
+			p.queues[partition] = append(queue, msg)
+			return true, nil
+		}
+		// the message waited for goes back to the front of its partition
+		delete(p.held, partition)
+		p.queues[partition] = append([]*rawMessage{msg}, queue...)
+		p.ready = append(p.ready, partition)
+		p.cond.Signal()
+		return false, queue
+	}
+
+	p.queues[partition] = append(queue, msg)
+	if !exists {
+		p.ready = append(p.ready, partition)
+		p.cond.Signal()
+	}
+	return false, nil
+}
+
+// pop waits for the next message of a partition that is not being handled;
+// once closed it returns false after every message has been handed out
+func (p *partitions) pop() (string, *rawMessage, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.ready) == 0 && !p.closed {
+		p.cond.Wait()
+	}
+	if len(p.ready) == 0 {
+		return "", nil, false
+	}
+
+	partition := p.ready[0]
+	p.ready = p.ready[1:]
+
+	return partition, p.queues[partition][0], true
+}
+
+// done lets the next message of the partition be handed out
+func (p *partitions) done(partition string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	queue := p.queues[partition][1:]
+	if len(queue) == 0 {
+		delete(p.queues, partition)
+		return
+	}
+	p.queues[partition] = queue
+	p.ready = append(p.ready, partition)
+	p.cond.Signal()
+}
+
+// hold keeps the rest of the partition back until the message that was being
+// handled is pushed again; it returns the token of the hold along with the
+// messages waiting behind it
+func (p *partitions) hold(partition string, msg *rawMessage) (uint64, []*rawMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.holds++
+	queue := p.queues[partition][1:]
+	p.queues[partition] = queue
+	p.held[partition] = hold{id: msg.ID(), token: p.holds}
+
+	return p.holds, append([]*rawMessage(nil), queue...)
+}
+
+// release gives up on the hold when the partition is still waiting; the
+// partition is removed along with the messages that waited, which are returned
+func (p *partitions) release(partition string, token uint64) []*rawMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if h, held := p.held[partition]; !held || h.token != token {
+		return nil
+	}
+
+	queue := p.queues[partition]
+	delete(p.held, partition)
+	delete(p.queues, partition)
+
+	return queue
+}
+
+// waiting reports whether the message waits behind a partition that is held
+func (p *partitions) waiting(msg *rawMessage) bool {
+	partition := p.partition(msg)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, held := p.held[partition]
+	return held
+}
+
+func (p *partitions) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	p.cond.Broadcast()
+}
@@ -0,0 +1,121 @@
+package jetstream
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+)
+
+func messageIDs(msgs []*rawMessage) []string {
+	ids := make([]string, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.ID()
+	}
+	return ids
+}
+
+// pushAll pushes the messages and returns the ids of those that were parked
+func pushAll(p *partitions, msgs ...*rawMessage) []string {
+	var parked []*rawMessage
+	for _, msg := range msgs {
+		if wait, _ := p.push(msg); wait {
+			parked = append(parked, msg)
+		}
+	}
+	return messageIDs(parked)
+}
+
+func TestPartitionsHold(t *testing.T) {
+	ordered := &am.Ordered{PartitionKey: ddd.AggregateIDKey}
+	other := testMessage("other")
+	other.metadata = ddd.Metadata{ddd.AggregateIDKey: "other-id"}
+
+	tests := map[string]struct {
+		// redeliver pushes the message that was held for, or nothing when
+		// the hold is released first
+		redeliver   bool
+		wantResumed []string
+		wantOrder   []string
+		wantRelease []string
+	}{
+		"the message delivered again goes first": {
+			redeliver:   true,
+			wantResumed: []string{"2", "3"},
+			wantOrder:   []string{"1", "2", "3"},
+		},
+		"a released hold drops the messages that waited": {
+			wantRelease: []string{"2", "3"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newPartitions(ordered)
+			pushAll(p, testMessage("1"), testMessage("2"))
+
+			partition, msg, _ := p.pop()
+			token, waiting := p.hold(partition, msg)
+			if diff := cmp.Diff([]string{"2"}, messageIDs(waiting)); diff != "" {
+				t.Errorf("expected the messages behind the hold (-want +got):\n%s", diff)
+			}
+
+			// messages of the partition fetched during the hold wait, those of
+			// other partitions do not
+			if parked := pushAll(p, testMessage("3"), other); !cmp.Equal(parked, []string{"3"}) {
+				t.Errorf("expected only message 3 to wait, got %v", parked)
+			}
+			if !p.waiting(testMessage("2")) || p.waiting(other) {
+				t.Error("expected only the messages of the held partition to be waiting")
+			}
+			if _, next, _ := p.pop(); next.ID() != "other" {
+				t.Fatalf("expected the other partition to be handed out, got %s", next.ID())
+			}
+
+			if tc.redeliver {
+				_, resumed := p.push(testMessage("1"))
+				if diff := cmp.Diff(tc.wantResumed, messageIDs(resumed)); diff != "" {
+					t.Errorf("expected the messages that waited (-want +got):\n%s", diff)
+				}
+			}
+			released := p.release(partition, token)
+			if diff := cmp.Diff(tc.wantRelease, messageIDs(released), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("expected the messages released (-want +got):\n%s", diff)
+			}
+			if p.waiting(testMessage("2")) {
+				t.Error("expected the partition to no longer be held")
+			}
+
+			var order []string
+			for range tc.wantOrder {
+				partition, msg, _ := p.pop()
+				order = append(order, msg.ID())
+				p.done(partition)
+			}
+			if diff := cmp.Diff(tc.wantOrder, order, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("expected the partition handed out in order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPartitionsReleaseOnlyTheirOwnHold(t *testing.T) {
+	p := newPartitions(&am.Ordered{PartitionKey: ddd.AggregateIDKey})
+	pushAll(p, testMessage("1"), testMessage("2"))
+
+	partition, msg, _ := p.pop()
+	first, _ := p.hold(partition, msg)
+	p.push(testMessage("1"))
+
+	// the message fails again, holding the partition a second time
+	partition, msg, _ = p.pop()
+	p.hold(partition, msg)
+
+	if released := p.release(partition, first); len(released) != 0 {
+		t.Errorf("expected an earlier hold not to release a later one, got %v", messageIDs(released))
+	}
+	if !p.waiting(testMessage("2")) {
+		t.Error("expected the partition to still be held")
+	}
+}
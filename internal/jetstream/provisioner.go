@@ -0,0 +1,250 @@
+package jetstream
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+)
+
+// Provisioner creates the stream and the durable consumers to the specs, or
+// brings the existing ones in line with them
+//
+// The settings JetStream allows to be changed are updated in place. Those
+// that would take recreating the stream or consumer, such as the retention of
+// a stream or the deliver policy of a consumer, are drift; it is logged and
+// the stream or consumer is used as it is, or when failOnDrift is set it is an
+// error instead.
+type Provisioner struct {
+	js          nats.JetStreamContext
+	specs       Specs
+	failOnDrift bool
+	logger      zerolog.Logger
+}
+
+// convertedKey marks in its metadata a consumer that has been converted from
+// a push consumer
+const convertedKey = "lunchbox.converted_from"
+
+// changes are the differences between an existing stream or consumer and its
+// spec; updates can be made in place while drift cannot
+type changes struct {
+	updates []string
+	drift   []string
+}
+
+func NewProvisioner(js nats.JetStreamContext, specs Specs, failOnDrift bool, logger zerolog.Logger) Provisioner {
+	return Provisioner{
+		js:          js,
+		specs:       specs,
+		failOnDrift: failOnDrift,
+		logger:      logger,
+	}
+}
+
+// ProvisionStream creates the stream or updates it to the spec
+func (p Provisioner) ProvisionStream(name string) error {
+	want := p.specs.Stream.config(name)
+
+	info, err := p.js.StreamInfo(name)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = p.js.AddStream(want)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg := info.Config
+	spec := p.specs.Stream
+
+	var c changes
+	if subjects := spec.subjects(name); !slices.Equal(cfg.Subjects, subjects) {
+		c.update("subjects", cfg.Subjects, subjects)
+		cfg.Subjects = subjects
+	}
+	if spec.Retention != "" && cfg.Retention != want.Retention {
+		c.drifted("retention", cfg.Retention, want.Retention)
+	}
+	if spec.MaxAge != 0 && cfg.MaxAge != spec.MaxAge {
+		c.update("max_age", cfg.MaxAge, spec.MaxAge)
+		cfg.MaxAge = spec.MaxAge
+	}
+	if spec.MaxBytes != 0 && cfg.MaxBytes != spec.MaxBytes {
+		c.update("max_bytes", cfg.MaxBytes, spec.MaxBytes)
+		cfg.MaxBytes = spec.MaxBytes
+	}
+	if spec.Replicas != 0 && cfg.Replicas != spec.Replicas {
+		c.update("replicas", cfg.Replicas, spec.Replicas)
+		cfg.Replicas = spec.Replicas
+	}
+	if spec.DuplicateWindow != 0 && cfg.Duplicates != spec.DuplicateWindow {
+		c.update("duplicate_window", cfg.Duplicates, spec.DuplicateWindow)
+		cfg.Duplicates = spec.DuplicateWindow
+	}
+
+	if err = p.checkDrift("the "+name+" stream", c); err != nil {
+		return err
+	}
+	if len(c.updates) == 0 {
+		return nil
+	}
+
+	if _, err = p.js.UpdateStream(&cfg); err != nil {
+		return err
+	}
+	p.logger.Info().Strs("Changes", c.updates).Msgf("updated the %s stream", name)
+
+	return nil
+}
+
+// ProvisionConsumer creates the durable consumer or updates it, after the
+// spec for it has been applied to the configuration the subscriber wants
+func (p Provisioner) ProvisionConsumer(streamName string, want *nats.ConsumerConfig) error {
+	if spec, exists := p.specs.Consumers[want.Durable]; exists {
+		if spec.DeliverPolicy != "" {
+			want.DeliverPolicy = deliverPolicies[spec.DeliverPolicy]
+		}
+		if len(spec.FilterSubjects) > 0 {
+			want.FilterSubject = ""
+			want.FilterSubjects = spec.FilterSubjects
+		}
+	}
+
+	// the dead letters of the consumer are re-driven to it alone; a filter
+	// that takes in the subject already would overlap it
+	if redrive := redriveSubject(streamName, want.Durable); !takesIn(filterSubjects(want), redrive) {
+		want.FilterSubjects = append(slices.Clone(filterSubjects(want)), redrive)
+		want.FilterSubject = ""
+	}
+
+	info, err := p.js.ConsumerInfo(streamName, want.Durable)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		_, err = p.js.AddConsumer(streamName, want)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg := info.Config
+
+	if cfg.DeliverSubject != "" && want.DeliverSubject == "" {
+		return p.convertToPull(streamName, info, want)
+	}
+
+	var c changes
+	if isPush, wantPush := cfg.DeliverSubject != "", want.DeliverSubject != ""; isPush != wantPush {
+		c.drifted("kind", consumerKind(isPush), consumerKind(wantPush))
+	} else if cfg.DeliverGroup != want.DeliverGroup {
+		c.drifted("deliver_group", cfg.DeliverGroup, want.DeliverGroup)
+	}
+	// a converted consumer kept the position of the push consumer rather
+	// than its deliver policy
+	if _, converted := cfg.Metadata[convertedKey]; !converted && cfg.DeliverPolicy != want.DeliverPolicy {
+		c.drifted("deliver_policy", deliverPolicyName(cfg.DeliverPolicy), deliverPolicyName(want.DeliverPolicy))
+	}
+	if cfg.AckPolicy != want.AckPolicy {
+		c.drifted("ack_policy", cfg.AckPolicy, want.AckPolicy)
+	}
+	if filters, wantFilters := filterSubjects(&cfg), filterSubjects(want); !slices.Equal(filters, wantFilters) {
+		c.update("filter_subjects", filters, wantFilters)
+		cfg.FilterSubject, cfg.FilterSubjects = want.FilterSubject, want.FilterSubjects
+	}
+	if want.AckWait != 0 && cfg.AckWait != want.AckWait {
+		c.update("ack_wait", cfg.AckWait, want.AckWait)
+		cfg.AckWait = want.AckWait
+	}
+	if cfg.MaxDeliver != want.MaxDeliver {
+		c.update("max_deliver", cfg.MaxDeliver, want.MaxDeliver)
+		cfg.MaxDeliver = want.MaxDeliver
+	}
+	if want.MaxAckPending != 0 && cfg.MaxAckPending != want.MaxAckPending {
+		c.update("max_ack_pending", cfg.MaxAckPending, want.MaxAckPending)
+		cfg.MaxAckPending = want.MaxAckPending
+	}
+
+	if err = p.checkDrift("the "+want.Durable+" consumer", c); err != nil {
+		return err
+	}
+	if len(c.updates) == 0 {
+		return nil
+	}
+
+	if _, err = p.js.UpdateConsumer(streamName, &cfg); err != nil {
+		return err
+	}
+	p.logger.Info().Strs("Changes", c.updates).Msgf("updated the %s consumer", want.Durable)
+
+	return nil
+}
+
+// convertToPull recreates the push consumer of a group that now fetches its
+// messages as a pull consumer
+//
+// The pull consumer starts after the last message the push consumer had Ack'd
+// along with every message before it, so only the messages it had Ack'd out of
+// order after that are delivered again. Instances still bound to the push
+// consumer stop receiving messages once it has been deleted.
+func (p Provisioner) convertToPull(streamName string, info *nats.ConsumerInfo, want *nats.ConsumerConfig) error {
+	if err := p.js.DeleteConsumer(streamName, want.Durable); err != nil {
+		return err
+	}
+
+	cfg := *want
+	cfg.DeliverPolicy = nats.DeliverByStartSequencePolicy
+	cfg.OptStartSeq = info.AckFloor.Stream + 1
+	cfg.Metadata = map[string]string{convertedKey: "push"}
+	if _, err := p.js.AddConsumer(streamName, &cfg); err != nil {
+		return err
+	}
+	p.logger.Warn().Msgf("recreated the %s consumer as a pull consumer starting at message %d", want.Durable, cfg.OptStartSeq)
+
+	return nil
+}
+
+func (p Provisioner) checkDrift(what string, c changes) error {
+	if len(c.drift) == 0 {
+		return nil
+	}
+	if p.failOnDrift {
+		return errors.Wrapf(errors.ErrFailedPrecondition, "%s cannot be brought in line with its spec without being recreated: %s", what, strings.Join(c.drift, "; "))
+	}
+	p.logger.Warn().Strs("Drift", c.drift).Msgf("%s differs from its spec in ways that need it to be recreated; using it as it is", what)
+
+	return nil
+}
+
+func (c *changes) update(setting string, from, to any) {
+	c.updates = append(c.updates, fmt.Sprintf("%s from %v to %v", setting, from, to))
+}
+
+func (c *changes) drifted(setting string, is, want any) {
+	c.drift = append(c.drift, fmt.Sprintf("%s is %v, not %v", setting, is, want))
+}
+
+func consumerKind(push bool) string {
+	if push {
+		return "push"
+	}
+	return "pull"
+}
+
+func deliverPolicyName(policy nats.DeliverPolicy) string {
+	for name, p := range deliverPolicies {
+		if p == policy {
+			return name
+		}
+	}
+	return fmt.Sprintf("policy %d", policy)
+}
+
+func filterSubjects(cfg *nats.ConsumerConfig) []string {
+	if cfg.FilterSubject != "" {
+		return []string{cfg.FilterSubject}
+	}
+	return cfg.FilterSubjects
+}
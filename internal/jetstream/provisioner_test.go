@@ -0,0 +1,223 @@
+package jetstream
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+)
+
+func testConsumerConfig() *nats.ConsumerConfig {
+	return &nats.ConsumerConfig{
+		Durable:       "group",
+		FilterSubject: testTopic,
+		AckPolicy:     nats.AckExplicitPolicy,
+		AckWait:       30 * time.Second,
+		MaxDeliver:    5,
+		MaxAckPending: 10,
+	}
+}
+
+func TestProvisionerCreates(t *testing.T) {
+	js := startTestServer(t)
+	specs := Specs{
+		Stream: StreamSpec{
+			Retention:       "interest",
+			MaxAge:          time.Hour,
+			MaxBytes:        1 << 20,
+			DuplicateWindow: time.Minute,
+		},
+		Consumers: map[string]ConsumerSpec{
+			"group": {DeliverPolicy: "new", FilterSubjects: []string{"test.events.Wanted", "test.events.Other"}},
+		},
+	}
+	provisioner := NewProvisioner(js, specs, true, zerolog.Nop())
+
+	if err := provisioner.ProvisionStream(testStreamName); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := js.StreamInfo(testStreamName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := stream.Config
+	if cfg.Retention != nats.InterestPolicy || cfg.MaxAge != time.Hour || cfg.MaxBytes != 1<<20 || cfg.Duplicates != time.Minute {
+		t.Errorf("expected the stream to be created to its spec, got %+v", cfg)
+	}
+	if len(cfg.Subjects) != 1 || cfg.Subjects[0] != "test.>" {
+		t.Errorf("expected the stream to default to every subject under its name, got %v", cfg.Subjects)
+	}
+
+	if err = provisioner.ProvisionConsumer(testStreamName, testConsumerConfig()); err != nil {
+		t.Fatal(err)
+	}
+	consumer, err := js.ConsumerInfo(testStreamName, "group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consumer.Config.DeliverPolicy != nats.DeliverNewPolicy {
+		t.Errorf("expected the deliver policy of the spec, got %v", consumer.Config.DeliverPolicy)
+	}
+	// the redrive subject of the consumer comes along with those of the spec
+	wantFilters := []string{"test.events.Wanted", "test.events.Other", "test.redrive.group"}
+	if diff := cmp.Diff(wantFilters, filterSubjects(&consumer.Config)); diff != "" {
+		t.Errorf("expected the filter subjects of the spec to replace the topic (-want +got):\n%s", diff)
+	}
+	if consumer.Config.AckWait != 30*time.Second || consumer.Config.MaxAckPending != 10 {
+		t.Errorf("expected the settings of the subscriber, got %+v", consumer.Config)
+	}
+}
+
+func TestProvisionerStreamSubjects(t *testing.T) {
+	tests := map[string]struct {
+		subjects []string
+		want     []string
+	}{
+		"every subject under the name of the stream": {
+			subjects: nil,
+			want:     []string{"test.>"},
+		},
+		"the subjects of the spec and the redrive subjects": {
+			subjects: []string{"test.events.>"},
+			want:     []string{"test.events.>", "test.redrive.>"},
+		},
+		"subjects that take in the redrive subjects already": {
+			subjects: []string{"test.>"},
+			want:     []string{"test.>"},
+		},
+		"the redrive subjects given in the spec": {
+			subjects: []string{"test.events.>", "test.redrive.>"},
+			want:     []string{"test.events.>", "test.redrive.>"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			js := startTestServer(t)
+			provisioner := NewProvisioner(js, Specs{Stream: StreamSpec{Subjects: tc.subjects}}, true, zerolog.Nop())
+			if err := provisioner.ProvisionStream(testStreamName); err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := js.StreamInfo(testStreamName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, info.Config.Subjects); diff != "" {
+				t.Errorf("the subjects of the stream are not the ones expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvisionerUpdatesInPlace(t *testing.T) {
+	js := startTestServer(t)
+	if err := NewProvisioner(js, Specs{Stream: StreamSpec{MaxAge: time.Hour}}, true, zerolog.Nop()).ProvisionStream(testStreamName); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewProvisioner(js, Specs{}, true, zerolog.Nop()).ProvisionConsumer(testStreamName, testConsumerConfig()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the max age of a stream and the AckWait of a consumer are changed in
+	// place, which is not drift even when drift fails the provisioning
+	provisioner := NewProvisioner(js, Specs{Stream: StreamSpec{MaxAge: 2 * time.Hour}}, true, zerolog.Nop())
+	if err := provisioner.ProvisionStream(testStreamName); err != nil {
+		t.Fatalf("expected the stream to be updated, got %v", err)
+	}
+	want := testConsumerConfig()
+	want.AckWait = time.Minute
+	if err := provisioner.ProvisionConsumer(testStreamName, want); err != nil {
+		t.Fatalf("expected the consumer to be updated, got %v", err)
+	}
+
+	stream, err := js.StreamInfo(testStreamName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stream.Config.MaxAge != 2*time.Hour {
+		t.Errorf("expected the max age to be updated to 2h, got %v", stream.Config.MaxAge)
+	}
+	consumer, err := js.ConsumerInfo(testStreamName, "group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consumer.Config.AckWait != time.Minute {
+		t.Errorf("expected the AckWait to be updated to 1m, got %v", consumer.Config.AckWait)
+	}
+}
+
+func TestProvisionerDrift(t *testing.T) {
+	tests := map[string]struct {
+		// provision brings the stream or consumer created with the defaults
+		// to a spec that changes a setting that cannot be changed in place
+		provision func(p Provisioner) error
+		// drifted reports whether the setting is still the one created
+		drifted func(t *testing.T, js nats.JetStreamContext) bool
+	}{
+		"the retention of a stream": {
+			provision: func(p Provisioner) error {
+				return p.ProvisionStream(testStreamName)
+			},
+			drifted: func(t *testing.T, js nats.JetStreamContext) bool {
+				info, err := js.StreamInfo(testStreamName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return info.Config.Retention == nats.LimitsPolicy
+			},
+		},
+		"the deliver policy of a consumer": {
+			provision: func(p Provisioner) error {
+				return p.ProvisionConsumer(testStreamName, testConsumerConfig())
+			},
+			drifted: func(t *testing.T, js nats.JetStreamContext) bool {
+				info, err := js.ConsumerInfo(testStreamName, "group")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return info.Config.DeliverPolicy == nats.DeliverAllPolicy
+			},
+		},
+	}
+	specs := Specs{
+		Stream:    StreamSpec{Retention: "interest"},
+		Consumers: map[string]ConsumerSpec{"group": {DeliverPolicy: "new"}},
+	}
+	for name, tc := range tests {
+		for mode, failOnDrift := range map[string]bool{"fails": true, "is logged": false} {
+			t.Run(name+" "+mode, func(t *testing.T) {
+				js := startTestServer(t)
+				created := NewProvisioner(js, Specs{}, true, zerolog.Nop())
+				if err := created.ProvisionStream(testStreamName); err != nil {
+					t.Fatal(err)
+				}
+				if err := created.ProvisionConsumer(testStreamName, testConsumerConfig()); err != nil {
+					t.Fatal(err)
+				}
+
+				var log bytes.Buffer
+				err := tc.provision(NewProvisioner(js, specs, failOnDrift, zerolog.New(&log)))
+				if failOnDrift {
+					if !errors.Is(err, errors.ErrFailedPrecondition) {
+						t.Errorf("expected the drift to fail the provisioning, got %v", err)
+					}
+				} else {
+					if err != nil {
+						t.Errorf("expected the drift to be logged, got %v", err)
+					}
+					if !strings.Contains(log.String(), "Drift") {
+						t.Errorf("expected the drift to be logged, got %q", log.String())
+					}
+				}
+				if !tc.drifted(t, js) {
+					t.Error("expected the setting to be left as it was")
+				}
+			})
+		}
+	}
+}
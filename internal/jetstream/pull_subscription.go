@@ -0,0 +1,363 @@
+package jetstream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/nats-io/nats.go"
+	"github.com/stackus/errors"
+)
+
+// pullFetchWait is how long a fetch waits for messages before asking again
+const pullFetchWait = 5 * time.Second
+
+// pullSubscription fetches the messages of a pull consumer and hands them to a
+// fixed pool of workers
+//
+// Every message fetched takes a slot until it has been handled, so no more
+// than MaxInFlight messages are held at a time, however many are waiting in
+// the stream; the consumer is created with the same MaxAckPending so the
+// server holds back the rest as well. The messages held are marked as in
+// progress every half AckWait, whether they are being handled or are still
+// waiting for a worker, unless they wait behind a partition that is held.
+//
+// For an Ordered subscription the workers handle one message of a partition
+// at a time. A message that is NAck'd holds its partition until the server
+// delivers it again, which it does ahead of new messages and even with
+// MaxAckPending reached; the messages of the partition fetched meanwhile give
+// their slots back while they wait, so the fetch always has a slot free for
+// it. When the message has not come back within half the AckWait, having gone
+// to another subscriber of the group, the hold is released and the messages
+// that waited are NAck'd to be delivered again after it. A message NAck'd for
+// the last of its MaxRedeliver deliveries is not delivered again and does not
+// hold its partition.
+type pullSubscription struct {
+	stream     *Stream
+	sub        *nats.Subscription
+	cfg        am.SubscriberConfig
+	pull       am.PullConsumer
+	handler    am.MessageHandler
+	filters    map[string]struct{}
+	slots      chan struct{}
+	partitions *partitions
+	mu         sync.Mutex
+	held       map[*rawMessage]struct{}
+	// the messages that gave their slot back to wait for their partition
+	unslotted map[*rawMessage]struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	fetcher   sync.WaitGroup
+	workers   sync.WaitGroup
+	// stopped ends the heartbeat once the workers are done
+	stopped   chan struct{}
+	heartbeat sync.WaitGroup
+	once      sync.Once
+	err       error
+}
+
+// pullSubscribe subscribes with a pull consumer; messages are always Ack'd
+// explicitly, those of an AckTypeAuto subscriber as soon as they are received
+//
+// A group that has been subscribed to with a push consumer already has a
+// durable consumer of that kind, which the provisioner recreates as a pull
+// consumer that carries on from where the push consumer was.
+func (s *Stream) pullSubscribe(topicName string, handler am.MessageHandler, subCfg am.SubscriberConfig, pull am.PullConsumer) (am.Subscription, error) {
+	var err error
+	var sub *nats.Subscription
+
+	ackWait := subCfg.AckWait()
+
+	if groupName := subCfg.GroupName(); groupName == "" {
+		// without a group each subscriber gets its own ephemeral consumer that
+		// only receives the messages published after it has subscribed
+		sub, err = s.js.PullSubscribe(topicName, "",
+			nats.DeliverNew(),
+			nats.AckExplicit(),
+			nats.AckWait(ackWait),
+			nats.MaxDeliver(subCfg.MaxRedeliver()),
+			nats.MaxAckPending(pull.MaxInFlight),
+		)
+	} else {
+		err = s.provisioner.ProvisionConsumer(s.streamName, &nats.ConsumerConfig{
+			Durable:       groupName,
+			FilterSubject: topicName,
+			AckPolicy:     nats.AckExplicitPolicy,
+			AckWait:       ackWait,
+			MaxDeliver:    subCfg.MaxRedeliver(),
+			MaxAckPending: pull.MaxInFlight,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sub, err = s.js.PullSubscribe(topicName, groupName, nats.Bind(s.streamName, groupName))
+		s.groups = append(s.groups, groupName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ordered *am.Ordered
+	if o, ok := subCfg.Ordered(); ok {
+		ordered = &o
+		handler = am.VersionCheck(o)(handler)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &pullSubscription{
+		stream:     s,
+		sub:        sub,
+		cfg:        subCfg,
+		pull:       pull,
+		handler:    handler,
+		filters:    messageFilters(subCfg),
+		slots:      make(chan struct{}, pull.MaxInFlight),
+		partitions: newPartitions(ordered),
+		held:       make(map[*rawMessage]struct{}),
+		unslotted:  make(map[*rawMessage]struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
+		stopped:    make(chan struct{}),
+	}
+
+	p.fetcher.Add(1)
+	go p.fetch()
+	p.heartbeat.Add(1)
+	go p.beat()
+	for i := 0; i < pull.Workers; i++ {
+		p.workers.Add(1)
+		go p.work()
+	}
+
+	s.subs = append(s.subs, p)
+
+	return p, nil
+}
+
+// Unsubscribe stops fetching and waits for the workers to handle the messages
+// already fetched before unsubscribing
+func (p *pullSubscription) Unsubscribe() error {
+	p.once.Do(func() {
+		p.cancel()
+		p.fetcher.Wait()
+		p.partitions.close()
+		p.workers.Wait()
+		close(p.stopped)
+		p.heartbeat.Wait()
+
+		if p.sub.IsValid() {
+			p.err = p.sub.Unsubscribe()
+		}
+	})
+
+	return p.err
+}
+
+func (p *pullSubscription) fetch() {
+	defer p.fetcher.Done()
+
+	for {
+		// wait for at least one free slot, then take as many more as the batch allows
+		select {
+		case <-p.ctx.Done():
+			return
+		case p.slots <- struct{}{}:
+		}
+		batch := 1
+	reserve:
+		for batch < p.pull.BatchSize {
+			select {
+			case p.slots <- struct{}{}:
+				batch++
+			default:
+				break reserve
+			}
+		}
+
+		// the messages are received as they arrive rather than once the batch
+		// is full, so none of them sit unseen by the heartbeat until the fetch
+		// is over
+		fCtx, cancel := context.WithTimeout(p.ctx, pullFetchWait)
+		received := 0
+		msgs, err := p.sub.FetchBatch(batch, nats.Context(fCtx))
+		if err == nil {
+			for msg := range msgs.Messages() {
+				received++
+				p.receive(msg)
+			}
+			err = msgs.Error()
+		}
+		cancel()
+
+		for i := received; i < batch; i++ {
+			<-p.slots
+		}
+
+		switch {
+		case err == nil:
+		case p.ctx.Err() != nil:
+			return
+		case errors.Is(err, nats.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+			// nothing to fetch for now
+		default:
+			p.stream.logger.Error().Err(err).Msg("failed to fetch messages")
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-time.After(pullFetchWait):
+			}
+		}
+	}
+}
+
+// receive holds the message for the workers; messages that are not to be
+// handled give their slot back straight away
+func (p *pullSubscription) receive(natsMsg *nats.Msg) {
+	msg, ok := p.stream.receive(natsMsg, p.filters)
+	if !ok {
+		<-p.slots
+		return
+	}
+
+	if p.cfg.AckType() == am.AckTypeAuto {
+		if err := msg.Ack(); err != nil {
+			p.stream.logger.Warn().Err(err).Msg("failed to auto-Ack a message")
+		}
+	} else {
+		p.mu.Lock()
+		p.held[msg] = struct{}{}
+		p.mu.Unlock()
+	}
+
+	parked, resumed := p.partitions.push(msg)
+	if parked {
+		p.mu.Lock()
+		p.unslotted[msg] = struct{}{}
+		p.mu.Unlock()
+		<-p.slots
+	}
+	// the messages that waited were left alone while their partition was held
+	p.extend(resumed)
+}
+
+func (p *pullSubscription) extend(msgs []*rawMessage) {
+	for _, msg := range msgs {
+		if err := msg.Extend(); err != nil {
+			p.stream.logger.Warn().Err(err).Msg("failed to mark a message as in progress")
+		}
+	}
+}
+
+// beat keeps the messages held from being delivered again
+func (p *pullSubscription) beat() {
+	defer p.heartbeat.Done()
+
+	ticker := time.NewTicker(p.cfg.AckWait() / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopped:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		for msg := range p.held {
+			if p.partitions.waiting(msg) {
+				continue
+			}
+			if err := msg.Extend(); err != nil {
+				p.stream.logger.Warn().Err(err).Msg("failed to mark a message as in progress")
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *pullSubscription) work() {
+	defer p.workers.Done()
+
+	for {
+		partition, msg, ok := p.partitions.pop()
+		if !ok {
+			return
+		}
+		p.handleMsg(msg)
+		if p.redelivered(msg) {
+			token, waiting := p.partitions.hold(partition, msg)
+			// the messages waiting are not kept in progress while the partition
+			// is held, so they are given a whole AckWait to outlast the hold
+			p.extend(waiting)
+			time.AfterFunc(p.cfg.AckWait()/2, func() {
+				p.release(partition, token)
+			})
+		} else {
+			p.partitions.done(partition)
+		}
+
+		p.giveBack(msg)
+	}
+}
+
+// release NAcks the messages that waited for a message that has not been
+// delivered again to this subscriber
+func (p *pullSubscription) release(partition string, token uint64) {
+	for _, msg := range p.partitions.release(partition, token) {
+		p.mu.Lock()
+		delete(p.held, msg)
+		p.mu.Unlock()
+
+		if err := msg.NAck(); err != nil {
+			p.stream.logger.Warn().Err(err).Msg("failed to Nack a message")
+		}
+		p.giveBack(msg)
+	}
+}
+
+// giveBack frees the slot of the message, unless it gave it back while it
+// waited for its partition
+func (p *pullSubscription) giveBack(msg *rawMessage) {
+	p.mu.Lock()
+	_, unslotted := p.unslotted[msg]
+	delete(p.unslotted, msg)
+	p.mu.Unlock()
+
+	if !unslotted {
+		<-p.slots
+	}
+}
+
+// redelivered reports whether the message is to be waited for by the rest of
+// its partition
+func (p *pullSubscription) redelivered(msg *rawMessage) bool {
+	if p.partitions.ordered == nil || !msg.nacked {
+		return false
+	}
+	maxRedeliver := p.cfg.MaxRedeliver()
+	return maxRedeliver <= 0 || msg.deliveries < uint64(maxRedeliver)
+}
+
+// handleMsg runs the handler; it is not cut off at the AckWait like it is for
+// a push consumer since the message is kept in progress until it returns
+func (p *pullSubscription) handleMsg(msg *rawMessage) {
+	err := p.handler.HandleMessage(context.Background(), msg)
+
+	p.mu.Lock()
+	delete(p.held, msg)
+	p.mu.Unlock()
+
+	logger := p.stream.logger
+	if err == nil {
+		if ackErr := msg.Ack(); ackErr != nil {
+			logger.Warn().Err(ackErr).Msg("failed to Ack a message")
+		}
+		return
+	}
+	logger.Error().Err(err).Msg("error while handling message")
+	if nakErr := msg.NAck(); nakErr != nil {
+		logger.Warn().Err(nakErr).Msg("failed to Nack a message")
+	}
+}
@@ -15,7 +15,11 @@ type rawMessage struct {
 	metadata   ddd.Metadata
 	sentAt     time.Time
 	receivedAt time.Time
+	// deliveries is how many times the message has been delivered, this
+	// time included
+	deliveries uint64
 	acked      bool
+	nacked     bool
 	ackFn      func() error
 	nackFn     func() error
 	extendFn   func() error
@@ -45,6 +49,7 @@ func (m *rawMessage) NAck() error {
 		return nil
 	}
 	m.acked = true
+	m.nacked = true
 	return m.nackFn()
 }
 
@@ -0,0 +1,77 @@
+package jetstream
+
+import (
+	"os"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stackus/errors"
+)
+
+const serverStartTimeout = 10 * time.Second
+
+// EmbeddedServer is a NATS server with JetStream enabled that runs within the
+// process, for local development and tests
+type EmbeddedServer struct {
+	server  *server.Server
+	tempDir string
+}
+
+// StartEmbeddedServer starts the server on the port, or on any free port when
+// port is -1, and returns once it accepts connections
+//
+// The streams are stored in storeDir; when it is blank they are stored in a
+// temporary directory that is removed on shutdown.
+func StartEmbeddedServer(port int, storeDir string) (*EmbeddedServer, error) {
+	s := &EmbeddedServer{}
+
+	if storeDir == "" {
+		tempDir, err := os.MkdirTemp("", "lunchbox-jetstream-")
+		if err != nil {
+			return nil, err
+		}
+		s.tempDir = tempDir
+		storeDir = tempDir
+	}
+
+	var err error
+	s.server, err = server.NewServer(&server.Options{
+		ServerName: "lunchbox",
+		Host:       "127.0.0.1",
+		Port:       port,
+		JetStream:  true,
+		StoreDir:   storeDir,
+		NoSigs:     true,
+		NoLog:      true,
+	})
+	if err != nil {
+		s.removeTempDir()
+		return nil, err
+	}
+
+	go s.server.Start()
+
+	if !s.server.ReadyForConnections(serverStartTimeout) {
+		s.Shutdown()
+		return nil, errors.ErrUnavailable.Msgf("the embedded NATS server did not start within %s", serverStartTimeout)
+	}
+
+	return s, nil
+}
+
+func (s *EmbeddedServer) ClientURL() string {
+	return s.server.ClientURL()
+}
+
+// Shutdown stops the server and removes the temporary directory, if any
+func (s *EmbeddedServer) Shutdown() {
+	s.server.Shutdown()
+	s.server.WaitForShutdown()
+	s.removeTempDir()
+}
+
+func (s *EmbeddedServer) removeTempDir() {
+	if s.tempDir != "" {
+		_ = os.RemoveAll(s.tempDir)
+	}
+}
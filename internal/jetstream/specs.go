@@ -0,0 +1,129 @@
+package jetstream
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stackus/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	retentionPolicies = map[string]nats.RetentionPolicy{
+		"limits":    nats.LimitsPolicy,
+		"interest":  nats.InterestPolicy,
+		"workqueue": nats.WorkQueuePolicy,
+	}
+
+	deliverPolicies = map[string]nats.DeliverPolicy{
+		"all":              nats.DeliverAllPolicy,
+		"new":              nats.DeliverNewPolicy,
+		"last":             nats.DeliverLastPolicy,
+		"last_per_subject": nats.DeliverLastPerSubjectPolicy,
+	}
+)
+
+type (
+	// Specs is the file describing the stream and the durable consumers of
+	// the groups subscribed to it; anything left out keeps the JetStream
+	// defaults, or for a consumer, the settings of the subscriber
+	//
+	//	stream:
+	//	  retention: limits
+	//	  max_age: 720h
+	//	  max_bytes: 10737418240
+	//	  replicas: 3
+	//	  duplicate_window: 2m
+	//	consumers:
+	//	  recommendation-restaurants:
+	//	    deliver_policy: new
+	//	    filter_subjects:
+	//	      - lunchbox.restaurant.events.Restaurant
+	Specs struct {
+		Stream    StreamSpec              `yaml:"stream"`
+		Consumers map[string]ConsumerSpec `yaml:"consumers"`
+	}
+
+	// StreamSpec describes the stream; the subjects default to every subject
+	// under the name of the stream, and the redrive subjects under
+	// "<name>.redrive." are added to those given when they do not overlap
+	StreamSpec struct {
+		Subjects        []string      `yaml:"subjects"`
+		Retention       string        `yaml:"retention"`
+		MaxAge          time.Duration `yaml:"max_age"`
+		MaxBytes        int64         `yaml:"max_bytes"`
+		Replicas        int           `yaml:"replicas"`
+		DuplicateWindow time.Duration `yaml:"duplicate_window"`
+	}
+
+	// ConsumerSpec describes the durable consumer of a group; the filter
+	// subjects replace the subject the group subscribes to
+	ConsumerSpec struct {
+		DeliverPolicy  string   `yaml:"deliver_policy"`
+		FilterSubjects []string `yaml:"filter_subjects"`
+	}
+)
+
+func LoadSpecs(filename string) (Specs, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Specs{}, err
+	}
+
+	return ParseSpecs(data)
+}
+
+func ParseSpecs(data []byte) (Specs, error) {
+	var specs Specs
+	if err := yaml.Unmarshal(data, &specs); err != nil {
+		return Specs{}, err
+	}
+
+	stream := specs.Stream
+	if _, exists := retentionPolicies[stream.Retention]; stream.Retention != "" && !exists {
+		return Specs{}, errors.Wrapf(errors.ErrBadRequest, "the stream has an unknown retention %q", stream.Retention)
+	}
+	if stream.MaxAge < 0 || stream.MaxBytes < 0 || stream.Replicas < 0 || stream.DuplicateWindow < 0 {
+		return Specs{}, errors.Wrap(errors.ErrBadRequest, "the limits of the stream cannot be negative")
+	}
+	for name, consumer := range specs.Consumers {
+		if _, exists := deliverPolicies[consumer.DeliverPolicy]; consumer.DeliverPolicy != "" && !exists {
+			return Specs{}, errors.Wrapf(errors.ErrBadRequest, "consumer %q has an unknown deliver policy %q", name, consumer.DeliverPolicy)
+		}
+	}
+
+	return specs, nil
+}
+
+// config returns the configuration of a new stream
+func (s StreamSpec) config(name string) *nats.StreamConfig {
+	cfg := &nats.StreamConfig{
+		Name:       name,
+		Subjects:   s.subjects(name),
+		Retention:  retentionPolicies[s.Retention],
+		MaxAge:     s.MaxAge,
+		MaxBytes:   -1,
+		Replicas:   s.Replicas,
+		Duplicates: s.DuplicateWindow,
+	}
+	if s.MaxBytes > 0 {
+		cfg.MaxBytes = s.MaxBytes
+	}
+
+	return cfg
+}
+
+// subjects take in the redrive subjects of the consumers as well, unless
+// those given overlap them, which the stream would not allow
+func (s StreamSpec) subjects(name string) []string {
+	if len(s.Subjects) == 0 {
+		return []string{fmt.Sprintf("%s.>", name)}
+	}
+	if redrive := fmt.Sprintf("%s.redrive.>", name); !takesIn(s.Subjects, redrive) {
+		return append(slices.Clone(s.Subjects), redrive)
+	}
+	return s.Subjects
+}
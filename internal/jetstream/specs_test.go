@@ -0,0 +1,104 @@
+package jetstream
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testSpecs = `
+stream:
+  retention: interest
+  max_age: 720h
+  max_bytes: 10737418240
+  replicas: 3
+  duplicate_window: 2m
+consumers:
+  group:
+    deliver_policy: new
+    filter_subjects:
+      - test.events.Wanted
+`
+
+func TestParseSpecs(t *testing.T) {
+	tests := map[string]struct {
+		data      string
+		want      Specs
+		wantError bool
+	}{
+		"every setting": {
+			data: testSpecs,
+			want: Specs{
+				Stream: StreamSpec{
+					Retention:       "interest",
+					MaxAge:          720 * time.Hour,
+					MaxBytes:        10737418240,
+					Replicas:        3,
+					DuplicateWindow: 2 * time.Minute,
+				},
+				Consumers: map[string]ConsumerSpec{
+					"group": {DeliverPolicy: "new", FilterSubjects: []string{"test.events.Wanted"}},
+				},
+			},
+		},
+		"nothing": {
+			data: "",
+			want: Specs{},
+		},
+		"an unknown retention": {
+			data:      "stream:\n  retention: forever\n",
+			wantError: true,
+		},
+		"a negative limit": {
+			data:      "stream:\n  max_bytes: -1\n",
+			wantError: true,
+		},
+		"an unknown deliver policy": {
+			data:      "consumers:\n  group:\n    deliver_policy: oldest\n",
+			wantError: true,
+		},
+		"yaml that is not a spec": {
+			data:      "stream: [",
+			wantError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			specs, err := ParseSpecs([]byte(tc.data))
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", specs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, specs); diff != "" {
+				t.Errorf("the specs parsed are not the ones expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadSpecs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "specs.yaml")
+	if err := os.WriteFile(filename, []byte(testSpecs), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	specs, err := LoadSpecs(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if specs.Stream.Retention != "interest" || specs.Consumers["group"].DeliverPolicy != "new" {
+		t.Errorf("expected the specs of the file, got %+v", specs)
+	}
+
+	if _, err = LoadSpecs(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected a missing file to be an error")
+	}
+}
@@ -2,15 +2,15 @@ package jetstream
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/health"
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog"
-	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/structpb"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/stackus/errors"
 )
 
 const ContainerKey = "container.stream"
@@ -18,47 +18,38 @@ const ContainerKey = "container.stream"
 const maxRetries = 5
 
 type Stream struct {
-	streamName string
-	js         nats.JetStreamContext
-	mu         sync.Mutex
-	subs       []*nats.Subscription
-	logger     zerolog.Logger
+	streamName  string
+	js          nats.JetStreamContext
+	provisioner Provisioner
+	format      WireFormat
+	mu          sync.Mutex
+	subs        []am.Subscription
+	groups      []string
+	logger      zerolog.Logger
 }
 
 var _ am.MessageStream = (*Stream)(nil)
 
-func NewStream(streamName string, js nats.JetStreamContext, logger zerolog.Logger) *Stream {
+func NewStream(streamName string, js nats.JetStreamContext, provisioner Provisioner, format WireFormat, logger zerolog.Logger) *Stream {
 	return &Stream{
-		streamName: streamName,
-		js:         js,
-		logger:     logger,
+		streamName:  streamName,
+		js:          js,
+		provisioner: provisioner,
+		format:      format,
+		logger:      logger,
 	}
 }
 
 func (s *Stream) Publish(ctx context.Context, topicName string, rawMsg am.Message) (err error) {
-	var data []byte
+	var natsMsg *nats.Msg
 
-	metadata, err := structpb.NewStruct(rawMsg.Metadata())
-	if err != nil {
-		return err
-	}
-
-	data, err = proto.Marshal(&StreamMessage{
-		Id:       rawMsg.ID(),
-		Name:     rawMsg.MessageName(),
-		Data:     rawMsg.Data(),
-		Metadata: metadata,
-		SentAt:   timestamppb.New(rawMsg.SentAt()),
-	})
+	natsMsg, err = s.format.encode(rawMsg)
 	if err != nil {
 		return
 	}
 
 	var p nats.PubAckFuture
-	p, err = s.js.PublishMsgAsync(&nats.Msg{
-		Subject: rawMsg.Subject(),
-		Data:    data,
-	}, nats.MsgId(rawMsg.ID()))
+	p, err = s.js.PublishMsgAsync(natsMsg, nats.MsgId(rawMsg.ID()))
 	if err != nil {
 		return
 	}
@@ -99,7 +90,20 @@ func (s *Stream) Subscribe(topicName string, handler am.MessageHandler, options
 
 	subCfg := am.NewSubscriberConfig(options)
 
+	if pull, ok := subCfg.PullConsumer(); ok {
+		return s.pullSubscribe(topicName, handler, subCfg, pull)
+	}
+
+	// a push consumer cannot keep a partition back without holding back every
+	// other partition as well
+	if _, ok := subCfg.Ordered(); ok {
+		return nil, errors.Wrap(errors.ErrBadRequest, "an Ordered subscription needs a PullConsumer")
+	}
+
+	// the messages are settled by handleMsg; left to the client, a message
+	// given up on after its AckWait would be Ack'd rather than redelivered
 	opts := []nats.SubOpt{
+		nats.ManualAck(),
 		nats.MaxDeliver(subCfg.MaxRedeliver()),
 	}
 	cfg := &nats.ConsumerConfig{
@@ -111,8 +115,6 @@ func (s *Stream) Subscribe(topicName string, handler am.MessageHandler, options
 		cfg.DeliverSubject = groupName
 		cfg.DeliverGroup = groupName
 		cfg.Durable = groupName
-
-		opts = append(opts, nats.Bind(s.streamName, groupName), nats.Durable(groupName))
 	}
 
 	if ackType := subCfg.AckType(); ackType != am.AckTypeAuto {
@@ -127,30 +129,60 @@ func (s *Stream) Subscribe(topicName string, handler am.MessageHandler, options
 		opts = append(opts, nats.AckNone())
 	}
 
-	_, err = s.js.AddConsumer(s.streamName, cfg)
-	if err != nil {
-		return nil, err
-	}
-
 	var sub *nats.Subscription
 
 	if groupName := subCfg.GroupName(); groupName == "" {
+		// without a group each subscriber gets its own ephemeral consumer that
+		// only receives the messages published after it has subscribed
+		opts = append(opts, nats.DeliverNew())
 		sub, err = s.js.Subscribe(topicName, s.handleMsg(subCfg, handler), opts...)
 	} else {
-		sub, err = s.js.QueueSubscribe(topicName, groupName, s.handleMsg(subCfg, handler), opts...)
+		err = s.provisioner.ProvisionConsumer(s.streamName, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		// the consumer is bound to as it is, as it may have drifted from cfg
+		sub, err = s.js.QueueSubscribe(topicName, groupName, s.handleMsg(subCfg, handler), nats.Bind(s.streamName, groupName), nats.ManualAck())
+		s.groups = append(s.groups, groupName)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	s.subs = append(s.subs, sub)
+	s.subs = append(s.subs, subscription{sub})
 
 	return subscription{sub}, nil
 }
 
-func (s *Stream) Unsubscribe() error {
-	for _, sub := range s.subs {
-		if !sub.IsValid() {
-			continue
+// ConsumerLagCheck fails while any of the durable consumers of the groups
+// subscribed to has more than threshold messages waiting to be delivered
+func (s *Stream) ConsumerLagCheck(threshold uint64) health.CheckFunc {
+	return func(ctx context.Context) error {
+		s.mu.Lock()
+		groups := append([]string(nil), s.groups...)
+		s.mu.Unlock()
+
+		for _, group := range groups {
+			info, err := s.js.ConsumerInfo(s.streamName, group, nats.Context(ctx))
+			if err != nil {
+				return err
+			}
+			if info.NumPending > threshold {
+				return fmt.Errorf("the %s consumer is %d messages behind, more than the %d allowed", group, info.NumPending, threshold)
+			}
 		}
-		err := sub.Drain()
+		return nil
+	}
+}
+
+func (s *Stream) Unsubscribe() error {
+	s.mu.Lock()
+	subs := s.subs
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		err := sub.Unsubscribe()
 		if err != nil {
 			return err
 		}
@@ -159,53 +191,21 @@ func (s *Stream) Unsubscribe() error {
 }
 
 func (s *Stream) handleMsg(cfg am.SubscriberConfig, handler am.MessageHandler) func(*nats.Msg) {
-	var filters map[string]struct{}
-	if len(cfg.MessageFilters()) > 0 {
-		filters = make(map[string]struct{})
-		for _, key := range cfg.MessageFilters() {
-			filters[key] = struct{}{}
-		}
-	}
+	filters := messageFilters(cfg)
 
 	return func(natsMsg *nats.Msg) {
 		var err error
 
-		m := &StreamMessage{}
-		err = proto.Unmarshal(natsMsg.Data, m)
-		if err != nil {
-			s.logger.Warn().Err(err).Msg("failed to unmarshal the *nats.Msg")
+		msg, ok := s.receive(natsMsg, filters)
+		if !ok {
 			return
 		}
 
-		if filters != nil {
-			if _, exists := filters[m.GetName()]; !exists {
-				err = natsMsg.Ack()
-				if err != nil {
-					s.logger.Warn().Err(err).Msg("failed to Ack a filtered message")
-				}
-				return
-			}
-		}
-
-		msg := &rawMessage{
-			id:         m.GetId(),
-			name:       m.GetName(),
-			subject:    natsMsg.Subject,
-			data:       m.GetData(),
-			metadata:   m.GetMetadata().AsMap(),
-			sentAt:     m.SentAt.AsTime(),
-			receivedAt: time.Now(),
-			acked:      false,
-			ackFn:      func() error { return natsMsg.Ack() },
-			nackFn:     func() error { return natsMsg.Nak() },
-			extendFn:   func() error { return natsMsg.InProgress() },
-			killFn:     func() error { return natsMsg.Term() },
-		}
-
 		wCtx, cancel := context.WithTimeout(context.Background(), cfg.AckWait())
 		defer cancel()
 
-		errc := make(chan error)
+		// the handler that is given up on still has somewhere to send its result
+		errc := make(chan error, 1)
 		go func() {
 			errc <- handler.HandleMessage(wCtx, msg)
 		}()
@@ -235,3 +235,46 @@ func (s *Stream) handleMsg(cfg am.SubscriberConfig, handler am.MessageHandler) f
 		}
 	}
 }
+
+// receive decodes the message; messages that could not be decoded, and those
+// filtered out which are Ack'd straight away, are not to be handled
+func (s *Stream) receive(natsMsg *nats.Msg, filters map[string]struct{}) (*rawMessage, bool) {
+	msg, err := decode(natsMsg)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("failed to decode the *nats.Msg")
+		return nil, false
+	}
+
+	if filters != nil {
+		if _, exists := filters[msg.name]; !exists {
+			err = natsMsg.Ack()
+			if err != nil {
+				s.logger.Warn().Err(err).Msg("failed to Ack a filtered message")
+			}
+			return nil, false
+		}
+	}
+
+	msg.receivedAt = time.Now()
+	if meta, err := natsMsg.Metadata(); err == nil {
+		msg.deliveries = meta.NumDelivered
+	}
+	msg.ackFn = func() error { return natsMsg.Ack() }
+	msg.nackFn = func() error { return natsMsg.Nak() }
+	msg.extendFn = func() error { return natsMsg.InProgress() }
+	msg.killFn = func() error { return natsMsg.Term() }
+
+	return msg, true
+}
+
+func messageFilters(cfg am.SubscriberConfig) map[string]struct{} {
+	if len(cfg.MessageFilters()) == 0 {
+		return nil
+	}
+
+	filters := make(map[string]struct{})
+	for _, key := range cfg.MessageFilters() {
+		filters[key] = struct{}{}
+	}
+	return filters
+}
@@ -0,0 +1,593 @@
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+)
+
+const (
+	testStreamName = "test"
+	testTopic      = "test.events"
+	// waitFor is how long a test waits on the server before failing
+	waitFor = 5 * time.Second
+)
+
+// startTestServer runs an embedded server for the test and returns a
+// JetStream context connected to it
+func startTestServer(t *testing.T) nats.JetStreamContext {
+	t.Helper()
+
+	server, err := StartEmbeddedServer(-1, t.TempDir())
+	if err != nil {
+		t.Fatalf("starting the embedded server: %v", err)
+	}
+	t.Cleanup(server.Shutdown)
+
+	nc, err := nats.Connect(server.ClientURL())
+	if err != nil {
+		t.Fatalf("connecting to the embedded server: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return js
+}
+
+// startTestStream runs an embedded server for the test and returns a stream
+// provisioned on it, along with its JetStream context
+func startTestStream(t *testing.T, format WireFormat) (*Stream, nats.JetStreamContext) {
+	t.Helper()
+
+	js := startTestServer(t)
+	provisioner := NewProvisioner(js, Specs{}, false, zerolog.Nop())
+	if err := provisioner.ProvisionStream(testStreamName); err != nil {
+		t.Fatalf("provisioning the stream: %v", err)
+	}
+
+	stream := NewStream(testStreamName, js, provisioner, format, zerolog.Nop())
+	t.Cleanup(func() { _ = stream.Unsubscribe() })
+
+	return stream, js
+}
+
+func testMessage(id string) *rawMessage {
+	return &rawMessage{
+		id:       id,
+		name:     "test.Event",
+		subject:  testTopic,
+		data:     []byte(`{"id":"` + id + `"}`),
+		metadata: ddd.Metadata{ddd.AggregateIDKey: "aggregate-id"},
+		sentAt:   time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// summary is what a message published has to arrive with
+type summary struct {
+	ID, Name, Subject, Data string
+	Metadata                ddd.Metadata
+	SentAt                  time.Time
+}
+
+func summarize(msg am.Message) summary {
+	return summary{msg.ID(), msg.MessageName(), msg.Subject(), string(msg.Data()), msg.Metadata(), msg.SentAt().UTC()}
+}
+
+func publish(t *testing.T, stream *Stream, ids ...string) {
+	t.Helper()
+
+	for _, id := range ids {
+		if err := stream.Publish(context.Background(), testTopic, testMessage(id)); err != nil {
+			t.Fatalf("publishing %s: %v", id, err)
+		}
+	}
+}
+
+// handled records the ids of the messages a handler was given, and how many
+// times each was given
+type handled struct {
+	mu    sync.Mutex
+	times map[string]int
+	c     chan string
+}
+
+func newHandled() *handled {
+	return &handled{
+		times: make(map[string]int),
+		c:     make(chan string, 100),
+	}
+}
+
+func (h *handled) add(id string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.times[id]++
+	h.c <- id
+	return h.times[id]
+}
+
+func (h *handled) count(id string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.times[id]
+}
+
+// wait returns once n messages have been handled
+func (h *handled) wait(t *testing.T, n int) {
+	t.Helper()
+
+	timeout := time.After(waitFor)
+	for i := 0; i < n; i++ {
+		select {
+		case <-h.c:
+		case <-timeout:
+			t.Fatalf("expected %d messages to be handled, %d were", n, i)
+		}
+	}
+}
+
+func TestStreamPublishSubscribe(t *testing.T) {
+	for _, format := range []WireFormat{HeaderFormat, EnvelopeFormat} {
+		t.Run(string(format), func(t *testing.T) {
+			stream, _ := startTestStream(t, format)
+
+			received := make(chan am.IncomingMessage, 1)
+			_, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+				received <- msg
+				return nil
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			publish(t, stream, "1")
+
+			select {
+			case msg := <-received:
+				want := testMessage("1")
+				if diff := cmp.Diff(summarize(want), summarize(msg)); diff != "" {
+					t.Errorf("the message received is not the one published (-want +got):\n%s", diff)
+				}
+			case <-time.After(waitFor):
+				t.Fatal("expected the message to be received")
+			}
+		})
+	}
+}
+
+func TestStreamQueueGroups(t *testing.T) {
+	stream, _ := startTestStream(t, HeaderFormat)
+
+	got := newHandled()
+	handler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		got.add(msg.ID())
+		return nil
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := stream.Subscribe(testTopic, handler, am.GroupName("group")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+	publish(t, stream, ids...)
+	got.wait(t, len(ids))
+
+	// give a duplicate delivery the chance to show up
+	time.Sleep(100 * time.Millisecond)
+	for _, id := range ids {
+		if times := got.count(id); times != 1 {
+			t.Errorf("expected message %s to be handled once by the group, it was handled %d times", id, times)
+		}
+	}
+}
+
+func TestStreamGroupsReceiveEarlierMessages(t *testing.T) {
+	stream, _ := startTestStream(t, HeaderFormat)
+	publish(t, stream, "1")
+
+	got := newHandled()
+	_, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		got.add(msg.ID())
+		return nil
+	}), am.GroupName("group"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got.wait(t, 1)
+}
+
+func TestStreamMessageFilters(t *testing.T) {
+	stream, _ := startTestStream(t, HeaderFormat)
+
+	got := newHandled()
+	_, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		got.add(msg.MessageName())
+		return nil
+	}), am.GroupName("group"), am.MessageFilter{"test.Wanted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unwanted := testMessage("1")
+	unwanted.name = "test.Unwanted"
+	wanted := testMessage("2")
+	wanted.name = "test.Wanted"
+	for _, msg := range []*rawMessage{unwanted, wanted} {
+		if err = stream.Publish(context.Background(), testTopic, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got.wait(t, 1)
+	if got.count("test.Unwanted") != 0 {
+		t.Error("expected the unwanted message not to be handled")
+	}
+}
+
+func TestStreamRedelivery(t *testing.T) {
+	tests := map[string]struct {
+		options []am.SubscriberOption
+		// settle fails or settles the message of the given delivery
+		settle         func(msg am.IncomingMessage, delivery int) error
+		wantDeliveries int
+		wantDeadLetter string
+	}{
+		"an error is delivered again": {
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				if delivery == 1 {
+					return fmt.Errorf("failed")
+				}
+				return nil
+			},
+			wantDeliveries: 2,
+		},
+		"a message not acked within the AckWait is delivered again": {
+			options: []am.SubscriberOption{am.AckWait(200 * time.Millisecond)},
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				if delivery == 1 {
+					time.Sleep(300 * time.Millisecond)
+				}
+				return nil
+			},
+			wantDeliveries: 2,
+		},
+		"a message failing MaxRedeliver times is a dead letter": {
+			options: []am.SubscriberOption{am.MaxRedeliver(3)},
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				return fmt.Errorf("failed")
+			},
+			wantDeliveries: 3,
+			wantDeadLetter: "max deliveries reached",
+		},
+		"a killed message is a dead letter": {
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				return msg.Kill()
+			},
+			wantDeliveries: 1,
+			wantDeadLetter: "terminated",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stream, js := startTestStream(t, HeaderFormat)
+			deadLetters := NewDeadLetters(testStreamName, js)
+			if err := deadLetters.Provision(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := newHandled()
+			handler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+				return tc.settle(msg, got.add(msg.ID()))
+			})
+			if _, err := stream.Subscribe(testTopic, handler, append(tc.options, am.GroupName("group"))...); err != nil {
+				t.Fatal(err)
+			}
+			publish(t, stream, "1")
+			got.wait(t, tc.wantDeliveries)
+
+			if tc.wantDeadLetter == "" {
+				// give an extra delivery the chance to show up
+				time.Sleep(300 * time.Millisecond)
+				if times := got.count("1"); times != tc.wantDeliveries {
+					t.Errorf("expected %d deliveries, got %d", tc.wantDeliveries, times)
+				}
+				return
+			}
+
+			var list []DeadLetter
+			for deadline := time.Now().Add(waitFor); len(list) == 0 && time.Now().Before(deadline); {
+				time.Sleep(20 * time.Millisecond)
+				var err error
+				if list, err = deadLetters.List(10); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(list) != 1 {
+				t.Fatalf("expected a dead letter, got %v", list)
+			}
+			deadLetter := list[0]
+			if deadLetter.Consumer != "group" || deadLetter.MessageID != "1" || deadLetter.Reason != tc.wantDeadLetter {
+				t.Errorf("expected message 1 to be a dead letter of the group for %q, got %+v", tc.wantDeadLetter, deadLetter)
+			}
+			if times := got.count("1"); times != tc.wantDeliveries {
+				t.Errorf("expected %d deliveries, got %d", tc.wantDeliveries, times)
+			}
+		})
+	}
+}
+
+func TestDeadLettersRedrive(t *testing.T) {
+	stream, js := startTestStream(t, HeaderFormat)
+	deadLetters := NewDeadLetters(testStreamName, js)
+	if err := deadLetters.Provision(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, other := newHandled(), newHandled()
+	_, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		if got.add(msg.ID()) == 1 {
+			return msg.Kill()
+		}
+		if msg.Subject() != testTopic {
+			t.Errorf("expected the message to be re-driven with its subject %s, got %s", testTopic, msg.Subject())
+		}
+		return nil
+	}), am.GroupName("group"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		other.add(msg.ID())
+		return nil
+	}), am.GroupName("other"), am.PullConsumer{}); err != nil {
+		t.Fatal(err)
+	}
+	publish(t, stream, "1")
+	got.wait(t, 1)
+	other.wait(t, 1)
+
+	var list []DeadLetter
+	for deadline := time.Now().Add(waitFor); len(list) == 0 && time.Now().Before(deadline); {
+		time.Sleep(20 * time.Millisecond)
+		if list, err = deadLetters.List(10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected a dead letter, got %v", list)
+	}
+
+	if err = deadLetters.Redrive(list[0].Sequence); err != nil {
+		t.Fatalf("redriving: %v", err)
+	}
+	got.wait(t, 1)
+
+	// give a delivery to the group that did not give up the chance to show up
+	time.Sleep(100 * time.Millisecond)
+	if times := other.count("1"); times != 1 {
+		t.Errorf("expected the dead letter to be re-driven to the group that gave up on it alone, the other group got it %d times", times)
+	}
+	if list, err = deadLetters.List(10); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Errorf("expected the dead letter to be removed once re-driven, got %v", list)
+	}
+}
+
+func TestDeadLettersRedriveNeedsTheConsumer(t *testing.T) {
+	stream, js := startTestStream(t, HeaderFormat)
+	deadLetters := NewDeadLetters(testStreamName, js)
+	if err := deadLetters.Provision(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := newHandled()
+	sub, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		got.add(msg.ID())
+		return msg.Kill()
+	}), am.GroupName("group"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	publish(t, stream, "1")
+	got.wait(t, 1)
+
+	var list []DeadLetter
+	for deadline := time.Now().Add(waitFor); len(list) == 0 && time.Now().Before(deadline); {
+		time.Sleep(20 * time.Millisecond)
+		if list, err = deadLetters.List(10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected a dead letter, got %v", list)
+	}
+
+	if err = sub.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+	if err = js.DeleteConsumer(testStreamName, "group"); err != nil {
+		t.Fatal(err)
+	}
+	if err = deadLetters.Redrive(list[0].Sequence); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("expected a dead letter of a consumer that is gone not to be re-driven, got %v", err)
+	}
+	if list, err = deadLetters.List(10); err != nil || len(list) != 1 {
+		t.Errorf("expected the dead letter to be kept, got %v, %v", list, err)
+	}
+}
+
+func TestStreamOrderedRedelivery(t *testing.T) {
+	pull := am.PullConsumer{BatchSize: 10, MaxInFlight: 10, Workers: 4}
+	tests := map[string]struct {
+		options []am.SubscriberOption
+		// failing is how many deliveries of message 1 fail
+		failing   int
+		wantOrder []string
+	}{
+		"pull": {
+			options:   []am.SubscriberOption{pull},
+			failing:   1,
+			wantOrder: []string{"1", "1", "2", "3"},
+		},
+		"pull with one message in flight": {
+			options:   []am.SubscriberOption{am.PullConsumer{BatchSize: 1, MaxInFlight: 1, Workers: 1}},
+			failing:   1,
+			wantOrder: []string{"1", "1", "2", "3"},
+		},
+		"pull moves on once a message runs out of redeliveries": {
+			options:   []am.SubscriberOption{pull, am.MaxRedeliver(2)},
+			failing:   2,
+			wantOrder: []string{"1", "1", "2", "3"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stream, _ := startTestStream(t, HeaderFormat)
+			// the messages are published first so they are fetched together
+			publish(t, stream, "1", "2", "3")
+
+			got := newHandled()
+			handler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+				if got.add(msg.ID()) <= tc.failing && msg.ID() == "1" {
+					return fmt.Errorf("failed")
+				}
+				return nil
+			})
+			options := append(tc.options, am.GroupName("group"), am.Ordered{})
+			if _, err := stream.Subscribe(testTopic, handler, options...); err != nil {
+				t.Fatal(err)
+			}
+
+			var order []string
+			timeout := time.After(waitFor)
+			for len(order) < len(tc.wantOrder) {
+				select {
+				case id := <-got.c:
+					order = append(order, id)
+				case <-timeout:
+					t.Fatalf("expected %d deliveries, got %v", len(tc.wantOrder), order)
+				}
+			}
+			if diff := cmp.Diff(tc.wantOrder, order); diff != "" {
+				t.Errorf("the messages were handled out of order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamGroupsMoveFromPushToPull(t *testing.T) {
+	stream, js := startTestStream(t, HeaderFormat)
+
+	pushed := newHandled()
+	sub, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		pushed.add(msg.ID())
+		return nil
+	}), am.GroupName("group"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	publish(t, stream, "1", "2")
+	pushed.wait(t, 2)
+	// let the Acks reach the server before the consumer is recreated
+	time.Sleep(100 * time.Millisecond)
+	if err = sub.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+	publish(t, stream, "3")
+
+	pulled := newHandled()
+	_, err = stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		pulled.add(msg.ID())
+		return nil
+	}), am.GroupName("group"), am.PullConsumer{})
+	if err != nil {
+		t.Fatalf("expected the group to move to a pull consumer, got %v", err)
+	}
+	pulled.wait(t, 1)
+
+	// give a message handled before the move the chance to show up
+	time.Sleep(300 * time.Millisecond)
+	for id, want := range map[string]int{"1": 0, "2": 0, "3": 1} {
+		if times := pulled.count(id); times != want {
+			t.Errorf("expected message %s to be pulled %d times, got %d", id, want, times)
+		}
+	}
+
+	info, err := js.ConsumerInfo(testStreamName, "group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Config.DeliverSubject != "" {
+		t.Errorf("expected a pull consumer, got one delivering to %q", info.Config.DeliverSubject)
+	}
+}
+
+func TestStreamOrderedNeedsAPullConsumer(t *testing.T) {
+	stream, _ := startTestStream(t, HeaderFormat)
+
+	_, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		return nil
+	}), am.GroupName("group"), am.Ordered{})
+	if err == nil {
+		t.Error("expected an Ordered push subscription to be rejected")
+	}
+}
+
+func TestStreamOrderedGroupsDoNotWaitForEachOther(t *testing.T) {
+	stream, _ := startTestStream(t, HeaderFormat)
+
+	got := newHandled()
+	handler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		if got.add(msg.ID()) == 1 && msg.ID() == "1" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	// whichever subscriber message 1 is delivered to again, the other does
+	// not hold the partition for longer than half the AckWait
+	for i := 0; i < 2; i++ {
+		_, err := stream.Subscribe(testTopic, handler, am.GroupName("group"), am.Ordered{},
+			am.PullConsumer{BatchSize: 5}, am.AckWait(time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i + 1)
+	}
+	publish(t, stream, ids...)
+	got.wait(t, len(ids)+1)
+
+	for _, id := range ids {
+		want := 1
+		if id == "1" {
+			want = 2
+		}
+		if times := got.count(id); times != want {
+			t.Errorf("expected message %s to be handled %d times, got %d", id, want, times)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package jetstream
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/nats-io/nats.go"
+	"github.com/stackus/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WireFormat is how the messages are laid out when they are published; both
+// are decoded regardless
+type WireFormat string
+
+const (
+	// HeaderFormat publishes the data of the message as the body, with the
+	// id, name, sent at and every metadata key in headers of their own
+	HeaderFormat WireFormat = "headers"
+	// EnvelopeFormat publishes the message wrapped in a StreamMessage, for
+	// while there are subscribers yet to be updated that only decode those
+	EnvelopeFormat WireFormat = "envelope"
+)
+
+const (
+	idHdr     = "Lunchbox-Id"
+	nameHdr   = "Lunchbox-Name"
+	sentAtHdr = "Lunchbox-Sent-At"
+	// subjectHdr keeps the subject of a message re-driven to a subject of
+	// its own
+	subjectHdr = "Lunchbox-Subject"
+)
+
+// encode lays out the message to be published; the metadata values that are
+// not plain strings are published as JSON, so numbers come back as float64
+// the same as they do from the envelope
+func (f WireFormat) encode(msg am.Message) (*nats.Msg, error) {
+	if f == EnvelopeFormat {
+		return encodeEnvelope(msg)
+	}
+
+	natsMsg := nats.NewMsg(msg.Subject())
+	natsMsg.Data = msg.Data()
+	natsMsg.Header.Set(idHdr, msg.ID())
+	natsMsg.Header.Set(nameHdr, msg.MessageName())
+	natsMsg.Header.Set(sentAtHdr, msg.SentAt().UTC().Format(time.RFC3339Nano))
+
+	for key, value := range msg.Metadata() {
+		if !validHeaderKey(key) {
+			return nil, errors.Wrapf(errors.ErrBadRequest, "the metadata key %q cannot be used as a header", key)
+		}
+		encoded, err := encodeHeaderValue(value)
+		if err != nil {
+			return nil, err
+		}
+		natsMsg.Header.Set(key, encoded)
+	}
+
+	return natsMsg, nil
+}
+
+func encodeEnvelope(msg am.Message) (*nats.Msg, error) {
+	metadata, err := structpb.NewStruct(msg.Metadata())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(&StreamMessage{
+		Id:       msg.ID(),
+		Name:     msg.MessageName(),
+		Data:     msg.Data(),
+		Metadata: metadata,
+		SentAt:   timestamppb.New(msg.SentAt()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &nats.Msg{
+		Subject: msg.Subject(),
+		Data:    data,
+	}, nil
+}
+
+// decode reads a message in either format; messages without the name header
+// were published in the envelope
+func decode(natsMsg *nats.Msg) (*rawMessage, error) {
+	if _, exists := natsMsg.Header[nameHdr]; !exists {
+		return decodeEnvelope(natsMsg)
+	}
+
+	sentAt, err := time.Parse(time.RFC3339Nano, natsMsg.Header.Get(sentAtHdr))
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(ddd.Metadata)
+	for key := range natsMsg.Header {
+		if !isMetadataKey(key) {
+			continue
+		}
+		metadata.Set(key, decodeHeaderValue(natsMsg.Header.Get(key)))
+	}
+
+	return &rawMessage{
+		id:       natsMsg.Header.Get(idHdr),
+		name:     natsMsg.Header.Get(nameHdr),
+		subject:  subject(natsMsg),
+		data:     natsMsg.Data,
+		metadata: metadata,
+		sentAt:   sentAt,
+	}, nil
+}
+
+func decodeEnvelope(natsMsg *nats.Msg) (*rawMessage, error) {
+	m := &StreamMessage{}
+	if err := proto.Unmarshal(natsMsg.Data, m); err != nil {
+		return nil, err
+	}
+
+	return &rawMessage{
+		id:       m.GetId(),
+		name:     m.GetName(),
+		subject:  subject(natsMsg),
+		data:     m.GetData(),
+		metadata: m.GetMetadata().AsMap(),
+		sentAt:   m.SentAt.AsTime(),
+	}, nil
+}
+
+// subject is the subject the message was first published to
+func subject(natsMsg *nats.Msg) string {
+	if subject := natsMsg.Header.Get(subjectHdr); subject != "" {
+		return subject
+	}
+	return natsMsg.Subject
+}
+
+// encodeHeaderValue leaves strings as they are unless they would be read
+// back as something else or cannot be put in a header as is
+func encodeHeaderValue(value any) (string, error) {
+	if s, ok := value.(string); ok && !json.Valid([]byte(s)) && strings.TrimSpace(s) == s && !strings.ContainsAny(s, "\r\n") {
+		return s, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func decodeHeaderValue(value string) any {
+	var v any
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return value
+	}
+	return v
+}
+
+// isMetadataKey is false for the headers of the message itself and those
+// NATS sets, such as the Nats-Msg-Id used to drop duplicates
+func isMetadataKey(key string) bool {
+	return !strings.HasPrefix(key, "Nats-") && key != idHdr && key != nameHdr && key != sentAtHdr && key != subjectHdr
+}
+
+func validHeaderKey(key string) bool {
+	if key == "" || !isMetadataKey(key) {
+		return false
+	}
+	for _, r := range key {
+		if r <= ' ' || r >= 0x7f || r == ':' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+// Package memory has in-memory versions of the stores and the message stream
+// that are otherwise backed by Postgres and NATS
+//
+// They keep the behaviour the modules rely on, such as rejecting duplicate
+// messages and conflicting event versions, so application code can be
+// exercised in tests without any infrastructure, and the stream lets every
+// module run in a single process without NATS. Nothing is transactional; a
+// change is visible as soon as it is made and is not undone when the
+// surrounding work fails. Nothing is kept once the process exits.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/stackus/errors"
+)
+
+type (
+	EventStore struct {
+		mu       sync.RWMutex
+		streams  map[streamKey][]storedEvent
+		registry registry.Registry
+	}
+
+	streamKey struct {
+		id   string
+		name string
+	}
+
+	storedEvent struct {
+		version    int
+		id         string
+		name       string
+		data       []byte
+		occurredAt time.Time
+	}
+)
+
+var _ es.AggregateStore = (*EventStore)(nil)
+
+// NewEventStore returns an empty store; the events are serialized with the
+// registry the same as they would be for Postgres, so an event that has not
+// been registered fails here too
+func NewEventStore(registry registry.Registry) *EventStore {
+	return &EventStore{
+		streams:  make(map[streamKey][]storedEvent),
+		registry: registry,
+	}
+}
+
+func (s *EventStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate) error {
+	key := streamKey{id: aggregate.ID(), name: aggregate.AggregateName()}
+	currentVersion := aggregate.Version()
+
+	s.mu.RLock()
+	stream := s.streams[key]
+	s.mu.RUnlock()
+
+	for _, stored := range stream {
+		if stored.version <= currentVersion {
+			continue
+		}
+
+		payload, err := s.registry.Deserialize(stored.name, stored.data)
+		if err != nil {
+			return err
+		}
+
+		event := aggregateEvent{
+			id:         stored.id,
+			name:       stored.name,
+			payload:    payload,
+			aggregate:  aggregate,
+			version:    stored.version,
+			occurredAt: stored.occurredAt,
+		}
+
+		if err := es.LoadEvent(aggregate, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Save appends the pending events of the aggregate to its stream; the events
+// are rejected when the stream has changed since the aggregate was loaded
+func (s *EventStore) Save(ctx context.Context, aggregate es.EventSourcedAggregate) error {
+	key := streamKey{id: aggregate.ID(), name: aggregate.AggregateName()}
+
+	events := make([]storedEvent, len(aggregate.Events()))
+	for i, event := range aggregate.Events() {
+		data, err := s.registry.Serialize(event.EventName(), event.Payload())
+		if err != nil {
+			return err
+		}
+		events[i] = storedEvent{
+			version:    event.AggregateVersion(),
+			id:         event.ID(),
+			name:       event.EventName(),
+			data:       data,
+			occurredAt: event.OccurredAt(),
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stream := s.streams[key]
+	for _, event := range events {
+		if event.version != len(stream)+1 {
+			return errors.Wrapf(errors.ErrConflict, "%s %s is already at version %d", key.name, key.id, len(stream))
+		}
+		stream = append(stream, event)
+	}
+	s.streams[key] = stream
+
+	return nil
+}
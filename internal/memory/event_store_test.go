@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+	"github.com/stackus/errors"
+)
+
+const (
+	counterAggregate = "memory.Counter"
+	counterAdded     = "memory.CounterAdded"
+	counterSnapshot  = "memory.CounterV1"
+)
+
+// counter is the smallest aggregate that has events and snapshots
+type counter struct {
+	es.Aggregate
+	Total int
+}
+
+type added struct {
+	Amount int
+}
+
+type counterV1 struct {
+	Total int
+}
+
+func (counterV1) SnapshotName() string { return counterSnapshot }
+
+func (c *counter) ApplyEvent(event ddd.Event) error {
+	payload, ok := event.Payload().(*added)
+	if !ok {
+		return errors.ErrInternal.Msgf("unexpected payload %T", event.Payload())
+	}
+	c.Total += payload.Amount
+	return nil
+}
+
+func (c *counter) ApplySnapshot(snapshot es.Snapshot) error {
+	v1, ok := snapshot.(*counterV1)
+	if !ok {
+		return errors.ErrInternal.Msgf("unexpected snapshot %T", snapshot)
+	}
+	c.Total = v1.Total
+	return nil
+}
+
+func (c *counter) ToSnapshot() es.Snapshot {
+	return counterV1{Total: c.Total}
+}
+
+func newCounterRegistry(t *testing.T) registry.Registry {
+	t.Helper()
+
+	reg := registry.New()
+	serde := serdes.NewJsonSerde(reg)
+	err := serde.RegisterKey(counterAggregate, counter{}, func(v any) error {
+		v.(*counter).Aggregate = es.NewAggregate("", counterAggregate)
+		return nil
+	})
+	if err == nil {
+		err = serde.RegisterKey(counterAdded, added{})
+	}
+	if err == nil {
+		err = serde.RegisterKey(counterSnapshot, counterV1{})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return reg
+}
+
+// add loads the counter, adds the amounts to it and saves it
+func add(t *testing.T, repo es.AggregateRepository[*counter], amounts ...int) *counter {
+	t.Helper()
+
+	c, err := repo.Load(context.Background(), "counter-id")
+	if err != nil {
+		t.Fatalf("loading: %v", err)
+	}
+	for _, amount := range amounts {
+		c.AddEvent(counterAdded, &added{Amount: amount})
+	}
+	if err = repo.Save(context.Background(), c); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+
+	return c
+}
+
+func TestEventStore(t *testing.T) {
+	reg := newCounterRegistry(t)
+	repo := es.NewAggregateRepository[*counter](counterAggregate, reg, NewEventStore(reg))
+
+	add(t, repo, 1, 2)
+	add(t, repo, 3)
+
+	loaded, err := repo.Load(context.Background(), "counter-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Total != 6 || loaded.Version() != 3 {
+		t.Errorf("expected the counter at 6 and version 3, got %d and version %d", loaded.Total, loaded.Version())
+	}
+
+	// the other counter is a stream of its own
+	other, err := repo.Load(context.Background(), "other-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Total != 0 || other.Version() != 0 {
+		t.Errorf("expected another counter to be empty, got %d and version %d", other.Total, other.Version())
+	}
+}
+
+func TestEventStoreRejectsConflicts(t *testing.T) {
+	reg := newCounterRegistry(t)
+	repo := es.NewAggregateRepository[*counter](counterAggregate, reg, NewEventStore(reg))
+	add(t, repo, 1)
+
+	first, err := repo.Load(context.Background(), "counter-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.Load(context.Background(), "counter-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first.AddEvent(counterAdded, &added{Amount: 2})
+	if err = repo.Save(context.Background(), first); err != nil {
+		t.Fatal(err)
+	}
+	second.AddEvent(counterAdded, &added{Amount: 3})
+	if err = repo.Save(context.Background(), second); !errors.Is(err, errors.ErrConflict) {
+		t.Errorf("expected saving a stale counter to conflict, got %v", err)
+	}
+}
+
+func TestEventStoreRejectsUnregisteredEvents(t *testing.T) {
+	reg := newCounterRegistry(t)
+	repo := es.NewAggregateRepository[*counter](counterAggregate, reg, NewEventStore(reg))
+
+	c, err := repo.Load(context.Background(), "counter-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.AddEvent("memory.Unregistered", &added{Amount: 1})
+	if err = repo.Save(context.Background(), c); err == nil {
+		t.Error("expected an event that has not been registered to be rejected")
+	}
+}
+
+func TestSnapshotStore(t *testing.T) {
+	tests := map[string]struct {
+		// the amounts added by each save
+		saves        [][]int
+		wantSnapshot int
+	}{
+		"no snapshot before three changes": {
+			saves:        [][]int{{1}, {2}},
+			wantSnapshot: 0,
+		},
+		"a snapshot at the third change": {
+			saves:        [][]int{{1}, {2}, {3}},
+			wantSnapshot: 3,
+		},
+		"a snapshot when a save passes a multiple of three": {
+			saves:        [][]int{{1, 2}, {3, 4}},
+			wantSnapshot: 4,
+		},
+		"no snapshot between multiples of three": {
+			saves:        [][]int{{1, 2, 3}, {4}},
+			wantSnapshot: 3,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg := newCounterRegistry(t)
+			events := NewEventStore(reg)
+			store := es.AggregateStoreWithMiddleware(events, NewSnapshotStore(reg))
+			repo := es.NewAggregateRepository[*counter](counterAggregate, reg, store)
+
+			want := 0
+			for _, amounts := range tc.saves {
+				add(t, repo, amounts...)
+				for _, amount := range amounts {
+					want += amount
+				}
+			}
+
+			snapshots := store.(*SnapshotStore)
+			stored, exists := snapshots.snapshots[streamKey{id: "counter-id", name: counterAggregate}]
+			if stored.version != tc.wantSnapshot || exists != (tc.wantSnapshot > 0) {
+				t.Fatalf("expected a snapshot at version %d, got %d", tc.wantSnapshot, stored.version)
+			}
+
+			loaded, err := repo.Load(context.Background(), "counter-id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loaded.Total != want || loaded.Version() != len(events.streams[streamKey{id: "counter-id", name: counterAggregate}]) {
+				t.Errorf("expected the counter at %d, got %d at version %d", want, loaded.Total, loaded.Version())
+			}
+		})
+	}
+}
+
+func TestSnapshotStoreLoadsTheEventsAfterTheSnapshot(t *testing.T) {
+	reg := newCounterRegistry(t)
+	events := NewEventStore(reg)
+	store := es.AggregateStoreWithMiddleware(events, NewSnapshotStore(reg))
+	repo := es.NewAggregateRepository[*counter](counterAggregate, reg, store)
+	add(t, repo, 1, 2, 3)
+	add(t, repo, 4)
+
+	// with the events the snapshot covers gone, the counter can only come
+	// back complete from the snapshot and the event after it
+	key := streamKey{id: "counter-id", name: counterAggregate}
+	stream := events.streams[key]
+	for i := 0; i < 3; i++ {
+		stream[i].data = []byte(`{"Amount":0}`)
+	}
+
+	loaded, err := repo.Load(context.Background(), "counter-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Total != 10 || loaded.Version() != 4 {
+		t.Errorf("expected the counter at 10 and version 4, got %d and version %d", loaded.Total, loaded.Version())
+	}
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/stackus/errors"
+)
+
+type (
+	IdempotencyStore struct {
+		mu     sync.Mutex
+		claims map[string]*claim
+	}
+
+	claim struct {
+		record    idempotency.Record
+		completed bool
+	}
+)
+
+var _ idempotency.Store = (*IdempotencyStore)(nil)
+
+func NewIdempotencyStore() *IdempotencyStore {
+	return &IdempotencyStore{
+		claims: make(map[string]*claim),
+	}
+}
+
+// Claim only returns the existing record once it has been completed; without a
+// transaction to roll back the claim of a request that failed, a key that has
+// not been completed is claimed again
+func (s *IdempotencyStore) Claim(ctx context.Context, record idempotency.Record) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.claims[record.Key]; exists && existing.completed {
+		claimed := existing.record
+		return &claimed, nil
+	}
+
+	s.claims[record.Key] = &claim{record: record}
+
+	return nil, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, key string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.claims[key]
+	if !exists {
+		return errors.Wrapf(errors.ErrNotFound, "the idempotency key %q has not been claimed", key)
+	}
+	existing.record.Response = response
+	existing.completed = true
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/stackus/errors"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewIdempotencyStore()
+	record := idempotency.Record{Key: "user:key", Method: "/test.Service/Create", RequestHash: []byte("hash")}
+
+	existing, err := store.Claim(ctx, record)
+	if err != nil || existing != nil {
+		t.Fatalf("expected the first claim to succeed, got %v and %v", existing, err)
+	}
+	// a request that failed never completed its claim, so the key is free
+	if existing, err = store.Claim(ctx, record); err != nil || existing != nil {
+		t.Fatalf("expected a claim that was not completed to be claimed again, got %v and %v", existing, err)
+	}
+
+	if err = store.Complete(ctx, record.Key, []byte("response")); err != nil {
+		t.Fatal(err)
+	}
+	if existing, err = store.Claim(ctx, idempotency.Record{Key: record.Key, Method: record.Method}); err != nil {
+		t.Fatal(err)
+	}
+	want := record
+	want.Response = []byte("response")
+	if diff := cmp.Diff(&want, existing); diff != "" {
+		t.Errorf("expected the completed record (-want +got):\n%s", diff)
+	}
+
+	if err = store.Complete(ctx, "unknown", nil); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("expected completing a key never claimed to be not found, got %v", err)
+	}
+}
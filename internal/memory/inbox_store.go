@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/tm"
+)
+
+type InboxStore struct {
+	mu       sync.Mutex
+	messages []am.Message
+	ids      map[string]struct{}
+}
+
+var _ tm.InboxStore = (*InboxStore)(nil)
+
+func NewInboxStore() *InboxStore {
+	return &InboxStore{
+		ids: make(map[string]struct{}),
+	}
+}
+
+func (i *InboxStore) Save(ctx context.Context, msg am.IncomingMessage) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, exists := i.ids[msg.ID()]; exists {
+		return tm.ErrDuplicateMessage(msg.ID())
+	}
+
+	i.messages = append(i.messages, copyMessage(msg))
+	i.ids[msg.ID()] = struct{}{}
+
+	return nil
+}
+
+// Messages returns every message received in the order it arrived
+func (i *InboxStore) Messages() []am.Message {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return append([]am.Message(nil), i.messages...)
+}
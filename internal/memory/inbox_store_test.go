@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/internal/tm"
+)
+
+func TestInboxStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewInboxStore()
+
+	for _, id := range []string{"1", "2"} {
+		if err := store.Save(ctx, &incomingMessage{message: testMessage(id)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var dupe tm.ErrDuplicateMessage
+	if err := store.Save(ctx, &incomingMessage{message: testMessage("1")}); !errors.As(err, &dupe) {
+		t.Errorf("expected a message received again to be a duplicate, got %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"1", "2"}, ids(store.Messages())); diff != "" {
+		t.Errorf("expected each message received once (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+)
+
+// message is a copy of a message made when it is kept by a store or stream
+type message struct {
+	id       string
+	name     string
+	subject  string
+	data     []byte
+	metadata ddd.Metadata
+	sentAt   time.Time
+}
+
+var _ am.Message = (*message)(nil)
+
+func copyMessage(msg am.Message) message {
+	metadata := make(ddd.Metadata, len(msg.Metadata()))
+	for key, value := range msg.Metadata() {
+		metadata[key] = value
+	}
+
+	return message{
+		id:       msg.ID(),
+		name:     msg.MessageName(),
+		subject:  msg.Subject(),
+		data:     append([]byte(nil), msg.Data()...),
+		metadata: metadata,
+		sentAt:   msg.SentAt(),
+	}
+}
+
+func (m message) ID() string             { return m.id }
+func (m message) Subject() string        { return m.subject }
+func (m message) MessageName() string    { return m.name }
+func (m message) Data() []byte           { return m.data }
+func (m message) Metadata() ddd.Metadata { return m.metadata }
+func (m message) SentAt() time.Time      { return m.sentAt }
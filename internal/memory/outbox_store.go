@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/tm"
+)
+
+type (
+	OutboxStore struct {
+		mu       sync.Mutex
+		messages []*outboxMessage
+		ids      map[string]*outboxMessage
+	}
+
+	outboxMessage struct {
+		message
+		publishedAt *time.Time
+	}
+)
+
+var _ tm.OutboxStore = (*OutboxStore)(nil)
+
+func NewOutboxStore() *OutboxStore {
+	return &OutboxStore{
+		ids: make(map[string]*outboxMessage),
+	}
+}
+
+func (o *OutboxStore) Save(ctx context.Context, msg am.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, exists := o.ids[msg.ID()]; exists {
+		return tm.ErrDuplicateMessage(msg.ID())
+	}
+
+	saved := &outboxMessage{message: copyMessage(msg)}
+	o.messages = append(o.messages, saved)
+	o.ids[msg.ID()] = saved
+
+	return nil
+}
+
+// FindUnpublished returns the unpublished messages in the order they were saved
+func (o *OutboxStore) FindUnpublished(ctx context.Context, limit int) ([]am.Message, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var messages []am.Message
+	for _, msg := range o.messages {
+		if len(messages) == limit {
+			break
+		}
+		if msg.publishedAt == nil {
+			messages = append(messages, msg.message)
+		}
+	}
+
+	return messages, nil
+}
+
+func (o *OutboxStore) MarkPublished(ctx context.Context, ids ...string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	for _, id := range ids {
+		if msg, exists := o.ids[id]; exists {
+			msg.publishedAt = &now
+		}
+	}
+
+	return nil
+}
+
+// Messages returns every message saved, published or not
+func (o *OutboxStore) Messages() []am.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	messages := make([]am.Message, len(o.messages))
+	for i, msg := range o.messages {
+		messages[i] = msg.message
+	}
+
+	return messages
+}
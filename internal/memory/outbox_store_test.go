@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/tm"
+)
+
+func testMessage(id string) message {
+	return message{
+		id:       id,
+		name:     "Event",
+		subject:  "test.events",
+		data:     []byte(id),
+		metadata: ddd.Metadata{"key": "value"},
+		sentAt:   time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func ids(msgs []am.Message) []string {
+	ids := make([]string, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.ID()
+	}
+	return ids
+}
+
+func TestOutboxStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewOutboxStore()
+
+	for _, id := range []string{"1", "2", "3", "4"} {
+		if err := store.Save(ctx, testMessage(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var dupe tm.ErrDuplicateMessage
+	if err := store.Save(ctx, testMessage("1")); !errors.As(err, &dupe) {
+		t.Errorf("expected a message saved again to be a duplicate, got %v", err)
+	}
+
+	unpublished, err := store.FindUnpublished(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, ids(unpublished)); diff != "" {
+		t.Errorf("expected the first messages saved (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(testMessage("1"), unpublished[0], cmp.AllowUnexported(message{})); diff != "" {
+		t.Errorf("the message found is not the one saved (-want +got):\n%s", diff)
+	}
+
+	if err = store.MarkPublished(ctx, "1", "3", "unknown"); err != nil {
+		t.Fatal(err)
+	}
+	if unpublished, err = store.FindUnpublished(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"2", "4"}, ids(unpublished)); diff != "" {
+		t.Errorf("expected the messages not published (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"1", "2", "3", "4"}, ids(store.Messages())); diff != "" {
+		t.Errorf("expected every message saved (-want +got):\n%s", diff)
+	}
+}
+
+func TestOutboxStoreKeepsACopy(t *testing.T) {
+	ctx := context.Background()
+	store := NewOutboxStore()
+
+	msg := testMessage("1")
+	if err := store.Save(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	msg.metadata["key"] = "changed"
+	msg.data[0] = 'x'
+
+	saved := store.Messages()[0]
+	if saved.Metadata()["key"] != "value" || string(saved.Data()) != "1" {
+		t.Errorf("expected the message saved not to change with the one given, got %+v", saved)
+	}
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/jongyunha/lunchbox/internal/registry"
+)
+
+type (
+	SnapshotStore struct {
+		es.AggregateStore
+		mu        sync.RWMutex
+		snapshots map[streamKey]storedSnapshot
+		registry  registry.Registry
+	}
+
+	storedSnapshot struct {
+		version int
+		name    string
+		data    []byte
+	}
+)
+
+var _ es.AggregateStore = (*SnapshotStore)(nil)
+
+func NewSnapshotStore(registry registry.Registry) es.AggregateStoreMiddleware {
+	snapshots := &SnapshotStore{
+		snapshots: make(map[streamKey]storedSnapshot),
+		registry:  registry,
+	}
+
+	return func(store es.AggregateStore) es.AggregateStore {
+		snapshots.AggregateStore = store
+		return snapshots
+	}
+}
+
+func (s *SnapshotStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate) error {
+	s.mu.RLock()
+	stored, exists := s.snapshots[streamKey{id: aggregate.ID(), name: aggregate.AggregateName()}]
+	s.mu.RUnlock()
+
+	if !exists {
+		return s.AggregateStore.Load(ctx, aggregate)
+	}
+
+	v, err := s.registry.Deserialize(stored.name, stored.data, registry.ValidateImplements((*es.Snapshot)(nil)))
+	if err != nil {
+		return err
+	}
+
+	if err := es.LoadSnapshot(aggregate, v.(es.Snapshot), stored.version); err != nil {
+		return err
+	}
+
+	return s.AggregateStore.Load(ctx, aggregate)
+}
+
+func (s *SnapshotStore) Save(ctx context.Context, aggregate es.EventSourcedAggregate) error {
+	if err := s.AggregateStore.Save(ctx, aggregate); err != nil {
+		return err
+	}
+
+	if !s.shouldSnapshot(aggregate) {
+		return nil
+	}
+
+	sser, ok := aggregate.(es.Snapshotter)
+	if !ok {
+		return fmt.Errorf("%T does not implement es.Snapshotter", aggregate)
+	}
+
+	snapshot := sser.ToSnapshot()
+
+	data, err := s.registry.Serialize(snapshot.SnapshotName(), snapshot)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots[streamKey{id: aggregate.ID(), name: aggregate.AggregateName()}] = storedSnapshot{
+		version: aggregate.PendingVersion(),
+		name:    snapshot.SnapshotName(),
+		data:    data,
+	}
+
+	return nil
+}
+
+// shouldSnapshot takes snapshots as often as the Postgres store so both
+// exercise the snapshots of an aggregate the same way
+func (*SnapshotStore) shouldSnapshot(aggregate es.EventSourcedAggregate) bool {
+	var maxChanges = 3
+	var pendingVersion = aggregate.PendingVersion()
+	var pendingChanges = len(aggregate.Events())
+
+	return pendingVersion >= maxChanges && ((pendingChanges >= maxChanges) ||
+		(pendingVersion%maxChanges < pendingChanges) ||
+		(pendingVersion%maxChanges == 0))
+}
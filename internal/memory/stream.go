@@ -0,0 +1,549 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/health"
+	"github.com/rs/zerolog"
+)
+
+// Stream keeps every message published and delivers them the way the
+// JetStream stream does
+//
+// A subscriber without a group only receives the messages published after it
+// subscribed. The subscribers in a group share a consumer that starts from the
+// first message of the stream and lives on after they have unsubscribed, so a
+// group that subscribes again picks up where it left off. Messages that are
+// NAck'd, or not Ack'd within the AckWait, are delivered again until they have
+// been delivered MaxRedeliver times, after which they become dead letters, as
+// do the messages that are killed. The consumer of an Ordered subscription
+// hands out one message of a partition at a time, and a message delivered
+// again goes ahead of the rest of its partition.
+type Stream struct {
+	mu          sync.Mutex
+	messages    []message
+	ids         map[string]struct{}
+	consumers   []*consumer
+	groups      map[string]*consumer
+	subs        []*subscription
+	deadLetters []DeadLetter
+	workers     sync.WaitGroup
+	logger      zerolog.Logger
+}
+
+// DeadLetter is a message a consumer gave up on
+type DeadLetter struct {
+	Consumer   string
+	Message    am.Message
+	Deliveries int
+	Reason     string
+	DeadAt     time.Time
+}
+
+type (
+	consumer struct {
+		stream   *Stream
+		name     string
+		subject  string
+		cfg      am.SubscriberConfig
+		filters  map[string]struct{}
+		ordered  *am.Ordered
+		mu       sync.Mutex
+		queue    []*delivery
+		inFlight int
+		workers  int
+		ready    chan struct{}
+		// the partitions with a message being handled
+		busy map[string]struct{}
+	}
+
+	delivery struct {
+		msg        message
+		deliveries int
+	}
+
+	subscription struct {
+		consumer *consumer
+		stop     chan struct{}
+		once     sync.Once
+	}
+
+	outcome int
+)
+
+const (
+	unsettled outcome = iota
+	acked
+	nacked
+	killed
+)
+
+var _ am.MessageStream = (*Stream)(nil)
+
+func NewStream(logger zerolog.Logger) *Stream {
+	return &Stream{
+		ids:    make(map[string]struct{}),
+		groups: make(map[string]*consumer),
+		logger: logger,
+	}
+}
+
+// Publish adds the message to the stream under its own subject; like a
+// JetStream stream, a message with an id that has been published before is
+// dropped as a duplicate
+func (s *Stream) Publish(ctx context.Context, topicName string, msg am.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.ids[msg.ID()]; exists {
+		return nil
+	}
+	s.ids[msg.ID()] = struct{}{}
+
+	published := copyMessage(msg)
+	s.messages = append(s.messages, published)
+	for _, c := range s.consumers {
+		if subjectMatches(c.subject, published.subject) {
+			c.push(&delivery{msg: published})
+		}
+	}
+
+	return nil
+}
+
+func (s *Stream) Subscribe(topicName string, handler am.MessageHandler, options ...am.SubscriberOption) (am.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subCfg := am.NewSubscriberConfig(options)
+
+	if ordered, ok := subCfg.Ordered(); ok {
+		handler = am.VersionCheck(ordered)(handler)
+	}
+
+	var c *consumer
+	if groupName := subCfg.GroupName(); groupName != "" {
+		c = s.groups[groupName]
+		if c == nil {
+			c = s.newConsumer(groupName, topicName, subCfg)
+			for _, msg := range s.messages {
+				if subjectMatches(topicName, msg.subject) {
+					c.push(&delivery{msg: msg})
+				}
+			}
+			s.groups[groupName] = c
+			s.consumers = append(s.consumers, c)
+		}
+	} else {
+		c = s.newConsumer(topicName, topicName, subCfg)
+		s.consumers = append(s.consumers, c)
+	}
+
+	sub := &subscription{
+		consumer: c,
+		stop:     make(chan struct{}),
+	}
+	s.subs = append(s.subs, sub)
+
+	c.mu.Lock()
+	c.workers++
+	c.mu.Unlock()
+
+	workers := 1
+	if pull, ok := subCfg.PullConsumer(); ok {
+		workers = pull.Workers
+	}
+	for i := 0; i < workers; i++ {
+		s.workers.Add(1)
+		go c.work(sub.stop, handler)
+	}
+
+	return sub, nil
+}
+
+// Unsubscribe stops every subscriber and waits for the messages being handled
+func (s *Stream) Unsubscribe() error {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = nil
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		_ = sub.Unsubscribe()
+	}
+	s.workers.Wait()
+
+	return nil
+}
+
+// Messages returns every message published in the order it was published
+func (s *Stream) Messages() []am.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]am.Message, len(s.messages))
+	for i, msg := range s.messages {
+		messages[i] = msg
+	}
+
+	return messages
+}
+
+func (s *Stream) DeadLetters() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]DeadLetter(nil), s.deadLetters...)
+}
+
+// WaitIdle waits until every subscriber has handled all the messages it has
+// been sent, including those the handlers published in turn
+func (s *Stream) WaitIdle(ctx context.Context) error {
+	ticker := time.NewTicker(5 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if s.idle() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ConsumerLagCheck fails while any of the groups subscribed has more than
+// threshold messages waiting to be delivered
+func (s *Stream) ConsumerLagCheck(threshold uint64) health.CheckFunc {
+	return func(ctx context.Context) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for name, c := range s.groups {
+			c.mu.Lock()
+			pending := uint64(len(c.queue))
+			c.mu.Unlock()
+
+			if pending > threshold {
+				return fmt.Errorf("the %s consumer is %d messages behind, more than the %d allowed", name, pending, threshold)
+			}
+		}
+		return nil
+	}
+}
+
+func (s *Stream) idle() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.consumers {
+		c.mu.Lock()
+		busy := c.workers > 0 && (len(c.queue) > 0 || c.inFlight > 0)
+		c.mu.Unlock()
+
+		if busy {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Stream) newConsumer(name, subject string, cfg am.SubscriberConfig) *consumer {
+	c := &consumer{
+		stream:  s,
+		name:    name,
+		subject: subject,
+		cfg:     cfg,
+		ready:   make(chan struct{}, 1),
+	}
+	if ordered, ok := cfg.Ordered(); ok {
+		c.ordered = &ordered
+		c.busy = make(map[string]struct{})
+	}
+	if len(cfg.MessageFilters()) > 0 {
+		c.filters = make(map[string]struct{})
+		for _, key := range cfg.MessageFilters() {
+			c.filters[key] = struct{}{}
+		}
+	}
+
+	return c
+}
+
+func (s *Stream) removeConsumer(c *consumer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.consumers {
+		if existing == c {
+			s.consumers = append(s.consumers[:i], s.consumers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Stream) deadLetter(c *consumer, d *delivery, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters = append(s.deadLetters, DeadLetter{
+		Consumer:   c.name,
+		Message:    d.msg,
+		Deliveries: d.deliveries,
+		Reason:     reason,
+		DeadAt:     time.Now(),
+	})
+}
+
+func (s *subscription) Unsubscribe() error {
+	s.once.Do(func() {
+		close(s.stop)
+
+		c := s.consumer
+		c.mu.Lock()
+		c.workers--
+		c.mu.Unlock()
+
+		// only the consumers of groups outlive their subscribers
+		if c.cfg.GroupName() == "" {
+			c.stream.removeConsumer(c)
+		}
+	})
+
+	return nil
+}
+
+func (c *consumer) push(d *delivery) {
+	c.mu.Lock()
+	c.queue = append(c.queue, d)
+	c.mu.Unlock()
+
+	c.signal()
+}
+
+// retry puts the message at the front of the queue to be delivered next
+func (c *consumer) retry(d *delivery) {
+	c.mu.Lock()
+	c.queue = append([]*delivery{d}, c.queue...)
+	c.mu.Unlock()
+
+	c.signal()
+}
+
+func (c *consumer) signal() {
+	select {
+	case c.ready <- struct{}{}:
+	default:
+	}
+}
+
+// next takes the first message in the queue; for an ordered consumer, the
+// first one of a partition that does not have a message being handled
+func (c *consumer) next() *delivery {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, d := range c.queue {
+		if c.ordered != nil {
+			partition := c.ordered.Partition(d.msg)
+			if _, busy := c.busy[partition]; busy {
+				continue
+			}
+			c.busy[partition] = struct{}{}
+		}
+		if i == 0 {
+			c.queue = c.queue[1:]
+		} else {
+			c.queue = append(c.queue[:i:i], c.queue[i+1:]...)
+		}
+		c.inFlight++
+		return d
+	}
+
+	return nil
+}
+
+func (c *consumer) done(d *delivery) {
+	c.mu.Lock()
+	c.inFlight--
+	if c.ordered != nil {
+		delete(c.busy, c.ordered.Partition(d.msg))
+	}
+	c.mu.Unlock()
+
+	// another worker of the group may have gone back to waiting in the meantime
+	c.signal()
+}
+
+func (c *consumer) work(stop <-chan struct{}, handler am.MessageHandler) {
+	defer c.stream.workers.Done()
+
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		d := c.next()
+		if d == nil {
+			select {
+			case <-stop:
+				return
+			case <-c.ready:
+			}
+			continue
+		}
+
+		c.deliver(d, handler)
+		c.done(d)
+	}
+}
+
+func (c *consumer) deliver(d *delivery, handler am.MessageHandler) {
+	d.deliveries++
+
+	if c.filters != nil {
+		if _, exists := c.filters[d.msg.name]; !exists {
+			return
+		}
+	}
+
+	extended := make(chan struct{}, 1)
+	msg := &incomingMessage{
+		message:    d.msg,
+		receivedAt: time.Now(),
+		extended:   extended,
+	}
+
+	ackWait := c.cfg.AckWait()
+	wCtx, cancel := context.WithTimeout(context.Background(), ackWait)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- handler.HandleMessage(wCtx, msg)
+	}()
+
+	if c.cfg.AckType() == am.AckTypeAuto {
+		_ = msg.Ack()
+	}
+
+	timer := time.NewTimer(ackWait)
+	defer timer.Stop()
+
+	for waiting := true; waiting; {
+		select {
+		case err := <-errc:
+			if err != nil {
+				c.stream.logger.Error().Err(err).Msg("error while handling message")
+				_ = msg.NAck()
+			} else {
+				_ = msg.Ack()
+			}
+			waiting = false
+		case <-extended:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(ackWait)
+		case <-timer.C:
+			// the handler is left to finish; the message is delivered again regardless
+			msg.settle(nacked)
+			waiting = false
+		}
+	}
+
+	switch msg.outcome() {
+	case nacked:
+		if maxDeliveries := c.cfg.MaxRedeliver(); maxDeliveries > 0 && d.deliveries >= maxDeliveries {
+			c.stream.deadLetter(c, d, "max deliveries reached")
+			return
+		}
+		c.retry(d)
+	case killed:
+		c.stream.deadLetter(c, d, "terminated")
+	}
+}
+
+// subjectMatches compares the subjects the way NATS does; a "*" token matches
+// any single token and a final ">" matches one or more tokens
+func subjectMatches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, token := range patternTokens {
+		if token == ">" && i == len(patternTokens)-1 {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) {
+			return false
+		}
+		if token != "*" && token != subjectTokens[i] {
+			return false
+		}
+	}
+
+	return len(patternTokens) == len(subjectTokens)
+}
+
+type incomingMessage struct {
+	message
+	receivedAt time.Time
+	extended   chan struct{}
+	mu         sync.Mutex
+	result     outcome
+}
+
+var _ am.IncomingMessage = (*incomingMessage)(nil)
+
+func (m *incomingMessage) ReceivedAt() time.Time { return m.receivedAt }
+
+func (m *incomingMessage) Ack() error {
+	m.settle(acked)
+	return nil
+}
+
+func (m *incomingMessage) NAck() error {
+	m.settle(nacked)
+	return nil
+}
+
+func (m *incomingMessage) Extend() error {
+	select {
+	case m.extended <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (m *incomingMessage) Kill() error {
+	m.settle(killed)
+	return nil
+}
+
+// settle keeps the first outcome; the others are ignored the same as acking a
+// JetStream message twice
+func (m *incomingMessage) settle(result outcome) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.result == unsettled {
+		m.result = result
+	}
+}
+
+func (m *incomingMessage) outcome() outcome {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.result
+}
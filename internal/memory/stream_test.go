@@ -0,0 +1,348 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/rs/zerolog"
+)
+
+// received collects what a handler was given
+type received struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *received) add(msg am.IncomingMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ids = append(r.ids, msg.ID())
+}
+
+func (r *received) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.ids...)
+}
+
+func (r *received) handler() am.MessageHandler {
+	return am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		r.add(msg)
+		return nil
+	})
+}
+
+func publish(t *testing.T, s *Stream, id, name string) {
+	t.Helper()
+
+	err := s.Publish(context.Background(), "test.events", message{
+		id:       id,
+		name:     name,
+		subject:  "test.events",
+		metadata: ddd.Metadata{},
+		sentAt:   time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("publishing %s: %v", id, err)
+	}
+}
+
+func waitIdle(t *testing.T, s *Stream) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.WaitIdle(ctx); err != nil {
+		t.Fatalf("waiting for the stream to be idle: %v", err)
+	}
+}
+
+func newTestStream(t *testing.T) *Stream {
+	s := NewStream(zerolog.Nop())
+	t.Cleanup(func() { _ = s.Unsubscribe() })
+	return s
+}
+
+func TestStreamUngroupedSubscribersStartFromNow(t *testing.T) {
+	s := newTestStream(t)
+	publish(t, s, "1", "Event")
+
+	var got received
+	if _, err := s.Subscribe("test.>", got.handler()); err != nil {
+		t.Fatal(err)
+	}
+	publish(t, s, "2", "Event")
+	waitIdle(t, s)
+
+	if ids := got.get(); len(ids) != 1 || ids[0] != "2" {
+		t.Errorf("expected only the message published after subscribing, got %v", ids)
+	}
+}
+
+func TestStreamGroupsShareTheirMessages(t *testing.T) {
+	s := newTestStream(t)
+	publish(t, s, "1", "Event")
+	publish(t, s, "2", "Event")
+
+	var first, second received
+	if _, err := s.Subscribe("test.>", first.handler(), am.GroupName("group")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Subscribe("test.>", second.handler(), am.GroupName("group")); err != nil {
+		t.Fatal(err)
+	}
+	publish(t, s, "3", "Event")
+	publish(t, s, "4", "Event")
+	waitIdle(t, s)
+
+	seen := make(map[string]int)
+	for _, id := range append(first.get(), second.get()...) {
+		seen[id]++
+	}
+	if len(seen) != 4 {
+		t.Errorf("expected the group to receive all four messages, got %v", seen)
+	}
+	for id, times := range seen {
+		if times != 1 {
+			t.Errorf("expected message %s to be handled once by the group, it was handled %d times", id, times)
+		}
+	}
+}
+
+func TestStreamGroupsPickUpWhereTheyLeftOff(t *testing.T) {
+	s := newTestStream(t)
+
+	var got received
+	sub, err := s.Subscribe("test.>", got.handler(), am.GroupName("group"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	publish(t, s, "1", "Event")
+	waitIdle(t, s)
+	if err = sub.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+
+	publish(t, s, "2", "Event")
+	if _, err = s.Subscribe("test.>", got.handler(), am.GroupName("group")); err != nil {
+		t.Fatal(err)
+	}
+	waitIdle(t, s)
+
+	if ids := got.get(); len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Errorf("expected the group to receive each message once, got %v", ids)
+	}
+}
+
+func TestStreamMessageFilters(t *testing.T) {
+	s := newTestStream(t)
+
+	var got received
+	if _, err := s.Subscribe("test.>", got.handler(), am.MessageFilter{"Wanted"}); err != nil {
+		t.Fatal(err)
+	}
+	publish(t, s, "1", "Wanted")
+	publish(t, s, "2", "Unwanted")
+	publish(t, s, "3", "Wanted")
+	waitIdle(t, s)
+
+	if ids := got.get(); len(ids) != 2 || ids[0] != "1" || ids[1] != "3" {
+		t.Errorf("expected only the wanted messages, got %v", ids)
+	}
+	if deadLetters := s.DeadLetters(); len(deadLetters) != 0 {
+		t.Errorf("expected the unwanted message to be acked, got dead letters %v", deadLetters)
+	}
+}
+
+func TestStreamDropsDuplicates(t *testing.T) {
+	s := newTestStream(t)
+
+	var got received
+	if _, err := s.Subscribe("test.>", got.handler()); err != nil {
+		t.Fatal(err)
+	}
+	publish(t, s, "1", "Event")
+	publish(t, s, "1", "Event")
+	waitIdle(t, s)
+
+	if ids := got.get(); len(ids) != 1 {
+		t.Errorf("expected the duplicate to be dropped, got %v", ids)
+	}
+}
+
+func TestStreamRedelivery(t *testing.T) {
+	tests := map[string]struct {
+		options []am.SubscriberOption
+		// settle fails or settles the message of the given delivery
+		settle         func(msg am.IncomingMessage, delivery int) error
+		wantDeliveries int
+		wantDeadLetter string
+	}{
+		"an error is delivered again": {
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				if delivery == 1 {
+					return errors.New("failed")
+				}
+				return nil
+			},
+			wantDeliveries: 2,
+		},
+		"a NAck is delivered again": {
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				if delivery == 1 {
+					return msg.NAck()
+				}
+				return msg.Ack()
+			},
+			wantDeliveries: 2,
+		},
+		"a message not acked within the AckWait is delivered again": {
+			options: []am.SubscriberOption{am.AckWait(20 * time.Millisecond)},
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				if delivery == 1 {
+					time.Sleep(50 * time.Millisecond)
+				}
+				return nil
+			},
+			wantDeliveries: 2,
+		},
+		"an extended message is not delivered again": {
+			options: []am.SubscriberOption{am.AckWait(30 * time.Millisecond)},
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				for i := 0; i < 3; i++ {
+					time.Sleep(15 * time.Millisecond)
+					if err := msg.Extend(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantDeliveries: 1,
+		},
+		"a message failing MaxRedeliver times is a dead letter": {
+			options: []am.SubscriberOption{am.MaxRedeliver(3)},
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				return errors.New("failed")
+			},
+			wantDeliveries: 3,
+			wantDeadLetter: "max deliveries reached",
+		},
+		"a killed message is a dead letter": {
+			settle: func(msg am.IncomingMessage, delivery int) error {
+				return msg.Kill()
+			},
+			wantDeliveries: 1,
+			wantDeadLetter: "terminated",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newTestStream(t)
+
+			var mu sync.Mutex
+			deliveries := 0
+			handler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+				mu.Lock()
+				deliveries++
+				delivery := deliveries
+				mu.Unlock()
+
+				return tc.settle(msg, delivery)
+			})
+			if _, err := s.Subscribe("test.>", handler, append(tc.options, am.GroupName("group"))...); err != nil {
+				t.Fatal(err)
+			}
+			publish(t, s, "1", "Event")
+			waitIdle(t, s)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if deliveries != tc.wantDeliveries {
+				t.Errorf("expected %d deliveries, got %d", tc.wantDeliveries, deliveries)
+			}
+
+			deadLetters := s.DeadLetters()
+			if tc.wantDeadLetter == "" {
+				if len(deadLetters) != 0 {
+					t.Errorf("expected no dead letters, got %v", deadLetters)
+				}
+				return
+			}
+			if len(deadLetters) != 1 || deadLetters[0].Reason != tc.wantDeadLetter || deadLetters[0].Consumer != "group" {
+				t.Errorf("expected a dead letter of the group for %q, got %v", tc.wantDeadLetter, deadLetters)
+			}
+		})
+	}
+}
+
+func TestStreamOrderedHandlesAPartitionAtATime(t *testing.T) {
+	s := newTestStream(t)
+
+	var mu sync.Mutex
+	handling := make(map[string]bool)
+	order := make(map[string][]int)
+	overlapped := false
+	handler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		aggregateID := msg.Metadata().Get(ddd.AggregateIDKey).(string)
+
+		mu.Lock()
+		if handling[aggregateID] {
+			overlapped = true
+		}
+		handling[aggregateID] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		handling[aggregateID] = false
+		order[aggregateID] = append(order[aggregateID], msg.Metadata().Get(ddd.AggregateVersionKey).(int))
+		mu.Unlock()
+
+		return nil
+	})
+	_, err := s.Subscribe("test.>", handler, am.GroupName("group"), am.Ordered{}, am.PullConsumer{Workers: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for version := 1; version <= 5; version++ {
+		for _, aggregateID := range []string{"a", "b", "c"} {
+			err = s.Publish(context.Background(), "test.events", message{
+				id:      aggregateID + string(rune('0'+version)),
+				name:    "Event",
+				subject: "test.events",
+				metadata: ddd.Metadata{
+					ddd.AggregateIDKey:      aggregateID,
+					ddd.AggregateVersionKey: version,
+				},
+				sentAt: time.Now(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	waitIdle(t, s)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if overlapped {
+		t.Error("expected the messages of an aggregate never to be handled at the same time")
+	}
+	for aggregateID, versions := range order {
+		for i, version := range versions {
+			if version != i+1 {
+				t.Errorf("expected the versions of %s in order, got %v", aggregateID, versions)
+				break
+			}
+		}
+	}
+}
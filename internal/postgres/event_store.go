@@ -3,11 +3,22 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/jongyunha/lunchbox/internal/ddd"
 	"github.com/jongyunha/lunchbox/internal/es"
 	"github.com/jongyunha/lunchbox/internal/registry"
 )
 
+// StoredEvent is an event as it was saved into a stream
+type StoredEvent struct {
+	StreamVersion int       `json:"streamVersion"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Payload       any       `json:"payload"`
+	OccurredAt    time.Time `json:"occurredAt"`
+}
+
 type EventStore struct {
 	tableName string
 	db        DBTX
@@ -93,6 +104,102 @@ func (s EventStore) Save(ctx context.Context, aggregate es.EventSourcedAggregate
 	return nil
 }
 
+// LoadStream returns every event saved into the stream
+func (s EventStore) LoadStream(ctx context.Context, streamID, streamName string) ([]StoredEvent, error) {
+	rows, err := s.loadEvents(ctx, streamID, streamName, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]StoredEvent, len(rows))
+	for i, row := range rows {
+		payload, err := s.registry.Deserialize(row.EventName, row.EventData)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = StoredEvent{
+			StreamVersion: int(row.StreamVersion),
+			ID:            row.EventID,
+			Name:          row.EventName,
+			Payload:       payload,
+			OccurredAt:    row.OccurredAt,
+		}
+	}
+
+	return events, nil
+}
+
+// Replay loads every stream of the aggregate from scratch in the order the
+// events occurred; after each event is applied the handler is given an event
+// carrying the aggregate, the same as the domain events published live
+func (s EventStore) Replay(ctx context.Context, aggregateName string, handler ddd.EventHandler[ddd.Event]) error {
+	query := fmt.Sprintf(`
+		SELECT stream_id, stream_version, event_id, event_name, event_data, occurred_at
+		FROM %s
+		WHERE stream_name = $1
+		ORDER BY occurred_at ASC, stream_id ASC, stream_version ASC;`, s.tableName)
+
+	rows, err := s.db.Query(ctx, query, aggregateName)
+	if err != nil {
+		return err
+	}
+	type replayRow struct {
+		streamID string
+		LoadEventsRow
+	}
+	var replayRows []replayRow
+	for rows.Next() {
+		var row replayRow
+		if err = rows.Scan(&row.streamID, &row.StreamVersion, &row.EventID, &row.EventName, &row.EventData, &row.OccurredAt); err != nil {
+			rows.Close()
+			return err
+		}
+		replayRows = append(replayRows, row)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// the handlers write to the same connection, so the rows are read in full first
+	aggregates := make(map[string]es.EventSourcedAggregate)
+	for _, row := range replayRows {
+		aggregate, exists := aggregates[row.streamID]
+		if !exists {
+			v, err := s.registry.Build(aggregateName, ddd.SetID(row.streamID), ddd.SetName(aggregateName))
+			if err != nil {
+				return err
+			}
+			var ok bool
+			if aggregate, ok = v.(es.EventSourcedAggregate); !ok {
+				return fmt.Errorf("%T is not an event sourced aggregate", v)
+			}
+			aggregates[row.streamID] = aggregate
+		}
+
+		payload, err := s.registry.Deserialize(row.EventName, row.EventData)
+		if err != nil {
+			return err
+		}
+		event := aggregateEvent{
+			id:         row.EventID,
+			name:       row.EventName,
+			payload:    payload,
+			aggregate:  aggregate,
+			version:    int(row.StreamVersion),
+			occurredAt: row.OccurredAt,
+		}
+		if err = es.LoadEvent(aggregate, event); err != nil {
+			return err
+		}
+		if err = handler.HandleEvent(ctx, ddd.NewEvent(row.EventName, aggregate)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s EventStore) loadEvents(ctx context.Context, streamID string, streamName string, streamVersion int32) ([]LoadEventsRow, error) {
 	query := fmt.Sprintf(`
 		SELECT stream_version, event_id, event_name, event_data, occurred_at
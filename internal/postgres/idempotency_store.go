@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+)
+
+type IdempotencyStore struct {
+	tableName string
+	db        DBTX
+}
+
+var _ idempotency.Store = (*IdempotencyStore)(nil)
+
+func NewIdempotencyStore(tableName string, db DBTX) IdempotencyStore {
+	return IdempotencyStore{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+// Claim relies on the insert waiting on any transaction holding the same key;
+// the existing record is only read once that transaction has finished
+func (s IdempotencyStore) Claim(ctx context.Context, record idempotency.Record) (*idempotency.Record, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (key, method, request_hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO NOTHING;`, s.tableName)
+
+	tag, err := s.db.Exec(ctx, query, record.Key, record.Method, record.RequestHash)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 1 {
+		return nil, nil
+	}
+
+	query = fmt.Sprintf("SELECT key, method, request_hash, response FROM %s WHERE key = $1", s.tableName)
+
+	existing := &idempotency.Record{}
+	err = s.db.QueryRow(ctx, query, record.Key).Scan(
+		&existing.Key, &existing.Method, &existing.RequestHash, &existing.Response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (s IdempotencyStore) Complete(ctx context.Context, key string, response []byte) error {
+	query := fmt.Sprintf("UPDATE %s SET response = $2 WHERE key = $1;", s.tableName)
+
+	_, err := s.db.Exec(ctx, query, key, response)
+
+	return err
+}
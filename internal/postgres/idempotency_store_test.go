@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+)
+
+// testIdempotencyDB returns a pool on a schema of its own with a table of the
+// same shape as the idempotency_keys table of the modules
+func testIdempotencyDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	db := testStreamDB(t)
+	_, err := db.Exec(context.Background(), `
+		CREATE TABLE idempotency_keys (
+		  key          text        NOT NULL,
+		  method       text        NOT NULL,
+		  request_hash bytea       NOT NULL,
+		  response     bytea,
+		  created_at   timestamptz NOT NULL DEFAULT NOW(),
+		  PRIMARY KEY (key)
+		);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func testRecord(hash string) idempotency.Record {
+	return idempotency.Record{Key: "subject:key", Method: "/test.Service/Method", RequestHash: []byte(hash)}
+}
+
+func TestIdempotencyStore(t *testing.T) {
+	db := testIdempotencyDB(t)
+	store := NewIdempotencyStore("idempotency_keys", db)
+	ctx := context.Background()
+
+	existing, err := store.Claim(ctx, testRecord("hash"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if existing != nil {
+		t.Fatalf("expected the first claim to succeed, got %+v", existing)
+	}
+	if err = store.Complete(ctx, "subject:key", []byte("response")); err != nil {
+		t.Fatal(err)
+	}
+
+	// a second claim, for a request that may be another, gets the first back
+	existing, err = store.Claim(ctx, testRecord("another hash"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testRecord("hash")
+	want.Response = []byte("response")
+	if diff := cmp.Diff(&want, existing); diff != "" {
+		t.Errorf("expected the claimed record (-want +got):\n%s", diff)
+	}
+}
+
+func TestIdempotencyStoreClaimsWaitOnEachOther(t *testing.T) {
+	tests := map[string]struct {
+		commit bool
+		// wantExisting is whether the waiting claim gets the record of the
+		// first, or claims the key itself
+		wantExisting bool
+	}{
+		"the first request is committed":   {commit: true, wantExisting: true},
+		"the first request is rolled back": {commit: false, wantExisting: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := testIdempotencyDB(t)
+			ctx := context.Background()
+
+			tx, err := db.Begin(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = tx.Rollback(ctx) }()
+			if _, err = NewIdempotencyStore("idempotency_keys", tx).Claim(ctx, testRecord("hash")); err != nil {
+				t.Fatal(err)
+			}
+
+			type claimed struct {
+				existing *idempotency.Record
+				err      error
+			}
+			result := make(chan claimed, 1)
+			go func() {
+				existing, err := NewIdempotencyStore("idempotency_keys", db).Claim(ctx, testRecord("hash"))
+				result <- claimed{existing, err}
+			}()
+
+			select {
+			case r := <-result:
+				t.Fatalf("expected the claim to wait on the transaction holding the key, got %+v", r)
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			if tc.commit {
+				if err = NewIdempotencyStore("idempotency_keys", tx).Complete(ctx, "subject:key", []byte("response")); err != nil {
+					t.Fatal(err)
+				}
+				err = tx.Commit(ctx)
+			} else {
+				err = tx.Rollback(ctx)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case r := <-result:
+				if r.err != nil {
+					t.Fatal(r.err)
+				}
+				if (r.existing != nil) != tc.wantExisting {
+					t.Errorf("expected an existing record to be returned: %t, got %+v", tc.wantExisting, r.existing)
+				}
+				if r.existing != nil && string(r.existing.Response) != "response" {
+					t.Errorf("expected the response of the committed request, got %q", r.existing.Response)
+				}
+			case <-time.After(waitFor):
+				t.Fatal("expected the claim to finish once the transaction did")
+			}
+		})
+	}
+}
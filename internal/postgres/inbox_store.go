@@ -2,31 +2,62 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jongyunha/lunchbox/internal/am"
 	"github.com/jongyunha/lunchbox/internal/tm"
+	"github.com/stackus/errors"
 )
 
 type InboxStore struct {
-	db      DBTX
-	queries *Queries
+	tableName string
+	db        DBTX
 }
 
 var _ tm.InboxStore = (*InboxStore)(nil)
 
-func NewInboxStore(db DBTX) InboxStore {
+func NewInboxStore(tableName string, db DBTX) InboxStore {
 	return InboxStore{
-		db:      db,
-		queries: New(db),
+		tableName: tableName,
+		db:        db,
 	}
 }
 
 func (i InboxStore) Save(ctx context.Context, msg am.IncomingMessage) error {
-	_, err := i.queries.SaveRestaurantInboxMessage(ctx, SaveRestaurantInboxMessageParams{
-		ID:      msg.ID(),
-		Name:    msg.MessageName(),
-		Subject: msg.Subject(),
-		Data:    msg.Data(),
-	})
+	metadata, err := json.Marshal(msg.Metadata())
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, name, subject, data, metadata, sent_at, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`, i.tableName)
+
+	_, err = i.db.Exec(ctx, query,
+		msg.ID(), msg.MessageName(), msg.Subject(), msg.Data(), metadata, msg.SentAt(), time.Now())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.UniqueViolation {
+				return tm.ErrDuplicateMessage(msg.ID())
+			}
+		}
+	}
+
 	return err
 }
+
+// Received returns how many messages have been received and when the latest
+// of them arrived
+func (i InboxStore) Received(ctx context.Context) (count int, latest *time.Time, err error) {
+	query := fmt.Sprintf(`
+		SELECT count(*), max(received_at)
+		FROM %s;`, i.tableName)
+
+	err = i.db.QueryRow(ctx, query).Scan(&count, &latest)
+	return
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/database"
+	"github.com/pressly/goose/v3/lock"
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+)
+
+// migrationLockID is the advisory lock held while any schema is migrated so
+// that services starting together take turns
+const migrationLockID int64 = 0x6c756e6368626f78 // "lunchbox"
+
+type (
+	// Migrations are the goose migrations of a single schema; the versions
+	// applied are kept in a goose_db_version table within that schema
+	Migrations struct {
+		Schema string
+		FS     fs.FS
+	}
+
+	MigrationStatus struct {
+		Schema  string
+		Version int64
+		Name    string
+		Applied bool
+	}
+
+	Migrator struct {
+		db         *sql.DB
+		migrations []Migrations
+		logger     zerolog.Logger
+	}
+)
+
+func NewMigrator(db *sql.DB, logger zerolog.Logger, migrations ...Migrations) Migrator {
+	return Migrator{
+		db:         db,
+		migrations: migrations,
+		logger:     logger,
+	}
+}
+
+// Up applies every pending migration of each schema in turn
+func (m Migrator) Up(ctx context.Context) error {
+	for _, migrations := range m.migrations {
+		provider, err := m.provider(ctx, migrations)
+		if err != nil {
+			return err
+		}
+		results, err := provider.Up(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "migrating the %s schema", migrations.Schema)
+		}
+		for _, result := range results {
+			m.logger.Info().
+				Str("Schema", migrations.Schema).
+				Int64("Version", result.Source.Version).
+				Dur("Took", result.Duration).
+				Msg("applied migration")
+		}
+	}
+
+	return nil
+}
+
+// Down rolls back the most recently applied migration of the schema
+func (m Migrator) Down(ctx context.Context, schema string) error {
+	for _, migrations := range m.migrations {
+		if migrations.Schema != schema {
+			continue
+		}
+		provider, err := m.provider(ctx, migrations)
+		if err != nil {
+			return err
+		}
+		result, err := provider.Down(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "rolling back the %s schema", schema)
+		}
+		m.logger.Info().
+			Str("Schema", schema).
+			Int64("Version", result.Source.Version).
+			Dur("Took", result.Duration).
+			Msg("rolled back migration")
+		return nil
+	}
+
+	return errors.Wrapf(errors.ErrNotFound, "no migrations for the %s schema", schema)
+}
+
+func (m Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	for _, migrations := range m.migrations {
+		provider, err := m.provider(ctx, migrations)
+		if err != nil {
+			return nil, err
+		}
+		results, err := provider.Status(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading the status of the %s schema", migrations.Schema)
+		}
+		for _, result := range results {
+			statuses = append(statuses, MigrationStatus{
+				Schema:  migrations.Schema,
+				Version: result.Source.Version,
+				Name:    result.Source.Path,
+				Applied: result.State == goose.StateApplied,
+			})
+		}
+	}
+
+	return statuses, nil
+}
+
+func (m Migrator) provider(ctx context.Context, migrations Migrations) (*goose.Provider, error) {
+	// the version table lives in the schema, so it must exist before goose looks for it
+	if err := m.createSchema(ctx, migrations.Schema); err != nil {
+		return nil, err
+	}
+
+	store, err := database.NewStore(database.DialectPostgres, migrations.Schema+".goose_db_version")
+	if err != nil {
+		return nil, err
+	}
+	locker, err := lock.NewPostgresSessionLocker(lock.WithLockID(migrationLockID))
+	if err != nil {
+		return nil, err
+	}
+
+	return goose.NewProvider("", m.db, migrations.FS,
+		goose.WithStore(store),
+		goose.WithSessionLocker(locker),
+		goose.WithDisableGlobalRegistry(true),
+	)
+}
+
+func (m Migrator) createSchema(ctx context.Context, schema string) (err error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pgx.Identifier{schema}.Sanitize()))
+
+	return err
+}
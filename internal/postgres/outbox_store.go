@@ -3,18 +3,21 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jongyunha/lunchbox/internal/am"
 	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/health"
 	"github.com/jongyunha/lunchbox/internal/tm"
 	"github.com/stackus/errors"
 )
 
 type OutboxStore struct {
-	queries *Queries
+	tableName string
+	db        DBTX
 }
 
 type outboxMessage struct {
@@ -29,9 +32,10 @@ type outboxMessage struct {
 var _ tm.OutboxStore = (*OutboxStore)(nil)
 var _ am.Message = (*outboxMessage)(nil)
 
-func NewOutboxStore(db DBTX) *OutboxStore {
+func NewOutboxStore(tableName string, db DBTX) *OutboxStore {
 	return &OutboxStore{
-		queries: New(db),
+		tableName: tableName,
+		db:        db,
 	}
 }
 
@@ -40,15 +44,12 @@ func (o *OutboxStore) Save(ctx context.Context, msg am.Message) error {
 	if err != nil {
 		return err
 	}
-	param := SaveRestaurantOutboxMessageParams{
-		ID:       msg.ID(),
-		Name:     msg.MessageName(),
-		Subject:  msg.Subject(),
-		Data:     msg.Data(),
-		Metadata: metadata,
-		SentAt:   msg.SentAt(),
-	}
-	_, err = o.queries.SaveRestaurantOutboxMessage(ctx, param)
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, name, subject, data, metadata, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6);`, o.tableName)
+
+	_, err = o.db.Exec(ctx, query,
+		msg.ID(), msg.MessageName(), msg.Subject(), msg.Data(), metadata, msg.SentAt())
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -62,34 +63,77 @@ func (o *OutboxStore) Save(ctx context.Context, msg am.Message) error {
 }
 
 func (o *OutboxStore) FindUnpublished(ctx context.Context, limit int) ([]am.Message, error) {
-	rows, err := o.queries.FindRestaurantUnpublishedOutboxMessages(ctx, int32(limit))
+	query := fmt.Sprintf(`
+		SELECT id, name, subject, data, metadata, sent_at
+		FROM %s
+		WHERE published_at IS NULL
+		LIMIT $1;`, o.tableName)
+
+	rows, err := o.db.Query(ctx, query, int32(limit))
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	messages := make([]am.Message, len(rows))
-	for i, row := range rows {
+	var messages []am.Message
+	for rows.Next() {
+		var row FindRestaurantUnpublishedOutboxMessagesRow
+		if err = rows.Scan(&row.ID, &row.Name, &row.Subject, &row.Data, &row.Metadata, &row.SentAt); err != nil {
+			return nil, err
+		}
 		var metadata ddd.Metadata
 		err = json.Unmarshal(row.Metadata, &metadata)
 		if err != nil {
 			return nil, err
 		}
-		outbox := outboxMessage{
+		messages = append(messages, outboxMessage{
 			id:       row.ID,
 			name:     row.Name,
 			subject:  row.Subject,
 			data:     row.Data,
 			metadata: metadata,
 			sentAt:   row.SentAt,
-		}
-		messages[i] = outbox
+		})
 	}
 
-	return messages, nil
+	return messages, rows.Err()
 }
 
 func (o *OutboxStore) MarkPublished(ctx context.Context, ids ...string) error {
-	return o.queries.MarkRestaurantOutboxMessageAsPublishedByIDs(ctx, ids)
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET published_at = CURRENT_TIMESTAMP
+		WHERE id = ANY($1::text[]);`, o.tableName)
+
+	_, err := o.db.Exec(ctx, query, ids)
+	return err
+}
+
+// MarkUnpublished returns the messages to the outbox to be published again;
+// the number of messages found is returned
+func (o *OutboxStore) MarkUnpublished(ctx context.Context, ids ...string) (int64, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET published_at = NULL
+		WHERE id = ANY($1::text[]);`, o.tableName)
+
+	tag, err := o.db.Exec(ctx, query, ids)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Backlog returns how many messages are waiting to be published and when the
+// oldest of them was sent
+func (o *OutboxStore) Backlog(ctx context.Context) (count int, oldest *time.Time, err error) {
+	query := fmt.Sprintf(`
+		SELECT count(*), min(sent_at)
+		FROM %s
+		WHERE published_at IS NULL;`, o.tableName)
+
+	err = o.db.QueryRow(ctx, query).Scan(&count, &oldest)
+	return
 }
 
 func (r outboxMessage) MessageName() string {
@@ -115,3 +159,23 @@ func (r outboxMessage) Metadata() ddd.Metadata {
 func (r outboxMessage) SentAt() time.Time {
 	return r.sentAt
 }
+
+// OutboxBacklogCheck fails while more than threshold messages are waiting in
+// the outbox to be published
+func OutboxBacklogCheck(tableName string, db DBTX, threshold int) health.CheckFunc {
+	query := fmt.Sprintf(`
+		SELECT count(*)
+		FROM %s
+		WHERE published_at IS NULL;`, tableName)
+
+	return func(ctx context.Context) error {
+		var backlog int
+		if err := db.QueryRow(ctx, query).Scan(&backlog); err != nil {
+			return err
+		}
+		if backlog > threshold {
+			return fmt.Errorf("%d messages are waiting to be published, more than the %d allowed", backlog, threshold)
+		}
+		return nil
+	}
+}
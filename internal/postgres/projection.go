@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/registry"
+)
+
+// Projection is a read model kept up to date from the events of an aggregate
+type Projection struct {
+	Name          string
+	AggregateName string
+	// Tables are emptied before the events are replayed
+	Tables []string
+	// Handlers returns the handlers that maintain the read model, in the order
+	// they are subscribed to the domain events
+	Handlers func(db DBTX) []ddd.EventHandler[ddd.Event]
+}
+
+// RebuildProjection empties the read model then replays every event of the
+// aggregate into it; nothing changes unless the rebuild completes
+func RebuildProjection(ctx context.Context, db *pgxpool.Pool, eventsTable string, reg registry.Registry, projection Projection) error {
+	return NewTransactionManager(db).WithinTransaction(ctx, func(tx pgx.Tx) error {
+		if len(projection.Tables) > 0 {
+			if _, err := tx.Exec(ctx, fmt.Sprintf("TRUNCATE %s", strings.Join(projection.Tables, ", "))); err != nil {
+				return err
+			}
+		}
+
+		handlers := projection.Handlers(tx)
+		handler := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+			for _, h := range handlers {
+				if err := h.HandleEvent(ctx, event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		return NewEventStore(eventsTable, tx, reg).Replay(ctx, projection.AggregateName, handler)
+	})
+}
@@ -32,6 +32,45 @@ func NewSnapshotStore(tableName string, db DBTX, registry registry.Registry) es.
 	}
 }
 
+// StoredSnapshot is the snapshot last saved for a stream
+type StoredSnapshot struct {
+	StreamVersion int    `json:"streamVersion"`
+	Name          string `json:"name"`
+	Snapshot      any    `json:"snapshot"`
+}
+
+// NewSnapshotReader returns a store that is only used to look at snapshots
+// and is not part of an aggregate store
+func NewSnapshotReader(tableName string, db DBTX, registry registry.Registry) *SnapshotStore {
+	return &SnapshotStore{
+		queries:   New(db),
+		registry:  registry,
+		tableName: tableName,
+	}
+}
+
+// Find returns the snapshot of the stream, or nil when it has none
+func (s *SnapshotStore) Find(ctx context.Context, streamID, streamName string) (*StoredSnapshot, error) {
+	query := fmt.Sprintf("SELECT stream_version, snapshot_name, snapshot_data FROM %s WHERE stream_id = $1 AND stream_name = $2 LIMIT 1", s.tableName)
+
+	var snapshot StoredSnapshot
+	var snapshotData []byte
+	err := s.queries.db.QueryRow(ctx, query, streamID, streamName).Scan(&snapshot.StreamVersion, &snapshot.Name, &snapshotData)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	snapshot.Snapshot, err = s.registry.Deserialize(snapshot.Name, snapshotData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
 func (s *SnapshotStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate) error {
 	params := LoadSnapshotParams{
 		StreamID:   aggregate.ID(),
@@ -0,0 +1,778 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/health"
+	"github.com/rs/zerolog"
+)
+
+const (
+	streamChannel = "stream_messages"
+	// streamPollInterval is how often the subscribers look for messages
+	// without being notified, e.g. those whose visibility timeout has passed
+	streamPollInterval = time.Second
+	// streamPublishLockID is held while a message is published
+	streamPublishLockID int64 = 0x73747265616d // "stream"
+	// streamMaxPending is how many messages an ordered subscriber without a
+	// PullConsumer may have taken from the stream and not yet Ack'd
+	streamMaxPending = 10
+	// streamEphemeralPrefix starts the names of the consumers of the
+	// subscribers without a group
+	streamEphemeralPrefix = "ephemeral."
+	// streamSweepInterval is how often the ephemeral consumers of the process
+	// are touched and those no process has touched for streamEphemeralTTL
+	// are removed
+	streamSweepInterval = 15 * time.Second
+	streamEphemeralTTL  = time.Minute
+)
+
+// Stream is a message stream kept in Postgres, for deployments without NATS
+//
+// The messages are kept in stream_messages in the order they are published.
+// Each group has a consumer that keeps its position in the messages and
+// starts from the first one; a subscriber without a group gets a consumer of
+// its own that starts from the latest message and is removed when it
+// unsubscribes, or once it has not been touched for a while when its process
+// went away without unsubscribing. The subscribers are woken with LISTEN/NOTIFY when messages
+// are published.
+//
+// A message handed out to a consumer is kept in stream_deliveries until it is
+// Ack'd. It is handed out again once it has been NAck'd or its AckWait has
+// passed without being Ack'd or extended, and becomes a dead letter once it
+// has been delivered MaxRedeliver times or is killed.
+//
+// The consumer of an Ordered subscription that Acks manually keeps the
+// partition of each message it takes in its delivery, and a message is only
+// handed out once every message before it in its partition has been Ack'd or
+// killed, whichever subscriber of the group they were handed to.
+type Stream struct {
+	db        *pgxpool.Pool
+	mu        sync.Mutex
+	subs      []*streamSubscription
+	groups    []string
+	cancel    context.CancelFunc
+	workers   sync.WaitGroup
+	logger    zerolog.Logger
+	txManager TransactionManager
+}
+
+type (
+	streamSubscription struct {
+		stream   *Stream
+		consumer string
+		pattern  string
+		cfg      am.SubscriberConfig
+		ordered  *am.Ordered
+		handler  am.MessageHandler
+		stop     chan struct{}
+		ready    chan struct{}
+		once     sync.Once
+	}
+
+	streamDelivery struct {
+		position int64
+		message  streamMessage
+	}
+
+	streamMessage struct {
+		id         string
+		name       string
+		subject    string
+		data       []byte
+		metadata   ddd.Metadata
+		sentAt     time.Time
+		receivedAt time.Time
+		acked      bool
+		ackFn      func() error
+		nackFn     func() error
+		extendFn   func() error
+		killFn     func() error
+	}
+)
+
+var _ am.MessageStream = (*Stream)(nil)
+var _ am.IncomingMessage = (*streamMessage)(nil)
+
+func NewStream(db *pgxpool.Pool, logger zerolog.Logger) *Stream {
+	return &Stream{
+		db:        db,
+		logger:    logger,
+		txManager: NewTransactionManager(db),
+	}
+}
+
+// Publish adds the message to the stream under its own subject; a message
+// with an id that has been published before is dropped as a duplicate
+func (s *Stream) Publish(ctx context.Context, topicName string, msg am.Message) error {
+	metadata, err := json.Marshal(msg.Metadata())
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(tx pgx.Tx) error {
+		// publishing one message at a time keeps the positions in the order
+		// they are committed, so no consumer moves past a message that is yet
+		// to be committed
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", streamPublishLockID); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO stream_messages (id, name, subject, data, metadata, sent_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (id) DO NOTHING;`,
+			msg.ID(), msg.MessageName(), msg.Subject(), msg.Data(), metadata, msg.SentAt(),
+		)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+
+		// the notification is sent once the transaction commits
+		_, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", streamChannel, msg.Subject())
+		return err
+	})
+}
+
+func (s *Stream) Subscribe(topicName string, handler am.MessageHandler, options ...am.SubscriberOption) (am.Subscription, error) {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subCfg := am.NewSubscriberConfig(options)
+	pattern := subjectPattern(topicName)
+
+	var ordered *am.Ordered
+	if o, ok := subCfg.Ordered(); ok {
+		handler = am.VersionCheck(o)(handler)
+		// auto-acked messages are not kept in the deliveries to be ordered by
+		if subCfg.AckType() != am.AckTypeAuto {
+			ordered = &o
+		}
+	}
+
+	consumer := subCfg.GroupName()
+	if consumer != "" {
+		_, err := s.db.Exec(ctx, `
+			INSERT INTO stream_consumers (name, subject_pattern, position)
+			VALUES ($1, $2, 0)
+			ON CONFLICT (name) DO UPDATE SET subject_pattern = EXCLUDED.subject_pattern;`,
+			consumer, pattern,
+		)
+		if err != nil {
+			return nil, err
+		}
+		s.groups = append(s.groups, consumer)
+	} else {
+		// without a group each subscriber only receives the messages
+		// published after it has subscribed
+		consumer = streamEphemeralPrefix + uuid.New().String()
+		_, err := s.db.Exec(ctx, `
+			INSERT INTO stream_consumers (name, subject_pattern, position)
+			SELECT $1, $2, COALESCE(max(position), 0) FROM stream_messages;`,
+			consumer, pattern,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.listen()
+
+	sub := &streamSubscription{
+		stream:   s,
+		consumer: consumer,
+		pattern:  pattern,
+		cfg:      subCfg,
+		ordered:  ordered,
+		handler:  handler,
+		stop:     make(chan struct{}),
+		ready:    make(chan struct{}, 1),
+	}
+	s.subs = append(s.subs, sub)
+
+	// the rows are locked as they are handed out, so the workers of a
+	// subscription, like those of a group, never receive the same message
+	workers := 1
+	if pull, ok := subCfg.PullConsumer(); ok {
+		workers = pull.Workers
+	}
+	for i := 0; i < workers; i++ {
+		s.workers.Add(1)
+		go sub.work()
+	}
+
+	return sub, nil
+}
+
+// Unsubscribe stops every subscriber and the listener
+func (s *Stream) Unsubscribe() error {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = nil
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, sub := range subs {
+		errs = append(errs, sub.Unsubscribe())
+	}
+	s.workers.Wait()
+
+	return errors.Join(errs...)
+}
+
+// ConsumerLagCheck fails while any of the groups subscribed has more than
+// threshold messages waiting to be handed out
+func (s *Stream) ConsumerLagCheck(threshold uint64) health.CheckFunc {
+	return func(ctx context.Context) error {
+		s.mu.Lock()
+		groups := append([]string(nil), s.groups...)
+		s.mu.Unlock()
+
+		rows, err := s.db.Query(ctx, `
+			SELECT c.name, count(m.position)
+			FROM stream_consumers c
+			LEFT JOIN stream_messages m ON m.position > c.position AND m.subject ~ c.subject_pattern
+			WHERE c.name = ANY($1::text[])
+			GROUP BY c.name;`, groups)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var group string
+			var pending uint64
+			if err = rows.Scan(&group, &pending); err != nil {
+				return err
+			}
+			if pending > threshold {
+				return fmt.Errorf("the %s consumer is %d messages behind, more than the %d allowed", group, pending, threshold)
+			}
+		}
+		return rows.Err()
+	}
+}
+
+// StreamConsumer is how far a consumer of the stream has got; Pending are the
+// messages yet to be handed out, AckPending those handed out and not yet
+// Ack'd and Redelivered those of them handed out more than once
+type StreamConsumer struct {
+	Name        string
+	Pending     uint64
+	AckPending  uint64
+	Redelivered uint64
+}
+
+// StreamConsumers lists the consumers of the stream kept in the database
+func StreamConsumers(ctx context.Context, db DBTX) ([]StreamConsumer, error) {
+	rows, err := db.Query(ctx, `
+		SELECT c.name,
+			(SELECT count(*) FROM stream_messages m WHERE m.position > c.position AND m.subject ~ c.subject_pattern),
+			(SELECT count(*) FROM stream_deliveries d WHERE d.consumer = c.name AND d.deliveries > 0),
+			(SELECT count(*) FROM stream_deliveries d WHERE d.consumer = c.name AND d.deliveries > 1)
+		FROM stream_consumers c
+		ORDER BY c.name;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var consumers []StreamConsumer
+	for rows.Next() {
+		var consumer StreamConsumer
+		if err = rows.Scan(&consumer.Name, &consumer.Pending, &consumer.AckPending, &consumer.Redelivered); err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, rows.Err()
+}
+
+// listen starts waiting for notifications of published messages and
+// sweeping the ephemeral consumers; it is called with the lock held
+func (s *Stream) listen() {
+	if s.cancel != nil {
+		return
+	}
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.workers.Add(2)
+	go func() {
+		defer s.workers.Done()
+
+		ticker := time.NewTicker(streamSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := s.sweep(ctx, streamEphemeralTTL); err != nil && ctx.Err() == nil {
+				s.logger.Warn().Err(err).Msg("failed to sweep the ephemeral consumers")
+			}
+		}
+	}()
+	go func() {
+		defer s.workers.Done()
+
+		for {
+			err := s.waitForNotifications(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			// the subscribers keep polling in the meantime
+			s.logger.Warn().Err(err).Msg("stopped listening for published messages; listening again shortly")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(streamPollInterval):
+			}
+		}
+	}()
+}
+
+// sweep touches the ephemeral consumers of the subscribers of the process,
+// then removes those no process has touched within the ttl
+func (s *Stream) sweep(ctx context.Context, ttl time.Duration) error {
+	s.mu.Lock()
+	var consumers []string
+	for _, sub := range s.subs {
+		if sub.cfg.GroupName() == "" {
+			consumers = append(consumers, sub.consumer)
+		}
+	}
+	s.mu.Unlock()
+
+	if _, err := s.db.Exec(ctx, "UPDATE stream_consumers SET seen_at = NOW() WHERE name = ANY($1::text[])", consumers); err != nil {
+		return err
+	}
+
+	_, err := s.db.Exec(ctx, `
+		WITH orphans AS (
+			DELETE FROM stream_consumers
+			WHERE starts_with(name, $1) AND seen_at < NOW() - $2::float8 * interval '1 second'
+			RETURNING name
+		)
+		DELETE FROM stream_deliveries
+		WHERE consumer IN (SELECT name FROM orphans);`, streamEphemeralPrefix, ttl.Seconds())
+	return err
+}
+
+func (s *Stream) waitForNotifications(ctx context.Context) error {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+streamChannel); err != nil {
+		return err
+	}
+
+	for {
+		if _, err = conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+		s.notify()
+	}
+}
+
+func (s *Stream) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.subs {
+		sub.signal()
+	}
+}
+
+func (s *streamSubscription) Unsubscribe() error {
+	var err error
+	s.once.Do(func() {
+		close(s.stop)
+
+		// only the consumers of groups outlive their subscribers
+		if s.cfg.GroupName() != "" {
+			return
+		}
+		ctx := context.Background()
+		err = s.stream.txManager.WithinTransaction(ctx, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, "DELETE FROM stream_deliveries WHERE consumer = $1", s.consumer); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, "DELETE FROM stream_consumers WHERE name = $1", s.consumer)
+			return err
+		})
+	})
+
+	return err
+}
+
+func (s *streamSubscription) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (s *streamSubscription) work() {
+	defer s.stream.workers.Done()
+
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		delivery, err := s.next(context.Background())
+		if err != nil {
+			s.stream.logger.Error().Err(err).Str("Consumer", s.consumer).Msg("failed to fetch the next message")
+		}
+		if delivery == nil {
+			select {
+			case <-s.stop:
+				return
+			case <-s.ready:
+			case <-ticker.C:
+			}
+			continue
+		}
+
+		s.deliver(delivery)
+	}
+}
+
+// next hands out the next message to the consumer; messages that are due to
+// be delivered again are handed out before new ones
+//
+// The new messages of an ordered consumer are first added to its deliveries
+// with their partitions, then handed out like those delivered again, so a
+// message is held back for as long as one before it in its partition has not
+// been Ack'd.
+func (s *streamSubscription) next(ctx context.Context) (delivery *streamDelivery, err error) {
+	ackWait := s.cfg.AckWait().Seconds()
+
+	err = s.stream.txManager.WithinTransaction(ctx, func(tx pgx.Tx) error {
+		for {
+			for {
+				var position int64
+				var deliveries int
+				err := tx.QueryRow(ctx, `
+					SELECT d.position, d.deliveries
+					FROM stream_deliveries d
+					WHERE d.consumer = $1 AND d.visible_at <= NOW()
+					AND NOT EXISTS (
+						SELECT 1 FROM stream_deliveries e
+						WHERE e.consumer = d.consumer AND e.partition = d.partition AND e.position < d.position
+					)
+					ORDER BY d.position
+					LIMIT 1
+					FOR UPDATE OF d SKIP LOCKED;`, s.consumer,
+				).Scan(&position, &deliveries)
+				if errors.Is(err, pgx.ErrNoRows) {
+					break
+				}
+				if err != nil {
+					return err
+				}
+
+				if maxDeliveries := s.cfg.MaxRedeliver(); maxDeliveries > 0 && deliveries >= maxDeliveries {
+					if err = deadLetter(ctx, tx, s.consumer, position, "max deliveries reached"); err != nil {
+						return err
+					}
+					continue
+				}
+
+				_, err = tx.Exec(ctx, `
+					UPDATE stream_deliveries
+					SET deliveries = deliveries + 1, visible_at = NOW() + $3::float8 * interval '1 second'
+					WHERE consumer = $1 AND position = $2;`, s.consumer, position, ackWait)
+				if err != nil {
+					return err
+				}
+
+				delivery, err = loadStreamMessage(ctx, tx, position)
+				return err
+			}
+
+			if s.ordered != nil {
+				maxPending := streamMaxPending
+				if pull, ok := s.cfg.PullConsumer(); ok {
+					maxPending = pull.MaxInFlight
+				}
+				var pending int
+				err := tx.QueryRow(ctx, "SELECT count(*) FROM stream_deliveries WHERE consumer = $1", s.consumer).Scan(&pending)
+				if err != nil {
+					return err
+				}
+				if pending >= maxPending {
+					return nil
+				}
+			}
+
+			var offset int64
+			err := tx.QueryRow(ctx, "SELECT position FROM stream_consumers WHERE name = $1 FOR UPDATE", s.consumer).Scan(&offset)
+			if err != nil {
+				return err
+			}
+
+			var position int64
+			err = tx.QueryRow(ctx, `
+				SELECT position
+				FROM stream_messages
+				WHERE position > $1 AND subject ~ $2 AND (COALESCE(cardinality($3::text[]), 0) = 0 OR name = ANY($3::text[]))
+				ORDER BY position
+				LIMIT 1;`, offset, s.pattern, s.cfg.MessageFilters(),
+			).Scan(&position)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if _, err = tx.Exec(ctx, "UPDATE stream_consumers SET position = $2 WHERE name = $1", s.consumer, position); err != nil {
+				return err
+			}
+
+			if s.ordered != nil {
+				staged, err := loadStreamMessage(ctx, tx, position)
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(ctx, `
+					INSERT INTO stream_deliveries (consumer, position, deliveries, visible_at, partition)
+					VALUES ($1, $2, 0, NOW(), $3);`, s.consumer, position, s.ordered.Partition(&staged.message))
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			// auto-acked messages are handed out at most once
+			if s.cfg.AckType() != am.AckTypeAuto {
+				_, err = tx.Exec(ctx, `
+					INSERT INTO stream_deliveries (consumer, position, deliveries, visible_at)
+					VALUES ($1, $2, 1, NOW() + $3::float8 * interval '1 second');`, s.consumer, position, ackWait)
+				if err != nil {
+					return err
+				}
+			}
+
+			delivery, err = loadStreamMessage(ctx, tx, position)
+			return err
+		}
+	})
+
+	return delivery, err
+}
+
+func (s *streamSubscription) deliver(delivery *streamDelivery) {
+	extended := make(chan struct{}, 1)
+
+	msg := &delivery.message
+	msg.receivedAt = time.Now()
+	msg.ackFn = func() error { return s.ack(delivery.position) }
+	msg.nackFn = func() error { return s.nack(delivery.position) }
+	msg.extendFn = func() error {
+		if err := s.extend(delivery.position); err != nil {
+			return err
+		}
+		select {
+		case extended <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+	msg.killFn = func() error { return s.kill(delivery.position) }
+	if s.cfg.AckType() == am.AckTypeAuto {
+		noop := func() error { return nil }
+		msg.ackFn, msg.nackFn, msg.extendFn, msg.killFn = noop, noop, noop, noop
+	}
+
+	// the handler is cut off by the timer rather than a deadline, which an
+	// extension could not push back
+	ackWait := s.cfg.AckWait()
+	wCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.handler.HandleMessage(wCtx, msg)
+	}()
+
+	timer := time.NewTimer(ackWait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case err := <-errc:
+			if err == nil {
+				if ackErr := msg.Ack(); ackErr != nil {
+					s.stream.logger.Warn().Err(ackErr).Msg("failed to Ack a message")
+				}
+				return
+			}
+			s.stream.logger.Error().Err(err).Msg("error while handling message")
+			if nakErr := msg.NAck(); nakErr != nil {
+				s.stream.logger.Warn().Err(nakErr).Msg("failed to Nack a message")
+			}
+			return
+		case <-extended:
+			// the handler has pushed back the visibility timeout as well
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(ackWait)
+		case <-timer.C:
+			// the message is handed out again once its visibility timeout passes
+			cancel()
+			return
+		}
+	}
+}
+
+func (s *streamSubscription) ack(position int64) error {
+	_, err := s.stream.db.Exec(context.Background(),
+		"DELETE FROM stream_deliveries WHERE consumer = $1 AND position = $2", s.consumer, position)
+	// the next message of the partition may have been waiting on this one
+	s.signal()
+	return err
+}
+
+func (s *streamSubscription) nack(position int64) error {
+	_, err := s.stream.db.Exec(context.Background(),
+		"UPDATE stream_deliveries SET visible_at = NOW() WHERE consumer = $1 AND position = $2", s.consumer, position)
+	s.signal()
+	return err
+}
+
+func (s *streamSubscription) extend(position int64) error {
+	_, err := s.stream.db.Exec(context.Background(), `
+		UPDATE stream_deliveries
+		SET visible_at = NOW() + $3::float8 * interval '1 second'
+		WHERE consumer = $1 AND position = $2;`, s.consumer, position, s.cfg.AckWait().Seconds())
+	return err
+}
+
+func (s *streamSubscription) kill(position int64) error {
+	ctx := context.Background()
+	err := s.stream.txManager.WithinTransaction(ctx, func(tx pgx.Tx) error {
+		return deadLetter(ctx, tx, s.consumer, position, "terminated")
+	})
+	s.signal()
+	return err
+}
+
+func deadLetter(ctx context.Context, tx pgx.Tx, consumer string, position int64, reason string) error {
+	_, err := tx.Exec(ctx, `
+		WITH dead AS (
+			DELETE FROM stream_deliveries
+			WHERE consumer = $1 AND position = $2
+			RETURNING consumer, position, deliveries
+		)
+		INSERT INTO stream_dead_letters (consumer, position, deliveries, reason)
+		SELECT consumer, position, deliveries, $3 FROM dead
+		ON CONFLICT (consumer, position) DO NOTHING;`, consumer, position, reason)
+	return err
+}
+
+func loadStreamMessage(ctx context.Context, tx pgx.Tx, position int64) (*streamDelivery, error) {
+	delivery := &streamDelivery{position: position}
+	msg := &delivery.message
+
+	var metadata []byte
+	err := tx.QueryRow(ctx, `
+		SELECT id, name, subject, data, metadata, sent_at
+		FROM stream_messages
+		WHERE position = $1;`, position,
+	).Scan(&msg.id, &msg.name, &msg.subject, &msg.data, &metadata, &msg.sentAt)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(metadata, &msg.metadata); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// subjectPattern turns a NATS subject into a regular expression; a "*" token
+// matches any single token and a final ">" matches one or more tokens
+func subjectPattern(subject string) string {
+	tokens := strings.Split(subject, ".")
+	for i, token := range tokens {
+		switch {
+		case token == "*":
+			tokens[i] = `[^.]+`
+		case token == ">" && i == len(tokens)-1:
+			tokens[i] = `.+`
+		default:
+			tokens[i] = regexp.QuoteMeta(token)
+		}
+	}
+
+	return "^" + strings.Join(tokens, `\.`) + "$"
+}
+
+func (m *streamMessage) ID() string             { return m.id }
+func (m *streamMessage) Subject() string        { return m.subject }
+func (m *streamMessage) MessageName() string    { return m.name }
+func (m *streamMessage) Data() []byte           { return m.data }
+func (m *streamMessage) Metadata() ddd.Metadata { return m.metadata }
+func (m *streamMessage) SentAt() time.Time      { return m.sentAt }
+func (m *streamMessage) ReceivedAt() time.Time  { return m.receivedAt }
+
+func (m *streamMessage) Ack() error {
+	if m.acked {
+		return nil
+	}
+	m.acked = true
+	return m.ackFn()
+}
+
+func (m *streamMessage) NAck() error {
+	if m.acked {
+		return nil
+	}
+	m.acked = true
+	return m.nackFn()
+}
+
+func (m *streamMessage) Extend() error {
+	return m.extendFn()
+}
+
+func (m *streamMessage) Kill() error {
+	if m.acked {
+		return nil
+	}
+	m.acked = true
+	return m.killFn()
+}
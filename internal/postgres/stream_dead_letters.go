@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stackus/errors"
+)
+
+// StreamDeadLetter is a message a consumer of the stream gave up on, either
+// because it was delivered too many times or because the handler killed it
+type StreamDeadLetter struct {
+	// Sequence identifies the dead letter
+	Sequence    uint64
+	Consumer    string
+	Position    int64
+	Deliveries  int
+	Reason      string
+	Subject     string
+	MessageID   string
+	MessageName string
+	DeadAt      time.Time
+}
+
+// StreamDeadLetters looks into the dead letters of the stream kept in the
+// database and re-drives them
+type StreamDeadLetters struct {
+	db        *pgxpool.Pool
+	txManager TransactionManager
+}
+
+func NewStreamDeadLetters(db *pgxpool.Pool) StreamDeadLetters {
+	return StreamDeadLetters{
+		db:        db,
+		txManager: NewTransactionManager(db),
+	}
+}
+
+// List returns up to limit dead letters, oldest first
+func (d StreamDeadLetters) List(ctx context.Context, limit int) ([]StreamDeadLetter, error) {
+	rows, err := d.db.Query(ctx, `
+		SELECT l.sequence, l.consumer, l.position, l.deliveries, l.reason, l.dead_at, m.subject, m.id, m.name
+		FROM stream_dead_letters l
+		JOIN stream_messages m ON m.position = l.position
+		ORDER BY l.sequence
+		LIMIT $1;`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deadLetters []StreamDeadLetter
+	for rows.Next() {
+		var dl StreamDeadLetter
+		err = rows.Scan(&dl.Sequence, &dl.Consumer, &dl.Position, &dl.Deliveries, &dl.Reason, &dl.DeadAt, &dl.Subject, &dl.MessageID, &dl.MessageName)
+		if err != nil {
+			return nil, err
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+
+	return deadLetters, rows.Err()
+}
+
+// Redrive hands the message back to the consumer that gave up on it, as if it
+// had never been delivered, and then removes the dead letter; unlike with
+// JetStream no other consumer receives it again
+func (d StreamDeadLetters) Redrive(ctx context.Context, seq uint64) error {
+	return d.txManager.WithinTransaction(ctx, func(tx pgx.Tx) error {
+		var consumer, subject string
+		var position int64
+		err := tx.QueryRow(ctx, `
+			DELETE FROM stream_dead_letters l
+			USING stream_messages m
+			WHERE l.sequence = $1 AND m.position = l.position
+			RETURNING l.consumer, l.position, m.subject;`, seq,
+		).Scan(&consumer, &position, &subject)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.Wrapf(errors.ErrNotFound, "no dead letter %d", seq)
+		}
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO stream_deliveries (consumer, position, deliveries, visible_at)
+			SELECT name, $2, 0, NOW() FROM stream_consumers WHERE name = $1;`, consumer, position)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return errors.Wrapf(errors.ErrNotFound, "the consumer %s of dead letter %d no longer exists", consumer, seq)
+		}
+
+		// wake the subscribers, once the transaction commits
+		_, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", streamChannel, subject)
+		return err
+	})
+}
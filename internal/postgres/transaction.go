@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
 )
 
 type contextKey string
@@ -49,3 +50,24 @@ func (tm TransactionManager) WithinTransaction(ctx context.Context, fn func(tx p
 
 	return nil
 }
+
+// EndTransaction returns the finalizer of scoped transactions; the
+// transaction is committed when the scope ends without an error and is rolled
+// back otherwise
+//
+// A failed rollback is logged rather than returned since the scope has already
+// failed with an error of its own.
+func EndTransaction(logger zerolog.Logger) func(ctx context.Context, value any, err error) error {
+	return func(ctx context.Context, value any, err error) error {
+		tx := value.(pgx.Tx)
+
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				logger.Error().Err(rbErr).AnErr("Cause", err).Msg("failed to roll back the transaction of a scope")
+			}
+			return nil
+		}
+
+		return tx.Commit(ctx)
+	}
+}
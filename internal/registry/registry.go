@@ -1,6 +1,9 @@
 package registry
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 type (
 	Registrable interface {
@@ -14,6 +17,7 @@ type (
 		Serialize(key string, v interface{}) ([]byte, error)
 		Build(key string, options ...BuildOption) (interface{}, error)
 		Deserialize(key string, data []byte, options ...BuildOption) (interface{}, error)
+		Keys() []string
 		register(key string, fn func() interface{}, s Serializer, d Deserializer, o []BuildOption) error
 	}
 )
@@ -77,6 +81,20 @@ func (r *registry) Build(key string, options ...BuildOption) (interface{}, error
 	return v, nil
 }
 
+// Keys returns every registered key in order
+func (r *registry) Keys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]string, 0, len(r.registered))
+	for key := range r.registered {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 func (r *registry) register(key string, fn func() interface{}, s Serializer, d Deserializer, o []BuildOption) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -1,12 +1,53 @@
 package rpc
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
 
-type RpcConfig struct {
-	Host string `default:"0.0.0.0"`
-	Port string `default:":8085"`
-}
+	"github.com/stackus/errors"
+)
+
+type (
+	RpcConfig struct {
+		Host     string           `default:"0.0.0.0"`
+		Port     string           `default:":8085"`
+		Services ServiceAddresses `envconfig:"RPC_SERVICES"`
+	}
+
+	// ServiceAddresses maps module names to the gRPC address they are served from.
+	//
+	// It is decoded from a comma separated list of name=address pairs, e.g.
+	// "restaurants=restaurants:8085,users=users:8085"
+	ServiceAddresses map[string]string
+)
 
 func (c RpcConfig) Address() string {
 	return fmt.Sprintf("%s%s", c.Host, c.Port)
 }
+
+// Service returns the address to dial to reach the named module; modules
+// without a configured address are expected to be running in this process
+func (c RpcConfig) Service(name string) string {
+	if address, exists := c.Services[name]; exists {
+		return address
+	}
+	return c.Address()
+}
+
+func (s *ServiceAddresses) Decode(value string) error {
+	addresses := make(ServiceAddresses)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, address, found := strings.Cut(pair, "=")
+		name, address = strings.TrimSpace(name), strings.TrimSpace(address)
+		if !found || name == "" || address == "" {
+			return errors.Wrapf(errors.ErrBadRequest, "invalid service address %q", pair)
+		}
+		addresses[name] = address
+	}
+	*s = addresses
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+type transactionalRegistrar struct {
+	registrar    grpc.ServiceRegistrar
+	container    di.Container
+	interceptors []grpc.UnaryServerInterceptor
+	logger       zerolog.Logger
+}
+
+// NewTransactionalRegistrar returns a registrar that runs every unary RPC of
+// the services registered with it as one unit of work
+//
+// Each call gets a new scope of the container, attached to its context, which
+// ends with the outcome of the call so its transaction is committed when the
+// call succeeds and rolled back when it fails or panics. The interceptors run
+// inside the unit of work, after the interceptors of the server itself.
+//
+// Streaming RPCs are registered as they are; a stream can stay open far
+// longer than a transaction should, so their handlers use di.WithinScope for
+// each piece of work instead.
+func NewTransactionalRegistrar(registrar grpc.ServiceRegistrar, container di.Container, logger zerolog.Logger, interceptors ...grpc.UnaryServerInterceptor) grpc.ServiceRegistrar {
+	return transactionalRegistrar{
+		registrar:    registrar,
+		container:    container,
+		interceptors: interceptors,
+		logger:       logger,
+	}
+}
+
+func (r transactionalRegistrar) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	wrapped := *desc
+	wrapped.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, method := range desc.Methods {
+		wrapped.Methods[i] = grpc.MethodDesc{
+			MethodName: method.MethodName,
+			Handler:    r.wrapHandler(method.Handler),
+		}
+	}
+
+	r.registrar.RegisterService(&wrapped, impl)
+}
+
+func (r transactionalRegistrar) wrapHandler(handler grpc.MethodHandler) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		if interceptor == nil {
+			return handler(srv, ctx, dec, r.intercept)
+		}
+
+		return handler(srv, ctx, dec, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+			return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+				return r.intercept(ctx, req, info, next)
+			})
+		})
+	}
+}
+
+func (r transactionalRegistrar) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	err = di.WithinScope(ctx, r.container, func(ctx context.Context) error {
+		resp, err = chainInterceptors(r.interceptors, info, handler)(ctx, req)
+		return err
+	})
+	if err != nil {
+		r.logger.Error().Err(err).Str("Method", info.FullMethod).Msg("failed to handle request")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func chainInterceptors(interceptors []grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	return handler
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+const (
+	testMethod = "/test.Service/Method"
+	txKey      = "tx"
+)
+
+// unitOfWork stands in for a transaction; it records how the scope it was
+// built in ended
+type unitOfWork struct {
+	outcome string
+}
+
+// capturingRegistrar keeps the service registered with it so its methods can
+// be called directly
+type capturingRegistrar struct {
+	desc *grpc.ServiceDesc
+}
+
+func (r *capturingRegistrar) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	r.desc = desc
+}
+
+// testService registers a service with a single method, written the way the
+// generated code writes its method handlers
+func testService(registrar grpc.ServiceRegistrar, handler grpc.UnaryHandler) {
+	registrar.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Service",
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Method",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := "request"
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: testMethod}, handler)
+			},
+		}},
+	}, nil)
+}
+
+func TestTransactionalRegistrar(t *testing.T) {
+	tests := map[string]struct {
+		handle      func() (any, error)
+		wantOutcome string
+		wantPanic   bool
+	}{
+		"a call that succeeds is committed": {
+			handle:      func() (any, error) { return "response", nil },
+			wantOutcome: "committed",
+		},
+		"a call that fails is rolled back": {
+			handle:      func() (any, error) { return nil, fmt.Errorf("failed") },
+			wantOutcome: "rolled back",
+		},
+		"a call that panics is rolled back": {
+			handle:      func() (any, error) { panic("handler panicked") },
+			wantOutcome: "rolled back",
+			wantPanic:   true,
+		},
+	}
+	// the server may or may not have interceptors of its own
+	serverInterceptors := map[string]grpc.UnaryServerInterceptor{
+		"without server interceptors": nil,
+		"with server interceptors": func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(ctx, req)
+		},
+	}
+	for name, tc := range tests {
+		for server, serverInterceptor := range serverInterceptors {
+			t.Run(name+" "+server, func(t *testing.T) {
+				var work *unitOfWork
+				container := di.New()
+				di.Register(container, di.Scoped, txKey, func(c di.Container) (*unitOfWork, error) {
+					work = &unitOfWork{}
+					return work, nil
+				}, di.Finalizer(func(ctx context.Context, value any, err error) error {
+					value.(*unitOfWork).outcome = "committed"
+					if err != nil {
+						value.(*unitOfWork).outcome = "rolled back"
+					}
+					return nil
+				}))
+
+				// the interceptors of the registrar run inside the unit of work
+				intercepted := false
+				interceptor := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+					di.MustGet[*unitOfWork](di.FromContext(ctx), txKey)
+					intercepted = true
+					return handler(ctx, req)
+				}
+
+				registrar := &capturingRegistrar{}
+				testService(NewTransactionalRegistrar(registrar, container, zerolog.Nop(), interceptor), func(ctx context.Context, req any) (any, error) {
+					return tc.handle()
+				})
+
+				func() {
+					defer func() {
+						if p := recover(); (p != nil) != tc.wantPanic {
+							t.Errorf("expected a panic to be passed on: %t, got %v", tc.wantPanic, p)
+						}
+					}()
+					_, _ = registrar.desc.Methods[0].Handler(nil, context.Background(), nil, serverInterceptor)
+				}()
+
+				if !intercepted || work == nil {
+					t.Fatal("expected the call to be intercepted within a scope")
+				}
+				if work.outcome != tc.wantOutcome {
+					t.Errorf("expected the unit of work to be %s, it was %q", tc.wantOutcome, work.outcome)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package system
+
+import (
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/migrations"
+)
+
+// Migrations returns the shared migrations followed by those of each module
+func Migrations(modules ...Module) []postgres.Migrations {
+	all := []postgres.Migrations{
+		{Schema: "public", FS: migrations.FS},
+	}
+	for _, module := range modules {
+		if m, ok := module.(MigratingModule); ok {
+			all = append(all, m.Migrations())
+		}
+	}
+
+	return all
+}
+
+// StartupModules starts each module in turn using the system as the service;
+// the schemas of the modules are migrated first unless PG_AUTO_MIGRATE is off
+func (s *System) StartupModules(modules ...Module) error {
+	if s.cfg.PG.AutoMigrate {
+		if err := s.migrate(modules...); err != nil {
+			return err
+		}
+	}
+
+	for _, module := range modules {
+		ctx := s.Waiter().Context()
+		if err := module.Startup(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run serves the started modules until the system is shut down
+func (s *System) Run() error {
+	s.Waiter().Add(
+		s.WaitForWeb,
+		s.WaitForRPC,
+		s.WaitForStream,
+		s.WaitForHealth,
+	)
+
+	return s.Waiter().Wait()
+}
+
+func (s *System) migrate(modules ...Module) error {
+	db := stdlib.OpenDBFromPool(s.db)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return postgres.NewMigrator(db, s.logger, Migrations(modules...)...).Up(s.Waiter().Context())
+}
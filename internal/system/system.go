@@ -10,8 +10,14 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/auth"
 	"github.com/jongyunha/lunchbox/internal/config"
+	"github.com/jongyunha/lunchbox/internal/health"
+	"github.com/jongyunha/lunchbox/internal/jetstream"
 	"github.com/jongyunha/lunchbox/internal/logger"
+	"github.com/jongyunha/lunchbox/internal/memory"
+	"github.com/jongyunha/lunchbox/internal/postgres"
 	"github.com/jongyunha/lunchbox/internal/waiter"
 	"github.com/nats-io/nats.go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -32,8 +38,11 @@ type System struct {
 	db     *pgxpool.Pool
 	nc     *nats.Conn
 	js     nats.JetStreamContext
+	stream am.MessageStream
 	mux    *chi.Mux
 	rpc    *grpc.Server
+	auth   *auth.Authenticator
+	health *health.Health
 	waiter waiter.Waiter
 	logger zerolog.Logger
 	tp     *sdktrace.TracerProvider
@@ -48,18 +57,29 @@ func NewSystem(cfg config.AppConfig) (*System, error) {
 		return nil, err
 	}
 
-	if err := s.initJS(); err != nil {
-		return nil, err
+	if s.cfg.Stream.Backend == config.JetStreamBackend {
+		if err := s.initJS(); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.initOpenTelemetry(); err != nil {
 		return nil, err
 	}
 
+	if err := s.initAuth(); err != nil {
+		return nil, err
+	}
+
+	s.initHealth()
 	s.initMux()
 	s.initRpc()
 	s.initLogger()
 
+	if err := s.initStream(); err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
 
@@ -79,21 +99,75 @@ func (s *System) initOpenTelemetry() (err error) {
 }
 
 func (s *System) initJS() (err error) {
-	s.nc, err = nats.Connect(s.cfg.Nats.URL)
+	url := s.cfg.Nats.URL
+	if s.cfg.Nats.Embedded {
+		server, err := jetstream.StartEmbeddedServer(s.cfg.Nats.EmbeddedPort, s.cfg.Nats.StoreDir)
+		if err != nil {
+			return err
+		}
+		// the connection has been drained by the time the cleanups run
+		s.waiter.Cleanup(server.Shutdown)
+		url = server.ClientURL()
+	} else if url == "" {
+		return errors.ErrBadRequest.Msg("NATS_URL is required unless NATS_EMBEDDED is set")
+	}
+
+	s.nc, err = nats.Connect(url)
 	if err != nil {
 		return err
 	}
 	s.js, err = s.nc.JetStream()
-	if err != nil {
-		return err
+	return err
+}
+
+// provisionJS creates the stream, or updates it to its spec, once the logger
+// is there to report any drift
+func (s *System) provisionJS() (jetstream.Provisioner, error) {
+	var specs jetstream.Specs
+	if s.cfg.Nats.SpecsFile != "" {
+		var err error
+		if specs, err = jetstream.LoadSpecs(s.cfg.Nats.SpecsFile); err != nil {
+			return jetstream.Provisioner{}, err
+		}
 	}
 
-	_, err = s.js.AddStream(&nats.StreamConfig{
-		Name:     s.cfg.Nats.Stream,
-		Subjects: []string{fmt.Sprintf("%s.>", s.cfg.Nats.Stream)},
-	})
+	provisioner := jetstream.NewProvisioner(s.js, specs, s.cfg.Nats.FailOnDrift, s.logger)
+	if err := provisioner.ProvisionStream(s.cfg.Nats.Stream); err != nil {
+		return jetstream.Provisioner{}, err
+	}
 
-	return err
+	return provisioner, jetstream.NewDeadLetters(s.cfg.Nats.Stream, s.js).Provision()
+}
+
+// initStream sets up the one stream shared by the modules, so that the
+// backend can be changed without them knowing
+func (s *System) initStream() error {
+	switch s.cfg.Stream.Backend {
+	case config.JetStreamBackend:
+		provisioner, err := s.provisionJS()
+		if err != nil {
+			return err
+		}
+		format := jetstream.WireFormat(s.cfg.Nats.WireFormat)
+		if format != jetstream.HeaderFormat && format != jetstream.EnvelopeFormat {
+			return errors.Wrapf(errors.ErrBadRequest, "unknown wire format %q", s.cfg.Nats.WireFormat)
+		}
+		stream := jetstream.NewStream(s.cfg.Nats.Stream, s.js, provisioner, format, s.logger)
+		s.health.Register("stream.consumers", stream.ConsumerLagCheck(s.cfg.Health.ConsumerLag))
+		s.stream = stream
+	case config.MemoryBackend:
+		stream := memory.NewStream(s.logger)
+		s.health.Register("stream.consumers", stream.ConsumerLagCheck(s.cfg.Health.ConsumerLag))
+		s.stream = stream
+	case config.PostgresBackend:
+		stream := postgres.NewStream(s.db, s.logger)
+		s.health.Register("stream.consumers", stream.ConsumerLagCheck(s.cfg.Health.ConsumerLag))
+		s.stream = stream
+	default:
+		return errors.Wrapf(errors.ErrBadRequest, "unknown stream backend %q", s.cfg.Stream.Backend)
+	}
+
+	return nil
 }
 
 func (s *System) initLogger() {
@@ -103,10 +177,46 @@ func (s *System) initLogger() {
 	})
 }
 
+func (s *System) initAuth() (err error) {
+	s.auth, err = auth.New(s.cfg.Auth)
+	return err
+}
+
+func (s *System) Auth() *auth.Authenticator {
+	return s.auth
+}
+
+// initHealth registers the checks of the infrastructure every module relies
+// on; modules register their own checks during startup
+func (s *System) initHealth() {
+	s.health = health.New(s.cfg.Health)
+
+	s.health.Register("postgres", s.db.Ping)
+	if s.nc == nil {
+		return
+	}
+	s.health.Register("nats", func(ctx context.Context) error {
+		if status := s.nc.Status(); status != nats.CONNECTED {
+			return fmt.Errorf("the connection is %s", status)
+		}
+		return nil
+	})
+	s.health.Register("jetstream", func(ctx context.Context) error {
+		_, err := s.js.StreamInfo(s.cfg.Nats.Stream, nats.Context(ctx))
+		return err
+	})
+}
+
+func (s *System) Health() *health.Health {
+	return s.health
+}
+
 func (s *System) initMux() {
 	s.mux = chi.NewMux()
 	s.mux.Use(middleware.Heartbeat("/liveness"))
+	s.mux.Use(s.auth.Middleware)
 	s.mux.Method("GET", "/metrics", promhttp.Handler())
+	s.mux.Method("GET", "/readiness", s.health)
 }
 
 func (s *System) RPC() *grpc.Server {
@@ -191,7 +301,19 @@ func (s *System) WaitForRPC(ctx context.Context) error {
 	return group.Wait()
 }
 
+// WaitForHealth keeps the health checks running until shutdown begins
+func (s *System) WaitForHealth(ctx context.Context) error {
+	return s.health.Run(ctx)
+}
+
 func (s *System) WaitForStream(ctx context.Context) error {
+	if s.nc == nil {
+		fmt.Println("message stream started")
+		defer fmt.Println("message stream stopped")
+		<-ctx.Done()
+		return s.stream.Unsubscribe()
+	}
+
 	closed := make(chan struct{})
 	s.nc.SetClosedHandler(func(*nats.Conn) {
 		close(closed)
@@ -211,17 +333,7 @@ func (s *System) WaitForStream(ctx context.Context) error {
 }
 
 func (s *System) initDB() (err error) {
-	dsn := fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		s.cfg.PG.User,
-		s.cfg.PG.Password,
-		s.cfg.PG.Host,
-		s.cfg.PG.Port,
-		s.cfg.PG.DBName,
-		s.cfg.PG.SSLMode,
-	)
-
-	poolConfig, err := pgxpool.ParseConfig(dsn)
+	poolConfig, err := pgxpool.ParseConfig(s.cfg.PG.DSN())
 	if err != nil {
 		return fmt.Errorf("error parsing config: %w", err)
 	}
@@ -257,6 +369,10 @@ func (s *System) JS() nats.JetStreamContext {
 	return s.js
 }
 
+func (s *System) Stream() am.MessageStream {
+	return s.stream
+}
+
 func (s *System) Logger() zerolog.Logger {
 	return s.logger
 }
@@ -266,13 +382,16 @@ func (s *System) initRpc() {
 		grpc.ChainUnaryInterceptor(
 			otelgrpc.UnaryServerInterceptor(),
 			serverErrorUnaryInterceptor(),
+			s.auth.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
+			serverErrorStreamInterceptor(),
+			s.auth.StreamServerInterceptor(),
 		),
-		// If there are streaming endpoints also add
-		// grpc.StreamInterceptor(
-		// 	otelgrpc.StreamServerInterceptor(),
-		// ),
 	)
 	reflection.Register(s.rpc)
+	s.health.RegisterServer(s.rpc)
 }
 
 func serverErrorUnaryInterceptor() grpc.UnaryServerInterceptor {
@@ -281,3 +400,9 @@ func serverErrorUnaryInterceptor() grpc.UnaryServerInterceptor {
 		return resp, errors.SendGRPCError(err)
 	}
 }
+
+func serverErrorStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return errors.SendGRPCError(handler(srv, ss))
+	}
+}
@@ -5,7 +5,12 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/auth"
 	"github.com/jongyunha/lunchbox/internal/config"
+	"github.com/jongyunha/lunchbox/internal/health"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/registry"
 	"github.com/jongyunha/lunchbox/internal/waiter"
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog"
@@ -14,8 +19,11 @@ import (
 
 type Service interface {
 	Config() config.AppConfig
+	Auth() *auth.Authenticator
+	Health() *health.Health
 	DB() *pgxpool.Pool
 	JS() nats.JetStreamContext
+	Stream() am.MessageStream
 	Mux() *chi.Mux
 	RPC() *grpc.Server
 	Waiter() waiter.Waiter
@@ -25,3 +33,15 @@ type Service interface {
 type Module interface {
 	Startup(context.Context, Service) error
 }
+
+// MigratingModule is implemented by the modules that own a database schema
+type MigratingModule interface {
+	Migrations() postgres.Migrations
+}
+
+// InspectableModule is implemented by the modules the admin commands can look into
+type InspectableModule interface {
+	MigratingModule
+	Registry() (registry.Registry, error)
+	Projections() []postgres.Projection
+}
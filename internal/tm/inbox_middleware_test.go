@@ -0,0 +1,47 @@
+package tm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/memory"
+	"github.com/jongyunha/lunchbox/internal/tm"
+)
+
+// incomingMessage is a message as handed to a handler, settled by the stream
+type incomingMessage struct {
+	message
+}
+
+func (incomingMessage) ReceivedAt() time.Time { return time.Now() }
+func (incomingMessage) Ack() error            { return nil }
+func (incomingMessage) NAck() error           { return nil }
+func (incomingMessage) Extend() error         { return nil }
+func (incomingMessage) Kill() error           { return nil }
+
+func TestInboxHandler(t *testing.T) {
+	store := memory.NewInboxStore()
+
+	var handled []string
+	handler := tm.InboxHandler(store)(am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		handled = append(handled, msg.ID())
+		return nil
+	}))
+
+	// a message delivered again is Ack'd without being handled again
+	for _, id := range []string{"1", "2", "1"} {
+		if err := handler.HandleMessage(context.Background(), incomingMessage{message{id: id, sentAt: time.Now()}}); err != nil {
+			t.Errorf("expected message %s to be Ack'd, got %v", id, err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"1", "2"}, handled); diff != "" {
+		t.Errorf("expected each message to be handled once (-want +got):\n%s", diff)
+	}
+	if got := len(store.Messages()); got != 2 {
+		t.Errorf("expected the inbox to hold the two messages, got %d", got)
+	}
+}
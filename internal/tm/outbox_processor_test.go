@@ -0,0 +1,140 @@
+package tm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/jetstream"
+	"github.com/jongyunha/lunchbox/internal/memory"
+	"github.com/jongyunha/lunchbox/internal/tm"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+)
+
+const (
+	testStreamName = "test"
+	testTopic      = "test.events"
+)
+
+type message struct {
+	id     string
+	sentAt time.Time
+}
+
+func (m message) ID() string             { return m.id }
+func (m message) Subject() string        { return testTopic }
+func (m message) MessageName() string    { return "test.Event" }
+func (m message) Data() []byte           { return []byte(`{}`) }
+func (m message) Metadata() ddd.Metadata { return ddd.Metadata{} }
+func (m message) SentAt() time.Time      { return m.sentAt }
+
+func startTestStream(t *testing.T) *jetstream.Stream {
+	t.Helper()
+
+	server, err := jetstream.StartEmbeddedServer(-1, t.TempDir())
+	if err != nil {
+		t.Fatalf("starting the embedded server: %v", err)
+	}
+	t.Cleanup(server.Shutdown)
+
+	nc, err := nats.Connect(server.ClientURL())
+	if err != nil {
+		t.Fatalf("connecting to the embedded server: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provisioner := jetstream.NewProvisioner(js, jetstream.Specs{}, false, zerolog.Nop())
+	if err = provisioner.ProvisionStream(testStreamName); err != nil {
+		t.Fatalf("provisioning the stream: %v", err)
+	}
+
+	stream := jetstream.NewStream(testStreamName, js, provisioner, jetstream.HeaderFormat, zerolog.Nop())
+	t.Cleanup(func() { _ = stream.Unsubscribe() })
+
+	return stream
+}
+
+func TestOutboxProcessor(t *testing.T) {
+	stream := startTestStream(t)
+	store := memory.NewOutboxStore()
+
+	received := make(chan string, 10)
+	_, err := stream.Subscribe(testTopic, am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		received <- msg.ID()
+		return nil
+	}), am.GroupName("group"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// publishing through the outbox only saves the messages
+	publisher := am.MessagePublisherWithMiddleware(stream, tm.OutboxPublisher(store))
+	for _, id := range []string{"1", "2"} {
+		if err = publisher.Publish(context.Background(), testTopic, message{id: id, sentAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// saving a message again is not an error
+	if err = publisher.Publish(context.Background(), testTopic, message{id: "1", sentAt: time.Now()}); err != nil {
+		t.Errorf("expected a duplicate to be dropped, got %v", err)
+	}
+	select {
+	case id := <-received:
+		t.Fatalf("expected nothing to be published before the processor starts, got %s", id)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- tm.NewOutboxProcessor(stream, store).Start(ctx)
+	}()
+
+	// a message saved while the processor runs is picked up by the next poll
+	if err = publisher.Publish(context.Background(), testTopic, message{id: "3", sentAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]int)
+	timeout := time.After(5 * time.Second)
+	for len(got) < 3 {
+		select {
+		case id := <-received:
+			got[id]++
+		case <-timeout:
+			t.Fatalf("expected the three messages to be published, got %v", got)
+		}
+	}
+	// give a message published twice the chance to show up
+	select {
+	case id := <-received:
+		t.Errorf("expected each message to be published once, %s was published again", id)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	unpublished, err := store.FindUnpublished(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unpublished) != 0 {
+		t.Errorf("expected every message to be marked published, %d were not", len(unpublished))
+	}
+
+	cancel()
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Errorf("expected the processor to stop cleanly, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the processor to stop once its context is done")
+	}
+}
@@ -0,0 +1,31 @@
+-- +goose Up
+-- the trigger functions are shared by the tables of every module
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION created_at_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NEW.created_at IS NULL THEN
+    NEW.created_at := NOW();
+  ELSE
+    NEW.created_at := OLD.created_at;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION updated_at_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF row(NEW.*) IS DISTINCT FROM row(OLD.*) THEN
+    NEW.updated_at := NOW();
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION updated_at_trigger();
+DROP FUNCTION created_at_trigger();
@@ -0,0 +1,48 @@
+-- +goose Up
+-- the tables of the message stream used when STREAM_BACKEND is postgres
+CREATE TABLE stream_messages (
+  position     bigserial   NOT NULL,
+  id           text        NOT NULL,
+  name         text        NOT NULL,
+  subject      text        NOT NULL,
+  data         bytea       NOT NULL,
+  metadata     bytea       NOT NULL,
+  sent_at      timestamptz NOT NULL,
+  published_at timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (position)
+);
+
+-- a message id is only published once, the same as with JetStream
+CREATE UNIQUE INDEX stream_messages_id_idx ON stream_messages (id);
+
+-- position is the last message handed out to the consumer; subject_pattern
+-- is the regular expression the subjects it subscribed to are matched with
+CREATE TABLE stream_consumers (
+  name            text        NOT NULL,
+  subject_pattern text        NOT NULL,
+  position        bigint      NOT NULL,
+  created_at      timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (name)
+);
+
+-- the messages handed out to a consumer that have not been acked yet; they
+-- are handed out again once visible_at has passed
+CREATE TABLE stream_deliveries (
+  consumer   text        NOT NULL,
+  position   bigint      NOT NULL,
+  deliveries int         NOT NULL,
+  visible_at timestamptz NOT NULL,
+  PRIMARY KEY (consumer, position)
+);
+
+CREATE TABLE stream_dead_letters (
+  consumer   text        NOT NULL,
+  position   bigint      NOT NULL,
+  deliveries int         NOT NULL,
+  reason     text        NOT NULL,
+  dead_at    timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (consumer, position)
+);
+
+-- +goose Down
+DROP TABLE stream_dead_letters, stream_deliveries, stream_consumers, stream_messages;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- the partition of the message for the consumers of ordered subscriptions;
+-- a delivery is only handed out once those before it in its partition are gone
+ALTER TABLE stream_deliveries
+  ADD COLUMN partition text;
+
+CREATE INDEX stream_deliveries_partition_idx ON stream_deliveries (consumer, partition, position) WHERE partition IS NOT NULL;
+
+-- +goose Down
+DROP INDEX stream_deliveries_partition_idx;
+
+ALTER TABLE stream_deliveries
+  DROP COLUMN partition;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- the sequence identifies a dead letter when it is looked at or re-driven
+ALTER TABLE stream_dead_letters
+  ADD COLUMN sequence bigserial NOT NULL;
+
+CREATE UNIQUE INDEX stream_dead_letters_sequence_idx ON stream_dead_letters (sequence);
+
+-- +goose Down
+DROP INDEX stream_dead_letters_sequence_idx;
+
+ALTER TABLE stream_dead_letters
+  DROP COLUMN sequence;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- the subscribers of a process keep touching their ephemeral consumers;
+-- those left behind by a process that did not unsubscribe stop being touched
+-- and are swept away along with their deliveries
+ALTER TABLE stream_consumers
+  ADD COLUMN seen_at timestamptz NOT NULL DEFAULT NOW();
+
+-- +goose Down
+ALTER TABLE stream_consumers
+  DROP COLUMN seen_at;
@@ -0,0 +1,3 @@
+package polls
+
+//go:generate buf generate
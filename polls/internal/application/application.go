@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/polls/internal/application/commands"
+	"github.com/jongyunha/lunchbox/polls/internal/application/queries"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+)
+
+type (
+	App interface {
+		Commands
+		Queries
+	}
+
+	Commands interface {
+		CreatePoll(ctx context.Context, cmd commands.CreatePoll) error
+		CastVote(ctx context.Context, cmd commands.CastVote) error
+		ClosePoll(ctx context.Context, cmd commands.ClosePoll) error
+	}
+
+	Queries interface {
+		GetPoll(ctx context.Context, query queries.GetPoll) (*domain.Poll, error)
+		ListExpiredPolls(ctx context.Context, query queries.ListExpiredPolls) ([]string, error)
+	}
+
+	Application struct {
+		appCommands
+		appQueries
+	}
+
+	appCommands struct {
+		commands.CreatePollHandler
+		commands.CastVoteHandler
+		commands.ClosePollHandler
+	}
+
+	appQueries struct {
+		queries.GetPollHandler
+		queries.ListExpiredPollsHandler
+	}
+)
+
+var _ App = (*Application)(nil)
+
+func New(lunchPolls domain.LunchPollRepository, polls domain.PollRepository, publisher ddd.EventPublisher[ddd.Event]) *Application {
+	return &Application{
+		appCommands: appCommands{
+			CreatePollHandler: commands.NewCreatePollHandler(lunchPolls, publisher),
+			CastVoteHandler:   commands.NewCastVoteHandler(lunchPolls, publisher),
+			ClosePollHandler:  commands.NewClosePollHandler(lunchPolls, publisher),
+		},
+		appQueries: appQueries{
+			GetPollHandler:          queries.NewGetPollHandler(polls),
+			ListExpiredPollsHandler: queries.NewListExpiredPollsHandler(polls),
+		},
+	}
+}
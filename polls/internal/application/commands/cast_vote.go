@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+)
+
+type (
+	CastVote struct {
+		ID           string
+		UserID       string
+		RestaurantID string
+	}
+
+	CastVoteHandler struct {
+		polls     domain.LunchPollRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewCastVoteHandler(polls domain.LunchPollRepository, publisher ddd.EventPublisher[ddd.Event]) CastVoteHandler {
+	return CastVoteHandler{
+		polls:     polls,
+		publisher: publisher,
+	}
+}
+
+func (h CastVoteHandler) CastVote(ctx context.Context, cmd CastVote) error {
+	poll, err := h.polls.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := poll.CastVote(cmd.UserID, cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	err = h.polls.Save(ctx, poll)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+)
+
+type (
+	ClosePoll struct {
+		ID string
+	}
+
+	ClosePollHandler struct {
+		polls     domain.LunchPollRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewClosePollHandler(polls domain.LunchPollRepository, publisher ddd.EventPublisher[ddd.Event]) ClosePollHandler {
+	return ClosePollHandler{
+		polls:     polls,
+		publisher: publisher,
+	}
+}
+
+func (h ClosePollHandler) ClosePoll(ctx context.Context, cmd ClosePoll) error {
+	poll, err := h.polls.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := poll.ClosePoll()
+	if err != nil {
+		return err
+	}
+
+	err = h.polls.Save(ctx, poll)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
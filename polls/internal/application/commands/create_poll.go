@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+)
+
+type (
+	CreatePoll struct {
+		ID            string
+		Title         string
+		UserID        string
+		RestaurantIDs []string
+		Deadline      time.Time
+	}
+
+	CreatePollHandler struct {
+		polls     domain.LunchPollRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewCreatePollHandler(polls domain.LunchPollRepository, publisher ddd.EventPublisher[ddd.Event]) CreatePollHandler {
+	return CreatePollHandler{
+		polls:     polls,
+		publisher: publisher,
+	}
+}
+
+func (h CreatePollHandler) CreatePoll(ctx context.Context, cmd CreatePoll) error {
+	poll, err := h.polls.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := poll.CreatePoll(cmd.Title, cmd.UserID, cmd.RestaurantIDs, cmd.Deadline)
+	if err != nil {
+		return err
+	}
+
+	err = h.polls.Save(ctx, poll)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+)
+
+type (
+	GetPoll struct {
+		ID string
+	}
+
+	GetPollHandler struct {
+		polls domain.PollRepository
+	}
+)
+
+func NewGetPollHandler(polls domain.PollRepository) GetPollHandler {
+	return GetPollHandler{
+		polls: polls,
+	}
+}
+
+func (h GetPollHandler) GetPoll(ctx context.Context, query GetPoll) (*domain.Poll, error) {
+	return h.polls.Find(ctx, query.ID)
+}
@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+)
+
+type (
+	ListExpiredPolls struct {
+		Now time.Time
+	}
+
+	ListExpiredPollsHandler struct {
+		polls domain.PollRepository
+	}
+)
+
+func NewListExpiredPollsHandler(polls domain.PollRepository) ListExpiredPollsHandler {
+	return ListExpiredPollsHandler{
+		polls: polls,
+	}
+}
+
+func (h ListExpiredPollsHandler) ListExpiredPolls(ctx context.Context, query ListExpiredPolls) ([]string, error) {
+	return h.polls.FindExpired(ctx, query.Now)
+}
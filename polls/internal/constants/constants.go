@@ -0,0 +1,29 @@
+package constants
+
+// ServiceName The name of this module/service
+const ServiceName = "polls"
+
+// GRPC Service Names
+const (
+	PollsServiceName = "POLLS"
+)
+
+// Dependency Injection Keys
+const (
+	RegistryKey            = "registry"
+	DomainDispatcherKey    = "domainDispatcher"
+	DatabaseTransactionKey = "tx"
+	IdempotencyStoreKey    = "idempotencyStore"
+	MessagePublisherKey    = "messagePublisher"
+	MessageSubscriberKey   = "messageSubscriber"
+	EventPublisherKey      = "eventPublisher"
+	AggregateStoreKey      = "aggregateStore"
+	ApplicationKey         = "app"
+	DomainEventHandlersKey = "domainEventHandlers"
+
+	PollHandlersKey = "pollHandlers"
+	PollWatchersKey = "pollWatchers"
+
+	LunchPollsRepoKey = "lunchPollsRepo"
+	PollsRepoKey      = "pollsRepo"
+)
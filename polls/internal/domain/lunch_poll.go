@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/stackus/errors"
+)
+
+const (
+	LunchPollAggregate = "polls.LunchPoll"
+)
+
+const MinCandidates = 2
+
+var (
+	ErrTitleIsBlank        = errors.Wrap(errors.ErrBadRequest, "the poll title cannot be blank")
+	ErrUserIDIsBlank       = errors.Wrap(errors.ErrBadRequest, "the user id cannot be blank")
+	ErrNotEnoughCandidates = errors.Wrap(errors.ErrBadRequest, "a poll needs at least two candidate restaurants")
+	ErrDuplicateCandidate  = errors.Wrap(errors.ErrBadRequest, "a restaurant can only be a candidate once")
+	ErrDeadlineHasPassed   = errors.Wrap(errors.ErrBadRequest, "the poll deadline must be in the future")
+	ErrNotACandidate       = errors.Wrap(errors.ErrBadRequest, "the restaurant is not a candidate in this poll")
+	ErrPollAlreadyCreated  = errors.Wrap(errors.ErrAlreadyExists, "the poll has already been created")
+	ErrPollNotFound        = errors.Wrap(errors.ErrNotFound, "the poll does not exist")
+	ErrPollIsClosed        = errors.Wrap(errors.ErrFailedPrecondition, "the poll is closed")
+	ErrDeadlineNotReached  = errors.Wrap(errors.ErrFailedPrecondition, "the poll cannot be closed before its deadline")
+)
+
+type LunchPoll struct {
+	es.Aggregate
+	Title      string
+	CreatedBy  string
+	Candidates []string
+	Deadline   time.Time
+	// Votes maps each voter to the restaurant they voted for
+	Votes    map[string]string
+	Closed   bool
+	WinnerID string
+}
+
+var _ interface {
+	es.EventApplier
+	es.Snapshotter
+} = (*LunchPoll)(nil)
+
+func (p *LunchPoll) ApplyEvent(event ddd.Event) error {
+	switch payload := event.Payload().(type) {
+	case *LunchPollCreated:
+		p.Title = payload.Title
+		p.CreatedBy = payload.CreatedBy
+		p.Candidates = payload.Candidates
+		p.Deadline = payload.Deadline
+	case *VoteCast:
+		if p.Votes == nil {
+			p.Votes = make(map[string]string)
+		}
+		p.Votes[payload.UserID] = payload.RestaurantID
+	case *LunchPollClosed:
+		p.Closed = true
+		p.WinnerID = payload.WinnerID
+	default:
+		return errors.ErrInternal.Msgf("%T received the event %s with unexpected payload %T", p, event.EventName(), payload)
+	}
+
+	return nil
+}
+
+func (p *LunchPoll) ApplySnapshot(snapshot es.Snapshot) error {
+	switch ss := snapshot.(type) {
+	case *LunchPollV1:
+		p.Title = ss.Title
+		p.CreatedBy = ss.CreatedBy
+		p.Candidates = ss.Candidates
+		p.Deadline = ss.Deadline
+		p.Votes = ss.Votes
+		p.Closed = ss.Closed
+		p.WinnerID = ss.WinnerID
+	default:
+		return errors.ErrInternal.Msgf("%T received the unexpected snapshot %T", p, snapshot)
+	}
+
+	return nil
+}
+
+func (p *LunchPoll) ToSnapshot() es.Snapshot {
+	return LunchPollV1{
+		Title:      p.Title,
+		CreatedBy:  p.CreatedBy,
+		Candidates: p.Candidates,
+		Deadline:   p.Deadline,
+		Votes:      p.Votes,
+		Closed:     p.Closed,
+		WinnerID:   p.WinnerID,
+	}
+}
+
+func (p *LunchPoll) CreatePoll(title, createdBy string, candidates []string, deadline time.Time) (ddd.Event, error) {
+	if p.CreatedBy != "" {
+		return nil, ErrPollAlreadyCreated
+	}
+	if title == "" {
+		return nil, ErrTitleIsBlank
+	}
+	if createdBy == "" {
+		return nil, ErrUserIDIsBlank
+	}
+	if len(candidates) < MinCandidates {
+		return nil, ErrNotEnoughCandidates
+	}
+	seen := make(map[string]struct{}, len(candidates))
+	for _, candidate := range candidates {
+		if _, exists := seen[candidate]; exists {
+			return nil, ErrDuplicateCandidate
+		}
+		seen[candidate] = struct{}{}
+	}
+	if !deadline.After(time.Now()) {
+		return nil, ErrDeadlineHasPassed
+	}
+
+	p.AddEvent(LunchPollCreatedEvent, &LunchPollCreated{
+		Title:      title,
+		CreatedBy:  createdBy,
+		Candidates: candidates,
+		Deadline:   deadline,
+	})
+
+	return ddd.NewEvent(LunchPollCreatedEvent, p), nil
+}
+
+// CastVote records the user's vote; voting again replaces the earlier vote
+func (p *LunchPoll) CastVote(userID, restaurantID string) (ddd.Event, error) {
+	if p.CreatedBy == "" {
+		return nil, ErrPollNotFound
+	}
+	if p.Closed || !time.Now().Before(p.Deadline) {
+		return nil, ErrPollIsClosed
+	}
+	if userID == "" {
+		return nil, ErrUserIDIsBlank
+	}
+	if !p.isCandidate(restaurantID) {
+		return nil, ErrNotACandidate
+	}
+
+	p.AddEvent(VoteCastEvent, &VoteCast{
+		UserID:       userID,
+		RestaurantID: restaurantID,
+	})
+
+	return ddd.NewEvent(VoteCastEvent, p), nil
+}
+
+func (p *LunchPoll) ClosePoll() (ddd.Event, error) {
+	if p.CreatedBy == "" {
+		return nil, ErrPollNotFound
+	}
+	if p.Closed {
+		return nil, ErrPollIsClosed
+	}
+	if time.Now().Before(p.Deadline) {
+		return nil, ErrDeadlineNotReached
+	}
+
+	p.AddEvent(LunchPollClosedEvent, &LunchPollClosed{
+		WinnerID: p.winner(),
+	})
+
+	return ddd.NewEvent(LunchPollClosedEvent, p), nil
+}
+
+// Tallies returns the vote count for every candidate in candidate order
+func (p *LunchPoll) Tallies() []CandidateTally {
+	counts := make(map[string]int, len(p.Candidates))
+	for _, restaurantID := range p.Votes {
+		counts[restaurantID]++
+	}
+
+	tallies := make([]CandidateTally, 0, len(p.Candidates))
+	for _, candidate := range p.Candidates {
+		tallies = append(tallies, CandidateTally{
+			RestaurantID: candidate,
+			Votes:        counts[candidate],
+		})
+	}
+
+	return tallies
+}
+
+// winner picks the candidate with the most votes; ties go to the candidate
+// listed first and a poll without votes has no winner
+func (p *LunchPoll) winner() string {
+	winnerID, most := "", 0
+	for _, tally := range p.Tallies() {
+		if tally.Votes > most {
+			winnerID, most = tally.RestaurantID, tally.Votes
+		}
+	}
+
+	return winnerID
+}
+
+func (p *LunchPoll) isCandidate(restaurantID string) bool {
+	for _, candidate := range p.Candidates {
+		if candidate == restaurantID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (LunchPoll) Key() string {
+	return LunchPollAggregate
+}
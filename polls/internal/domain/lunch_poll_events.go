@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	LunchPollCreatedEvent = "polls.LunchPollCreated"
+	VoteCastEvent         = "polls.VoteCast"
+	LunchPollClosedEvent  = "polls.LunchPollClosed"
+)
+
+type LunchPollCreated struct {
+	Title      string
+	CreatedBy  string
+	Candidates []string
+	Deadline   time.Time
+}
+
+func (LunchPollCreated) Key() string { return LunchPollCreatedEvent }
+
+type VoteCast struct {
+	UserID       string
+	RestaurantID string
+}
+
+func (VoteCast) Key() string { return VoteCastEvent }
+
+type LunchPollClosed struct {
+	WinnerID string
+}
+
+func (LunchPollClosed) Key() string { return LunchPollClosedEvent }
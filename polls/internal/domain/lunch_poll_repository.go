@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"context"
+)
+
+type LunchPollRepository interface {
+	Load(ctx context.Context, pollID string) (*LunchPoll, error)
+	Save(ctx context.Context, poll *LunchPoll) error
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+)
+
+type LunchPollV1 struct {
+	Title      string
+	CreatedBy  string
+	Candidates []string
+	Deadline   time.Time
+	Votes      map[string]string
+	Closed     bool
+	WinnerID   string
+}
+
+func (LunchPollV1) SnapshotName() string { return "polls.LunchPollV1" }
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+type CandidateTally struct {
+	RestaurantID string
+	Votes        int
+}
+
+// Poll is the read model of a lunch poll along with its current tallies
+type Poll struct {
+	ID        string
+	Title     string
+	CreatedBy string
+	Deadline  time.Time
+	Closed    bool
+	WinnerID  string
+	Tallies   []CandidateTally
+}
+
+// Update copies the status and tallies from a live update onto the poll
+func (p *Poll) Update(update *Poll) {
+	p.Deadline = update.Deadline
+	p.Closed = update.Closed
+	p.WinnerID = update.WinnerID
+	p.Tallies = update.Tallies
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type PollRepository interface {
+	Add(ctx context.Context, pollID, title, createdBy string, candidates []string, deadline time.Time) error
+	SaveVote(ctx context.Context, pollID, userID, restaurantID string) error
+	Close(ctx context.Context, pollID, winnerID string) error
+	Find(ctx context.Context, pollID string) (*Poll, error)
+	// FindExpired returns the IDs of the open polls whose deadline has passed
+	FindExpired(ctx context.Context, now time.Time) ([]string, error)
+}
@@ -0,0 +1,9 @@
+package domain
+
+// PollWatchers fans poll updates out to the clients watching a poll live
+type PollWatchers interface {
+	// Watch returns a channel of updates for the poll; call stop once the
+	// updates are no longer wanted
+	Watch(pollID string) (updates <-chan *Poll, stop func())
+	Notify(poll *Poll)
+}
@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/internal/rpc"
+	"github.com/jongyunha/lunchbox/polls/internal/application"
+	"github.com/jongyunha/lunchbox/polls/internal/application/commands"
+	"github.com/jongyunha/lunchbox/polls/internal/application/queries"
+	"github.com/jongyunha/lunchbox/polls/internal/constants"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"github.com/jongyunha/lunchbox/polls/pollspb"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type server struct {
+	c        di.Container
+	watchers domain.PollWatchers
+	pollspb.UnimplementedPollsServiceServer
+}
+
+var _ pollspb.PollsServiceServer = (*server)(nil)
+
+func RegisterServer(c di.Container, registrar grpc.ServiceRegistrar, logger zerolog.Logger) error {
+	pollspb.RegisterPollsServiceServer(
+		rpc.NewTransactionalRegistrar(registrar, c, logger,
+			idempotency.UnaryServerInterceptor(constants.IdempotencyStoreKey,
+				pollspb.PollsService_CreatePoll_FullMethodName,
+				pollspb.PollsService_CastVote_FullMethodName,
+			),
+		),
+		server{
+			c:        c,
+			watchers: di.MustGet[domain.PollWatchers](c, constants.PollWatchersKey),
+		},
+	)
+
+	return nil
+}
+
+// app returns the application of the scope attached to the context
+func (s server) app(ctx context.Context) application.App {
+	return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey)
+}
+
+func (s server) CreatePoll(ctx context.Context, request *pollspb.CreatePollRequest) (*pollspb.CreatePollResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	pollID := uuid.New().String()
+
+	err = s.app(ctx).CreatePoll(ctx, commands.CreatePoll{
+		ID:            pollID,
+		Title:         request.GetTitle(),
+		UserID:        userID,
+		RestaurantIDs: request.GetRestaurantIds(),
+		Deadline:      request.GetDeadline().AsTime(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pollspb.CreatePollResponse{
+		Id: pollID,
+	}, nil
+}
+
+func (s server) CastVote(ctx context.Context, request *pollspb.CastVoteRequest) (*pollspb.CastVoteResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).CastVote(ctx, commands.CastVote{
+		ID:           request.GetId(),
+		UserID:       userID,
+		RestaurantID: request.GetRestaurantId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pollspb.CastVoteResponse{}, nil
+}
+
+func (s server) GetPoll(ctx context.Context, request *pollspb.GetPollRequest) (*pollspb.GetPollResponse, error) {
+	poll, err := s.app(ctx).GetPoll(ctx, queries.GetPoll{
+		ID: request.GetId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pollspb.GetPollResponse{
+		Poll: s.pollFromDomain(poll),
+	}, nil
+}
+
+// WatchPoll only holds a transaction while reading the current poll; the
+// stream itself may stay open until the poll closes
+func (s server) WatchPoll(request *pollspb.WatchPollRequest, stream pollspb.PollsService_WatchPollServer) error {
+	// start watching before reading the poll so no update can be missed
+	updates, stop := s.watchers.Watch(request.GetId())
+	defer stop()
+
+	var poll *domain.Poll
+	err := di.WithinScope(stream.Context(), s.c, func(ctx context.Context) (err error) {
+		poll, err = s.app(ctx).GetPoll(ctx, queries.GetPoll{
+			ID: request.GetId(),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sendPollUpdates(stream, poll, updates)
+}
+
+// sendPollUpdates sends the poll followed by each update until the poll is
+// closed or the client goes away
+func (s server) sendPollUpdates(stream pollspb.PollsService_WatchPollServer, poll *domain.Poll, updates <-chan *domain.Poll) error {
+	if err := stream.Send(s.pollFromDomain(poll)); err != nil {
+		return err
+	}
+
+	for !poll.Closed {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			poll.Update(update)
+			if err := stream.Send(s.pollFromDomain(poll)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s server) pollFromDomain(poll *domain.Poll) *pollspb.Poll {
+	tallies := make([]*pollspb.CandidateTally, 0, len(poll.Tallies))
+	for _, tally := range poll.Tallies {
+		tallies = append(tallies, &pollspb.CandidateTally{
+			RestaurantId: tally.RestaurantID,
+			Votes:        int32(tally.Votes),
+		})
+	}
+
+	return &pollspb.Poll{
+		Id:        poll.ID,
+		Title:     poll.Title,
+		CreatedBy: poll.CreatedBy,
+		Deadline:  timestamppb.New(poll.Deadline),
+		Closed:    poll.Closed,
+		WinnerId:  poll.WinnerID,
+		Tallies:   tallies,
+	}
+}
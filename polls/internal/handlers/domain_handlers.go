@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/polls/internal/constants"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"github.com/jongyunha/lunchbox/polls/pollspb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type domainHandlers[T ddd.Event] struct {
+	publisher am.EventPublisher
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*domainHandlers[ddd.Event])(nil)
+
+func NewDomainEventHandlers(publisher am.EventPublisher) ddd.EventHandler[ddd.Event] {
+	return &domainHandlers[ddd.Event]{
+		publisher: publisher,
+	}
+}
+
+func RegisterDomainEventHandlers(subscriber ddd.EventSubscriber[ddd.Event], handlers ddd.EventHandler[ddd.Event]) {
+	subscriber.Subscribe(handlers,
+		domain.LunchPollCreatedEvent,
+		domain.VoteCastEvent,
+		domain.LunchPollClosedEvent,
+	)
+}
+
+func RegisterDomainEventHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		domainHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.DomainEventHandlersKey)
+
+		return domainHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterDomainEventHandlers(subscriber, handlers)
+}
+
+func (d domainHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling domain event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled domain event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	span.AddEvent("Handling domain event", trace.WithAttributes(
+		attribute.String("Event", event.EventName()),
+	))
+
+	switch event.EventName() {
+	case domain.LunchPollCreatedEvent:
+		return d.onLunchPollCreated(ctx, event)
+	case domain.VoteCastEvent:
+		return d.onVoteCast(ctx, event)
+	case domain.LunchPollClosedEvent:
+		return d.onLunchPollClosed(ctx, event)
+	}
+
+	return nil
+}
+
+func (d domainHandlers[T]) onLunchPollCreated(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.LunchPoll)
+	return d.publisher.Publish(ctx, pollspb.LunchPollAggregateChannel, ddd.NewEvent(
+		pollspb.LunchPollCreatedEvent,
+		&pollspb.LunchPollCreated{
+			Id:        payload.ID(),
+			Title:     payload.Title,
+			CreatedBy: payload.CreatedBy,
+			Deadline:  timestamppb.New(payload.Deadline),
+			Tallies:   talliesToProto(payload.Tallies()),
+		},
+	))
+}
+
+func (d domainHandlers[T]) onVoteCast(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.LunchPoll)
+	return d.publisher.Publish(ctx, pollspb.LunchPollAggregateChannel, ddd.NewEvent(
+		pollspb.LunchPollVoteCastEvent,
+		&pollspb.LunchPollVoteCast{
+			Id:       payload.ID(),
+			Deadline: timestamppb.New(payload.Deadline),
+			Tallies:  talliesToProto(payload.Tallies()),
+		},
+	))
+}
+
+func (d domainHandlers[T]) onLunchPollClosed(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.LunchPoll)
+	return d.publisher.Publish(ctx, pollspb.LunchPollAggregateChannel, ddd.NewEvent(
+		pollspb.LunchPollClosedEvent,
+		&pollspb.LunchPollClosed{
+			Id:       payload.ID(),
+			WinnerId: payload.WinnerID,
+			Deadline: timestamppb.New(payload.Deadline),
+			Tallies:  talliesToProto(payload.Tallies()),
+		},
+	))
+}
+
+func talliesToProto(tallies []domain.CandidateTally) []*pollspb.CandidateTally {
+	protoTallies := make([]*pollspb.CandidateTally, 0, len(tallies))
+	for _, tally := range tallies {
+		protoTallies = append(protoTallies, &pollspb.CandidateTally{
+			RestaurantId: tally.RestaurantID,
+			Votes:        int32(tally.Votes),
+		})
+	}
+	return protoTallies
+}
+
+func talliesFromProto(protoTallies []*pollspb.CandidateTally) []domain.CandidateTally {
+	tallies := make([]domain.CandidateTally, 0, len(protoTallies))
+	for _, tally := range protoTallies {
+		tallies = append(tallies, domain.CandidateTally{
+			RestaurantID: tally.GetRestaurantId(),
+			Votes:        int(tally.GetVotes()),
+		})
+	}
+	return tallies
+}
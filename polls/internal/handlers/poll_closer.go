@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/polls/internal/application"
+	"github.com/jongyunha/lunchbox/polls/internal/application/commands"
+	"github.com/jongyunha/lunchbox/polls/internal/application/queries"
+	"github.com/jongyunha/lunchbox/polls/internal/constants"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+)
+
+// RunPollCloserTx closes the polls whose deadline has passed, checking every
+// interval until the context is cancelled
+//
+// Each poll is closed in its own transaction; when several instances race to
+// close the same poll only one of them will succeed.
+func RunPollCloserTx(ctx context.Context, container di.Container, interval time.Duration, logger zerolog.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			var pollIDs []string
+			err := di.WithinScope(ctx, container, func(ctx context.Context) (err error) {
+				pollIDs, err = di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey).ListExpiredPolls(ctx, queries.ListExpiredPolls{
+					Now: time.Now(),
+				})
+				return err
+			})
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to list the expired polls")
+				continue
+			}
+
+			for _, pollID := range pollIDs {
+				err = di.WithinScope(ctx, container, func(ctx context.Context) error {
+					return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey).ClosePoll(ctx, commands.ClosePoll{
+						ID: pollID,
+					})
+				})
+				if err != nil && !errors.Is(err, domain.ErrPollIsClosed) {
+					logger.Error().Err(err).Str("PollID", pollID).Msg("failed to close the poll")
+				}
+			}
+		}
+	}
+}
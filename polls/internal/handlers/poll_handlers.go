@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/polls/internal/constants"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type PollHandlers[T ddd.Event] struct {
+	polls domain.PollRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*PollHandlers[ddd.Event])(nil)
+
+func NewPollHandlers(polls domain.PollRepository) *PollHandlers[ddd.Event] {
+	return &PollHandlers[ddd.Event]{
+		polls: polls,
+	}
+}
+
+func (h PollHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling poll event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled poll event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.LunchPollCreatedEvent:
+		return h.onLunchPollCreated(ctx, event)
+	case domain.VoteCastEvent:
+		return h.onVoteCast(ctx, event)
+	case domain.LunchPollClosedEvent:
+		return h.onLunchPollClosed(ctx, event)
+	}
+	return nil
+}
+
+func (h PollHandlers[T]) onLunchPollCreated(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.LunchPoll)
+	return h.polls.Add(ctx, payload.ID(), payload.Title, payload.CreatedBy, payload.Candidates, payload.Deadline)
+}
+
+// onVoteCast upserts every vote; the payload does not say which vote changed
+// and polls are small enough for that not to matter
+func (h PollHandlers[T]) onVoteCast(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.LunchPoll)
+	for userID, restaurantID := range payload.Votes {
+		if err := h.polls.SaveVote(ctx, payload.ID(), userID, restaurantID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h PollHandlers[T]) onLunchPollClosed(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.LunchPoll)
+	return h.polls.Close(ctx, payload.ID(), payload.WinnerID)
+}
+
+func RegisterPollHandlers(pollHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(pollHandlers,
+		domain.LunchPollCreatedEvent,
+		domain.VoteCastEvent,
+		domain.LunchPollClosedEvent,
+	)
+}
+
+func RegisterPollHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		pollHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.PollHandlersKey)
+
+		return pollHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterPollHandlers(handlers, subscriber)
+}
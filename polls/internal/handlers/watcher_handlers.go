@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"github.com/jongyunha/lunchbox/polls/pollspb"
+)
+
+type watcherHandlers[T ddd.Event] struct {
+	watchers domain.PollWatchers
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*watcherHandlers[ddd.Event])(nil)
+
+func NewWatcherHandlers(watchers domain.PollWatchers) ddd.EventHandler[ddd.Event] {
+	return watcherHandlers[ddd.Event]{
+		watchers: watchers,
+	}
+}
+
+// RegisterWatcherHandlers subscribes without a group so that every instance
+// sees every poll change and can update the clients connected to it
+func RegisterWatcherHandlers(subscriber am.MessageSubscriber, reg registry.Registry, handlers ddd.EventHandler[ddd.Event]) (err error) {
+	_, err = subscriber.Subscribe(pollspb.LunchPollAggregateChannel, am.NewEventHandler(reg, handlers), am.MessageFilter{
+		pollspb.LunchPollVoteCastEvent,
+		pollspb.LunchPollClosedEvent,
+	}, am.AckTypeAuto)
+	return err
+}
+
+func (h watcherHandlers[T]) HandleEvent(ctx context.Context, event T) error {
+	switch payload := event.Payload().(type) {
+	case *pollspb.LunchPollVoteCast:
+		h.watchers.Notify(&domain.Poll{
+			ID:       payload.GetId(),
+			Deadline: payload.GetDeadline().AsTime(),
+			Tallies:  talliesFromProto(payload.GetTallies()),
+		})
+	case *pollspb.LunchPollClosed:
+		h.watchers.Notify(&domain.Poll{
+			ID:       payload.GetId(),
+			Deadline: payload.GetDeadline().AsTime(),
+			Closed:   true,
+			WinnerID: payload.GetWinnerId(),
+			Tallies:  talliesFromProto(payload.GetTallies()),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package live
+
+import (
+	"sync"
+
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+)
+
+// watcherBuffer is how many updates a slow watcher may fall behind before
+// updates are dropped for it; each update carries the full tally so dropping
+// intermediate updates loses nothing
+const watcherBuffer = 16
+
+type PollWatchers struct {
+	mu       sync.Mutex
+	watchers map[string]map[chan *domain.Poll]struct{}
+}
+
+var _ domain.PollWatchers = (*PollWatchers)(nil)
+
+func NewPollWatchers() *PollWatchers {
+	return &PollWatchers{
+		watchers: make(map[string]map[chan *domain.Poll]struct{}),
+	}
+}
+
+func (w *PollWatchers) Watch(pollID string) (<-chan *domain.Poll, func()) {
+	updates := make(chan *domain.Poll, watcherBuffer)
+
+	w.mu.Lock()
+	if _, exists := w.watchers[pollID]; !exists {
+		w.watchers[pollID] = make(map[chan *domain.Poll]struct{})
+	}
+	w.watchers[pollID][updates] = struct{}{}
+	w.mu.Unlock()
+
+	var once sync.Once
+	return updates, func() {
+		once.Do(func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			delete(w.watchers[pollID], updates)
+			if len(w.watchers[pollID]) == 0 {
+				delete(w.watchers, pollID)
+			}
+			close(updates)
+		})
+	}
+}
+
+func (w *PollWatchers) Notify(poll *domain.Poll) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for updates := range w.watchers[poll.ID] {
+		select {
+		case updates <- poll:
+		default:
+			// the watcher is not keeping up; drop the oldest update to make room
+			select {
+			case <-updates:
+			default:
+			}
+			select {
+			case updates <- poll:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+-- +goose Up
+CREATE TABLE polls.polls (
+  id         text        NOT NULL,
+  title      text        NOT NULL,
+  created_by text        NOT NULL,
+  candidates text[]      NOT NULL,
+  deadline   timestamptz NOT NULL,
+  closed     boolean     NOT NULL DEFAULT false,
+  winner_id  text        NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  updated_at timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX polls_open_deadline_idx ON polls.polls (deadline) WHERE closed = false;
+
+CREATE TRIGGER created_at_polls_trgr
+  BEFORE UPDATE
+  ON polls.polls
+  FOR EACH ROW EXECUTE PROCEDURE created_at_trigger();
+
+CREATE TRIGGER updated_at_polls_trgr
+  BEFORE UPDATE
+  ON polls.polls
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE polls.votes (
+  poll_id       text        NOT NULL,
+  user_id       text        NOT NULL,
+  restaurant_id text        NOT NULL,
+  updated_at    timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (poll_id, user_id)
+);
+
+CREATE TRIGGER updated_at_votes_trgr
+  BEFORE UPDATE
+  ON polls.votes
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE polls.events (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  event_id       text        NOT NULL,
+  event_name     text        NOT NULL,
+  event_data     bytea       NOT NULL,
+  occurred_at    timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name, stream_version)
+);
+
+CREATE TABLE polls.snapshots (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  snapshot_name  text        NOT NULL,
+  snapshot_data  bytea       NOT NULL,
+  updated_at     timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name)
+);
+
+CREATE TRIGGER updated_at_snapshots_trgr
+  BEFORE UPDATE
+  ON polls.snapshots
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE polls.outbox (
+  id           text        NOT NULL,
+  name         text        NOT NULL,
+  subject      text        NOT NULL,
+  data         bytea       NOT NULL,
+  metadata     bytea       NOT NULL,
+  sent_at      timestamptz NOT NULL,
+  published_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX polls_unpublished_idx ON polls.outbox (published_at) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE polls.outbox, polls.snapshots, polls.events, polls.votes, polls.polls;
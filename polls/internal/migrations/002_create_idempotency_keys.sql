@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE polls.idempotency_keys (
+  key          text        NOT NULL,
+  method       text        NOT NULL,
+  request_hash bytea       NOT NULL,
+  response     bytea,
+  created_at   timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (key)
+);
+
+-- +goose Down
+DROP TABLE polls.idempotency_keys;
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type PollRepository struct {
+	tableName      string
+	votesTableName string
+	db             postgres.DBTX
+}
+
+var _ domain.PollRepository = (*PollRepository)(nil)
+
+func NewPollRepository(tableName, votesTableName string, db postgres.DBTX) PollRepository {
+	return PollRepository{
+		tableName:      tableName,
+		votesTableName: votesTableName,
+		db:             db,
+	}
+}
+
+func (r PollRepository) Add(ctx context.Context, pollID, title, createdBy string, candidates []string, deadline time.Time) error {
+	const query = "INSERT INTO %s (id, title, created_by, candidates, deadline) VALUES ($1, $2, $3, $4, $5)"
+
+	_, err := r.db.Exec(ctx, r.table(query), pollID, title, createdBy, candidates, deadline)
+
+	return err
+}
+
+func (r PollRepository) SaveVote(ctx context.Context, pollID, userID, restaurantID string) error {
+	const query = `INSERT INTO %s (poll_id, user_id, restaurant_id) VALUES ($1, $2, $3)
+ON CONFLICT (poll_id, user_id) DO UPDATE SET restaurant_id = EXCLUDED.restaurant_id`
+
+	_, err := r.db.Exec(ctx, r.votesTable(query), pollID, userID, restaurantID)
+
+	return err
+}
+
+func (r PollRepository) Close(ctx context.Context, pollID, winnerID string) error {
+	const query = "UPDATE %s SET closed = true, winner_id = $2 WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), pollID, winnerID)
+
+	return err
+}
+
+func (r PollRepository) Find(ctx context.Context, pollID string) (*domain.Poll, error) {
+	const query = "SELECT id, title, created_by, candidates, deadline, closed, winner_id FROM %s WHERE id = $1 LIMIT 1"
+
+	poll := &domain.Poll{}
+	var candidates []string
+
+	err := r.db.QueryRow(ctx, r.table(query), pollID).Scan(
+		&poll.ID, &poll.Title, &poll.CreatedBy, &candidates, &poll.Deadline, &poll.Closed, &poll.WinnerID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPollNotFound
+		}
+		return nil, err
+	}
+
+	poll.Tallies, err = r.tallies(ctx, pollID, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return poll, nil
+}
+
+func (r PollRepository) FindExpired(ctx context.Context, now time.Time) ([]string, error) {
+	const query = "SELECT id FROM %s WHERE closed = false AND deadline <= $1"
+
+	rows, err := r.db.Query(ctx, r.table(query), now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pollIDs []string
+	for rows.Next() {
+		var pollID string
+		if err := rows.Scan(&pollID); err != nil {
+			return nil, err
+		}
+		pollIDs = append(pollIDs, pollID)
+	}
+
+	return pollIDs, rows.Err()
+}
+
+func (r PollRepository) tallies(ctx context.Context, pollID string, candidates []string) ([]domain.CandidateTally, error) {
+	const query = "SELECT restaurant_id, count(*) FROM %s WHERE poll_id = $1 GROUP BY restaurant_id"
+
+	rows, err := r.db.Query(ctx, r.votesTable(query), pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(candidates))
+	for rows.Next() {
+		var restaurantID string
+		var count int
+		if err := rows.Scan(&restaurantID, &count); err != nil {
+			return nil, err
+		}
+		counts[restaurantID] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tallies := make([]domain.CandidateTally, 0, len(candidates))
+	for _, candidate := range candidates {
+		tallies = append(tallies, domain.CandidateTally{
+			RestaurantID: candidate,
+			Votes:        counts[candidate],
+		})
+	}
+
+	return tallies, nil
+}
+
+func (r PollRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
+
+func (r PollRepository) votesTable(query string) string {
+	return fmt.Sprintf(query, r.votesTableName)
+}
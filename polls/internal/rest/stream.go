@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/polls/internal/application"
+	"github.com/jongyunha/lunchbox/polls/internal/application/queries"
+	"github.com/jongyunha/lunchbox/polls/internal/constants"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"github.com/jongyunha/lunchbox/polls/pollspb"
+	"github.com/stackus/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// keepAliveInterval keeps idle proxies from closing quiet event streams
+const keepAliveInterval = 15 * time.Second
+
+// RegisterPollStream serves the live poll tallies as server-sent events
+//
+// The current poll is sent as the first event followed by an event for every
+// change until the poll closes or the client disconnects.
+func RegisterPollStream(mux *chi.Mux, container di.Container, authenticator *auth.Authenticator) error {
+	const streamRoute = "/api/v1/polls/{id}/stream"
+
+	watchers := di.MustGet[domain.PollWatchers](container, constants.PollWatchersKey)
+
+	// the stream is held to the same policy as the WatchPoll RPC
+	mux.With(authenticator.Protect(pollspb.PollsService_WatchPoll_FullMethodName)).Get(streamRoute, func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		pollID := chi.URLParam(r, "id")
+
+		updates, stop := watchers.Watch(pollID)
+		defer stop()
+
+		poll, err := getPoll(r.Context(), container, pollID)
+		if err != nil {
+			http.Error(w, err.Error(), errors.HTTPCode(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		if err = writePollEvent(w, poll); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+
+		for !poll.Closed {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-keepAlive.C:
+				if _, err = fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				poll.Update(update)
+				if err = writePollEvent(w, poll); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	})
+
+	return nil
+}
+
+// getPoll only holds a transaction while reading the current poll
+func getPoll(ctx context.Context, container di.Container, pollID string) (poll *domain.Poll, err error) {
+	err = di.WithinScope(ctx, container, func(ctx context.Context) error {
+		poll, err = di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey).GetPoll(ctx, queries.GetPoll{
+			ID: pollID,
+		})
+		return err
+	})
+
+	return poll, err
+}
+
+func writePollEvent(w http.ResponseWriter, poll *domain.Poll) error {
+	tallies := make([]*pollspb.CandidateTally, 0, len(poll.Tallies))
+	for _, tally := range poll.Tallies {
+		tallies = append(tallies, &pollspb.CandidateTally{
+			RestaurantId: tally.RestaurantID,
+			Votes:        int32(tally.Votes),
+		})
+	}
+
+	data, err := protojson.Marshal(&pollspb.Poll{
+		Id:        poll.ID,
+		Title:     poll.Title,
+		CreatedBy: poll.CreatedBy,
+		Deadline:  timestamppb.New(poll.Deadline),
+		Closed:    poll.Closed,
+		WinnerId:  poll.WinnerID,
+		Tallies:   tallies,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: poll\ndata: %s\n\n", data)
+	return err
+}
@@ -0,0 +1,270 @@
+package polls
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/amotel"
+	"github.com/jongyunha/lunchbox/internal/amprom"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	pg "github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/postgresotel"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/jongyunha/lunchbox/internal/tm"
+	"github.com/jongyunha/lunchbox/polls/internal/application"
+	"github.com/jongyunha/lunchbox/polls/internal/constants"
+	"github.com/jongyunha/lunchbox/polls/internal/domain"
+	"github.com/jongyunha/lunchbox/polls/internal/grpc"
+	"github.com/jongyunha/lunchbox/polls/internal/handlers"
+	"github.com/jongyunha/lunchbox/polls/internal/live"
+	"github.com/jongyunha/lunchbox/polls/internal/migrations"
+	"github.com/jongyunha/lunchbox/polls/internal/postgres"
+	"github.com/jongyunha/lunchbox/polls/internal/rest"
+	"github.com/jongyunha/lunchbox/polls/pollspb"
+	"github.com/rs/zerolog"
+)
+
+// pollCloserInterval is how often polls are checked for a passed deadline
+const pollCloserInterval = 5 * time.Second
+
+type Module struct{}
+
+func (m *Module) Startup(ctx context.Context, svc system.Service) (err error) {
+	return Root(ctx, svc)
+}
+
+// Migrations returns the migrations of the module schema
+func (m *Module) Migrations() pg.Migrations {
+	return pg.Migrations{
+		Schema: constants.ServiceName,
+		FS:     migrations.FS,
+	}
+}
+
+// Registry returns a registry with everything the module serializes
+func (m *Module) Registry() (registry.Registry, error) {
+	return newRegistry()
+}
+
+// Projections returns the read models that can be rebuilt from the event store
+func (m *Module) Projections() []pg.Projection {
+	return []pg.Projection{{
+		Name:          "polls",
+		AggregateName: domain.LunchPollAggregate,
+		Tables: []string{
+			constants.ServiceName + ".polls",
+			constants.ServiceName + ".votes",
+		},
+		Handlers: func(db pg.DBTX) []ddd.EventHandler[ddd.Event] {
+			return []ddd.EventHandler[ddd.Event]{
+				handlers.NewPollHandlers(postgres.NewPollRepository(
+					constants.ServiceName+".polls",
+					constants.ServiceName+".votes",
+					db,
+				)),
+			}
+		},
+	}}
+}
+
+func Root(ctx context.Context, svc system.Service) (err error) {
+	container := di.New()
+
+	// setup Driven adapters
+	di.Register(container, di.Singleton, constants.RegistryKey, func(c di.Container) (registry.Registry, error) {
+		return newRegistry()
+	})
+
+	stream := svc.Stream()
+
+	di.Register(container, di.Singleton, constants.DomainDispatcherKey, func(c di.Container) (*ddd.EventDispatcher[ddd.Event], error) {
+		return ddd.NewEventDispatcher[ddd.Event](), nil
+	})
+
+	di.Register(container, di.Singleton, constants.MessageSubscriberKey, func(c di.Container) (am.MessageSubscriber, error) {
+		return am.NewMessageSubscriber(
+			stream,
+			amotel.OtelMessageContextExtractor(),
+			amprom.ReceivedMessagesCounter(constants.ServiceName),
+		), nil
+	})
+
+	di.Register(container, di.Singleton, constants.PollWatchersKey, func(c di.Container) (domain.PollWatchers, error) {
+		return live.NewPollWatchers(), nil
+	})
+
+	di.Register(container, di.Scoped, constants.DatabaseTransactionKey, func(c di.Container) (pgx.Tx, error) {
+		return svc.DB().Begin(context.Background())
+	}, di.Finalizer(pg.EndTransaction(svc.Logger())))
+	di.Register(container, di.Scoped, constants.IdempotencyStoreKey, func(c di.Container) (idempotency.Store, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewIdempotencyStore(constants.ServiceName+".idempotency_keys", tx), nil
+	})
+	sentCounter := amprom.SentMessagesCounter(constants.ServiceName)
+	di.Register(container, di.Scoped, constants.MessagePublisherKey, func(c di.Container) (am.MessagePublisher, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		outboxStore := pg.NewOutboxStore(constants.ServiceName+".outbox", tx)
+		return am.NewMessagePublisher(
+			stream,
+			amotel.OtelMessageContextInjector(),
+			sentCounter,
+			tm.OutboxPublisher(outboxStore),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.EventPublisherKey, func(c di.Container) (am.EventPublisher, error) {
+		return am.NewEventPublisher(
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[am.MessagePublisher](c, constants.MessagePublisherKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.AggregateStoreKey, func(c di.Container) (es.AggregateStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		reg := di.MustGet[registry.Registry](c, constants.RegistryKey)
+		return es.AggregateStoreWithMiddleware(
+			pg.NewEventStore(constants.ServiceName+".events", tx, reg),
+			pg.NewSnapshotStore(constants.ServiceName+".snapshots", tx, reg),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.LunchPollsRepoKey, func(c di.Container) (es.AggregateRepository[*domain.LunchPoll], error) {
+		return es.NewAggregateRepository[*domain.LunchPoll](
+			domain.LunchPollAggregate,
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[es.AggregateStore](c, constants.AggregateStoreKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.PollsRepoKey, func(c di.Container) (domain.PollRepository, error) {
+		return postgres.NewPollRepository(
+			constants.ServiceName+".polls",
+			constants.ServiceName+".votes",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.ApplicationKey, func(c di.Container) (application.App, error) {
+		return application.New(
+			di.MustGet[es.AggregateRepository[*domain.LunchPoll]](c, constants.LunchPollsRepoKey),
+			di.MustGet[domain.PollRepository](c, constants.PollsRepoKey),
+			di.MustGet[ddd.EventPublisher[ddd.Event]](c, constants.DomainDispatcherKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.PollHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewPollHandlers(di.MustGet[domain.PollRepository](c, constants.PollsRepoKey)), nil
+	})
+	di.Register(container, di.Scoped, constants.DomainEventHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewDomainEventHandlers(di.MustGet[am.EventPublisher](c, constants.EventPublisherKey)), nil
+	})
+
+	outboxProcessor := tm.NewOutboxProcessor(
+		stream,
+		pg.NewOutboxStore(constants.ServiceName+".outbox", svc.DB()),
+	)
+
+	if err = container.Validate(ctx); err != nil {
+		return err
+	}
+	svc.Waiter().Cleanup(func() {
+		_ = container.Close(context.Background())
+	})
+	svc.Health().Register(constants.ServiceName+".outbox", pg.OutboxBacklogCheck(
+		constants.ServiceName+".outbox", svc.DB(), svc.Config().Health.OutboxBacklog,
+	))
+
+	// setup Driver adapters
+	if err = grpc.RegisterServer(container, svc.RPC(), svc.Logger()); err != nil {
+		return err
+	}
+	if err = rest.RegisterGateway(ctx, svc.Mux(), svc.Config().Rpc.Address()); err != nil {
+		return err
+	}
+	if err = rest.RegisterPollStream(svc.Mux(), container, svc.Auth()); err != nil {
+		return err
+	}
+	if err = rest.RegisterSwagger(svc.Mux()); err != nil {
+		return err
+	}
+	handlers.RegisterPollHandlersTx(container)
+	handlers.RegisterDomainEventHandlersTx(container)
+	if err = handlers.RegisterWatcherHandlers(
+		di.MustGet[am.MessageSubscriber](container, constants.MessageSubscriberKey),
+		di.MustGet[registry.Registry](container, constants.RegistryKey),
+		handlers.NewWatcherHandlers(di.MustGet[domain.PollWatchers](container, constants.PollWatchersKey)),
+	); err != nil {
+		return err
+	}
+	startOutboxProcessor(ctx, outboxProcessor, svc.Logger())
+	startPollCloser(ctx, container, svc.Logger())
+	return nil
+}
+
+// newRegistry registers everything the module serializes
+func newRegistry() (reg registry.Registry, err error) {
+	reg = registry.New()
+	if err = registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = pollspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+func registrations(reg registry.Registry) (err error) {
+	serde := serdes.NewJsonSerde(reg)
+
+	// LunchPoll
+	if err = serde.Register(domain.LunchPoll{}, func(v any) error {
+		poll := v.(*domain.LunchPoll)
+		poll.Aggregate = es.NewAggregate("", domain.LunchPollAggregate)
+		poll.Votes = make(map[string]string)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// LunchPoll events
+	if err = serde.Register(domain.LunchPollCreated{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.VoteCast{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.LunchPollClosed{}); err != nil {
+		return
+	}
+
+	// LunchPoll snapshot
+	if err = serde.RegisterKey(domain.LunchPollV1{}.SnapshotName(), domain.LunchPollV1{}); err != nil {
+		return
+	}
+	return nil
+}
+
+func startOutboxProcessor(ctx context.Context, outboxProcessor tm.OutboxProcessor, logger zerolog.Logger) {
+	go func() {
+		err := outboxProcessor.Start(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msg("polls outbox processor encountered an error")
+		}
+	}()
+}
+
+func startPollCloser(ctx context.Context, container di.Container, logger zerolog.Logger) {
+	go func() {
+		err := handlers.RunPollCloserTx(ctx, container, pollCloserInterval, logger)
+		if err != nil {
+			logger.Error().Err(err).Msg("polls closer encountered an error")
+		}
+	}()
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pollspb/api.proto
+
+package pollspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PollsService_CreatePoll_FullMethodName = "/pollspb.PollsService/CreatePoll"
+	PollsService_CastVote_FullMethodName   = "/pollspb.PollsService/CastVote"
+	PollsService_GetPoll_FullMethodName    = "/pollspb.PollsService/GetPoll"
+	PollsService_WatchPoll_FullMethodName  = "/pollspb.PollsService/WatchPoll"
+)
+
+// PollsServiceClient is the client API for PollsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PollsServiceClient interface {
+	CreatePoll(ctx context.Context, in *CreatePollRequest, opts ...grpc.CallOption) (*CreatePollResponse, error)
+	CastVote(ctx context.Context, in *CastVoteRequest, opts ...grpc.CallOption) (*CastVoteResponse, error)
+	GetPoll(ctx context.Context, in *GetPollRequest, opts ...grpc.CallOption) (*GetPollResponse, error)
+	// WatchPoll sends the current tally followed by every change until the poll closes
+	WatchPoll(ctx context.Context, in *WatchPollRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Poll], error)
+}
+
+type pollsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPollsServiceClient(cc grpc.ClientConnInterface) PollsServiceClient {
+	return &pollsServiceClient{cc}
+}
+
+func (c *pollsServiceClient) CreatePoll(ctx context.Context, in *CreatePollRequest, opts ...grpc.CallOption) (*CreatePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePollResponse)
+	err := c.cc.Invoke(ctx, PollsService_CreatePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollsServiceClient) CastVote(ctx context.Context, in *CastVoteRequest, opts ...grpc.CallOption) (*CastVoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CastVoteResponse)
+	err := c.cc.Invoke(ctx, PollsService_CastVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollsServiceClient) GetPoll(ctx context.Context, in *GetPollRequest, opts ...grpc.CallOption) (*GetPollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPollResponse)
+	err := c.cc.Invoke(ctx, PollsService_GetPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollsServiceClient) WatchPoll(ctx context.Context, in *WatchPollRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Poll], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PollsService_ServiceDesc.Streams[0], PollsService_WatchPoll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPollRequest, Poll]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PollsService_WatchPollClient = grpc.ServerStreamingClient[Poll]
+
+// PollsServiceServer is the server API for PollsService service.
+// All implementations must embed UnimplementedPollsServiceServer
+// for forward compatibility.
+type PollsServiceServer interface {
+	CreatePoll(context.Context, *CreatePollRequest) (*CreatePollResponse, error)
+	CastVote(context.Context, *CastVoteRequest) (*CastVoteResponse, error)
+	GetPoll(context.Context, *GetPollRequest) (*GetPollResponse, error)
+	// WatchPoll sends the current tally followed by every change until the poll closes
+	WatchPoll(*WatchPollRequest, grpc.ServerStreamingServer[Poll]) error
+	mustEmbedUnimplementedPollsServiceServer()
+}
+
+// UnimplementedPollsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPollsServiceServer struct{}
+
+func (UnimplementedPollsServiceServer) CreatePoll(context.Context, *CreatePollRequest) (*CreatePollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePoll not implemented")
+}
+func (UnimplementedPollsServiceServer) CastVote(context.Context, *CastVoteRequest) (*CastVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CastVote not implemented")
+}
+func (UnimplementedPollsServiceServer) GetPoll(context.Context, *GetPollRequest) (*GetPollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoll not implemented")
+}
+func (UnimplementedPollsServiceServer) WatchPoll(*WatchPollRequest, grpc.ServerStreamingServer[Poll]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPoll not implemented")
+}
+func (UnimplementedPollsServiceServer) mustEmbedUnimplementedPollsServiceServer() {}
+func (UnimplementedPollsServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafePollsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PollsServiceServer will
+// result in compilation errors.
+type UnsafePollsServiceServer interface {
+	mustEmbedUnimplementedPollsServiceServer()
+}
+
+func RegisterPollsServiceServer(s grpc.ServiceRegistrar, srv PollsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPollsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PollsService_ServiceDesc, srv)
+}
+
+func _PollsService_CreatePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollsServiceServer).CreatePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollsService_CreatePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollsServiceServer).CreatePoll(ctx, req.(*CreatePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollsService_CastVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CastVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollsServiceServer).CastVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollsService_CastVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollsServiceServer).CastVote(ctx, req.(*CastVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollsService_GetPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollsServiceServer).GetPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollsService_GetPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollsServiceServer).GetPoll(ctx, req.(*GetPollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollsService_WatchPoll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPollRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PollsServiceServer).WatchPoll(m, &grpc.GenericServerStream[WatchPollRequest, Poll]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PollsService_WatchPollServer = grpc.ServerStreamingServer[Poll]
+
+// PollsService_ServiceDesc is the grpc.ServiceDesc for PollsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PollsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pollspb.PollsService",
+	HandlerType: (*PollsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePoll",
+			Handler:    _PollsService_CreatePoll_Handler,
+		},
+		{
+			MethodName: "CastVote",
+			Handler:    _PollsService_CastVote_Handler,
+		},
+		{
+			MethodName: "GetPoll",
+			Handler:    _PollsService_GetPoll_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPoll",
+			Handler:       _PollsService_WatchPoll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pollspb/api.proto",
+}
@@ -0,0 +1,35 @@
+package pollspb
+
+import (
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+)
+
+const (
+	LunchPollAggregateChannel = "lunchbox.polls.events.LunchPoll"
+
+	LunchPollCreatedEvent  = "pollsapi.LunchPollCreated"
+	LunchPollVoteCastEvent = "pollsapi.LunchPollVoteCast"
+	LunchPollClosedEvent   = "pollsapi.LunchPollClosed"
+)
+
+func Registrations(reg registry.Registry) error {
+	serde := serdes.NewProtoSerde(reg)
+
+	// LunchPoll events
+	if err := serde.Register(&LunchPollCreated{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&LunchPollVoteCast{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&LunchPollClosed{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (*LunchPollCreated) Key() string  { return LunchPollCreatedEvent }
+func (*LunchPollVoteCast) Key() string { return LunchPollVoteCastEvent }
+func (*LunchPollClosed) Key() string   { return LunchPollClosedEvent }
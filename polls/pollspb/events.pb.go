@@ -0,0 +1,394 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        (unknown)
+// source: pollspb/events.proto
+
+package pollspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CandidateTally struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Votes         int32                  `protobuf:"varint,2,opt,name=votes,proto3" json:"votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CandidateTally) Reset() {
+	*x = CandidateTally{}
+	mi := &file_pollspb_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CandidateTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateTally) ProtoMessage() {}
+
+func (x *CandidateTally) ProtoReflect() protoreflect.Message {
+	mi := &file_pollspb_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateTally.ProtoReflect.Descriptor instead.
+func (*CandidateTally) Descriptor() ([]byte, []int) {
+	return file_pollspb_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CandidateTally) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *CandidateTally) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+type LunchPollCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Tallies       []*CandidateTally      `protobuf:"bytes,5,rep,name=tallies,proto3" json:"tallies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LunchPollCreated) Reset() {
+	*x = LunchPollCreated{}
+	mi := &file_pollspb_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LunchPollCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LunchPollCreated) ProtoMessage() {}
+
+func (x *LunchPollCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_pollspb_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LunchPollCreated.ProtoReflect.Descriptor instead.
+func (*LunchPollCreated) Descriptor() ([]byte, []int) {
+	return file_pollspb_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LunchPollCreated) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LunchPollCreated) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LunchPollCreated) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *LunchPollCreated) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *LunchPollCreated) GetTallies() []*CandidateTally {
+	if x != nil {
+		return x.Tallies
+	}
+	return nil
+}
+
+// LunchPollVoteCast is published whenever a vote is cast or changed
+type LunchPollVoteCast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Tallies       []*CandidateTally      `protobuf:"bytes,3,rep,name=tallies,proto3" json:"tallies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LunchPollVoteCast) Reset() {
+	*x = LunchPollVoteCast{}
+	mi := &file_pollspb_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LunchPollVoteCast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LunchPollVoteCast) ProtoMessage() {}
+
+func (x *LunchPollVoteCast) ProtoReflect() protoreflect.Message {
+	mi := &file_pollspb_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LunchPollVoteCast.ProtoReflect.Descriptor instead.
+func (*LunchPollVoteCast) Descriptor() ([]byte, []int) {
+	return file_pollspb_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LunchPollVoteCast) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LunchPollVoteCast) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *LunchPollVoteCast) GetTallies() []*CandidateTally {
+	if x != nil {
+		return x.Tallies
+	}
+	return nil
+}
+
+type LunchPollClosed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WinnerId      string                 `protobuf:"bytes,2,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Tallies       []*CandidateTally      `protobuf:"bytes,4,rep,name=tallies,proto3" json:"tallies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LunchPollClosed) Reset() {
+	*x = LunchPollClosed{}
+	mi := &file_pollspb_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LunchPollClosed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LunchPollClosed) ProtoMessage() {}
+
+func (x *LunchPollClosed) ProtoReflect() protoreflect.Message {
+	mi := &file_pollspb_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LunchPollClosed.ProtoReflect.Descriptor instead.
+func (*LunchPollClosed) Descriptor() ([]byte, []int) {
+	return file_pollspb_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LunchPollClosed) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LunchPollClosed) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *LunchPollClosed) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *LunchPollClosed) GetTallies() []*CandidateTally {
+	if x != nil {
+		return x.Tallies
+	}
+	return nil
+}
+
+var File_pollspb_events_proto protoreflect.FileDescriptor
+
+var file_pollspb_events_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x70, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x4b, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6c,
+	0x6c, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x61,
+	0x75, 0x72, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x22, 0xc2, 0x01,
+	0x0a, 0x10, 0x4c, 0x75, 0x6e, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x31, 0x0a, 0x07, 0x74, 0x61, 0x6c, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x52, 0x07, 0x74, 0x61, 0x6c, 0x6c, 0x69,
+	0x65, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x11, 0x4c, 0x75, 0x6e, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c,
+	0x56, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x31, 0x0a, 0x07, 0x74, 0x61, 0x6c, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x52, 0x07, 0x74, 0x61, 0x6c, 0x6c,
+	0x69, 0x65, 0x73, 0x22, 0xa9, 0x01, 0x0a, 0x0f, 0x4c, 0x75, 0x6e, 0x63, 0x68, 0x50, 0x6f, 0x6c,
+	0x6c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x69, 0x6e, 0x6e,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x31, 0x0a, 0x07,
+	0x74, 0x61, 0x6c, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x52, 0x07, 0x74, 0x61, 0x6c, 0x6c, 0x69, 0x65, 0x73, 0x42,
+	0x8b, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x42,
+	0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x33,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x6f, 0x6e, 0x67, 0x79,
+	0x75, 0x6e, 0x68, 0x61, 0x2f, 0x6c, 0x75, 0x6e, 0x63, 0x68, 0x62, 0x6f, 0x78, 0x2f, 0x70, 0x6f,
+	0x6c, 0x6c, 0x73, 0x2f, 0x70, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x2f, 0x70, 0x6f, 0x6c, 0x6c,
+	0x73, 0x70, 0x62, 0xa2, 0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x07, 0x50, 0x6f, 0x6c, 0x6c,
+	0x73, 0x70, 0x62, 0xca, 0x02, 0x07, 0x50, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0xe2, 0x02, 0x13,
+	0x50, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x07, 0x50, 0x6f, 0x6c, 0x6c, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pollspb_events_proto_rawDescOnce sync.Once
+	file_pollspb_events_proto_rawDescData = file_pollspb_events_proto_rawDesc
+)
+
+func file_pollspb_events_proto_rawDescGZIP() []byte {
+	file_pollspb_events_proto_rawDescOnce.Do(func() {
+		file_pollspb_events_proto_rawDescData = protoimpl.X.CompressGZIP(file_pollspb_events_proto_rawDescData)
+	})
+	return file_pollspb_events_proto_rawDescData
+}
+
+var file_pollspb_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pollspb_events_proto_goTypes = []any{
+	(*CandidateTally)(nil),        // 0: pollspb.CandidateTally
+	(*LunchPollCreated)(nil),      // 1: pollspb.LunchPollCreated
+	(*LunchPollVoteCast)(nil),     // 2: pollspb.LunchPollVoteCast
+	(*LunchPollClosed)(nil),       // 3: pollspb.LunchPollClosed
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_pollspb_events_proto_depIdxs = []int32{
+	4, // 0: pollspb.LunchPollCreated.deadline:type_name -> google.protobuf.Timestamp
+	0, // 1: pollspb.LunchPollCreated.tallies:type_name -> pollspb.CandidateTally
+	4, // 2: pollspb.LunchPollVoteCast.deadline:type_name -> google.protobuf.Timestamp
+	0, // 3: pollspb.LunchPollVoteCast.tallies:type_name -> pollspb.CandidateTally
+	4, // 4: pollspb.LunchPollClosed.deadline:type_name -> google.protobuf.Timestamp
+	0, // 5: pollspb.LunchPollClosed.tallies:type_name -> pollspb.CandidateTally
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pollspb_events_proto_init() }
+func file_pollspb_events_proto_init() {
+	if File_pollspb_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pollspb_events_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pollspb_events_proto_goTypes,
+		DependencyIndexes: file_pollspb_events_proto_depIdxs,
+		MessageInfos:      file_pollspb_events_proto_msgTypes,
+	}.Build()
+	File_pollspb_events_proto = out.File
+	file_pollspb_events_proto_rawDesc = nil
+	file_pollspb_events_proto_goTypes = nil
+	file_pollspb_events_proto_depIdxs = nil
+}
@@ -0,0 +1,3 @@
+package recommendations
+
+//go:generate buf generate
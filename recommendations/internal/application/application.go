@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/recommendations/internal/application/queries"
+	"github.com/jongyunha/lunchbox/recommendations/internal/domain"
+)
+
+type (
+	App interface {
+		Queries
+	}
+
+	Queries interface {
+		RecommendRestaurants(ctx context.Context, query queries.RecommendRestaurants) ([]domain.Recommendation, error)
+	}
+
+	Application struct {
+		appQueries
+	}
+
+	appQueries struct {
+		queries.RecommendRestaurantsHandler
+	}
+)
+
+var _ App = (*Application)(nil)
+
+func New(
+	engine *domain.Engine,
+	restaurants domain.RestaurantRepository,
+	visits domain.VisitRepository,
+	avoidVisitedDays int,
+) *Application {
+	return &Application{
+		appQueries: appQueries{
+			RecommendRestaurantsHandler: queries.NewRecommendRestaurantsHandler(engine, restaurants, visits, avoidVisitedDays),
+		},
+	}
+}
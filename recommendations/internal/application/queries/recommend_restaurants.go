@@ -0,0 +1,65 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/recommendations/internal/domain"
+)
+
+type (
+	RecommendRestaurants struct {
+		domain.Criteria
+		// AvoidVisitedDays skips restaurants the user visited within this many
+		// days; zero uses the configured default and a negative value disables it
+		AvoidVisitedDays int
+	}
+
+	RecommendRestaurantsHandler struct {
+		engine           *domain.Engine
+		restaurants      domain.RestaurantRepository
+		visits           domain.VisitRepository
+		avoidVisitedDays int
+	}
+)
+
+func NewRecommendRestaurantsHandler(
+	engine *domain.Engine,
+	restaurants domain.RestaurantRepository,
+	visits domain.VisitRepository,
+	avoidVisitedDays int,
+) RecommendRestaurantsHandler {
+	return RecommendRestaurantsHandler{
+		engine:           engine,
+		restaurants:      restaurants,
+		visits:           visits,
+		avoidVisitedDays: avoidVisitedDays,
+	}
+}
+
+func (h RecommendRestaurantsHandler) RecommendRestaurants(ctx context.Context, query RecommendRestaurants) ([]domain.Recommendation, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	restaurants, err := h.restaurants.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	avoidVisitedDays := query.AvoidVisitedDays
+	if avoidVisitedDays == 0 {
+		avoidVisitedDays = h.avoidVisitedDays
+	}
+
+	var visited []string
+	if (query.UserID != "" || query.TeamID != "") && avoidVisitedDays > 0 {
+		since := time.Now().AddDate(0, 0, -avoidVisitedDays)
+		visited, err = h.visits.FindVisitedSince(ctx, query.UserID, query.TeamID, since)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h.engine.Recommend(query.Criteria, restaurants, visited), nil
+}
@@ -0,0 +1,23 @@
+package constants
+
+// ServiceName The name of this module/service
+const ServiceName = "recommendations"
+
+// GRPC Service Names
+const (
+	RecommendationsServiceName = "RECOMMENDATIONS"
+)
+
+// Dependency Injection Keys
+const (
+	RegistryKey                 = "registry"
+	DatabaseTransactionKey      = "tx"
+	MessageSubscriberKey        = "messageSubscriber"
+	ApplicationKey              = "app"
+	IntegrationEventHandlersKey = "integrationEventHandlers"
+
+	EngineKey          = "engine"
+	RestaurantsRepoKey = "restaurantsRepo"
+	VisitsRepoKey      = "visitsRepo"
+	InboxStoreKey      = "inboxStore"
+)
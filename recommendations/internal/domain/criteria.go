@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"github.com/stackus/errors"
+)
+
+var (
+	ErrInvalidLocation  = errors.Wrap(errors.ErrBadRequest, "the location is not a valid latitude and longitude")
+	ErrInvalidBudget    = errors.Wrap(errors.ErrBadRequest, "the budget cannot be negative")
+	ErrInvalidGroupSize = errors.Wrap(errors.ErrBadRequest, "the group size cannot be negative")
+)
+
+// Criteria describes the caller asking for a recommendation; zero values mean
+// the caller has no preference
+type Criteria struct {
+	UserID      string
+	TeamID      string
+	Location    Location
+	Budget      int
+	DietaryTags []string
+	GroupSize   int
+	Limit       int
+}
+
+func (c Criteria) Validate() error {
+	l := c.Location
+	if l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180 {
+		return ErrInvalidLocation
+	}
+	if c.Budget < 0 {
+		return ErrInvalidBudget
+	}
+	if c.GroupSize < 0 {
+		return ErrInvalidGroupSize
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"sort"
+)
+
+type Recommendation struct {
+	Restaurant *Restaurant
+	Score      float64
+	Reasons    []string
+	// Distance is in meters and is zero when either location is unknown
+	Distance float64
+}
+
+// Engine filters out restaurants that cannot satisfy the criteria and ranks
+// the rest by the weighted sum of the scorer results
+type Engine struct {
+	scorers []WeightedScorer
+}
+
+func NewEngine(scorers ...WeightedScorer) *Engine {
+	return &Engine{
+		scorers: scorers,
+	}
+}
+
+// Recommend ranks the restaurants, skipping any found in recentlyVisited
+func (e Engine) Recommend(criteria Criteria, restaurants []*Restaurant, recentlyVisited []string) []Recommendation {
+	visited := make(map[string]struct{}, len(recentlyVisited))
+	for _, restaurantID := range recentlyVisited {
+		visited[restaurantID] = struct{}{}
+	}
+
+	totalWeight := 0.0
+	for _, scorer := range e.scorers {
+		totalWeight += scorer.Weight
+	}
+
+	recommendations := make([]Recommendation, 0, len(restaurants))
+	for _, restaurant := range restaurants {
+		if _, exists := visited[restaurant.ID]; exists {
+			continue
+		}
+		if !restaurant.HasDietaryTags(criteria.DietaryTags) {
+			continue
+		}
+		// restaurants that have not told us their seating are given the benefit of the doubt
+		if criteria.GroupSize > 0 && restaurant.Seats > 0 && restaurant.Seats < criteria.GroupSize {
+			continue
+		}
+
+		recommendation := Recommendation{
+			Restaurant: restaurant,
+		}
+		for _, scorer := range e.scorers {
+			score, reason := scorer.Score(criteria, restaurant)
+			if totalWeight > 0 {
+				recommendation.Score += score * scorer.Weight / totalWeight
+			}
+			if reason != "" {
+				recommendation.Reasons = append(recommendation.Reasons, reason)
+			}
+		}
+		if !criteria.Location.IsZero() && !restaurant.Location.IsZero() {
+			recommendation.Distance = criteria.Location.DistanceTo(restaurant.Location)
+		}
+
+		recommendations = append(recommendations, recommendation)
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score == recommendations[j].Score {
+			return recommendations[i].Restaurant.Name < recommendations[j].Restaurant.Name
+		}
+		return recommendations[i].Score > recommendations[j].Score
+	})
+
+	if criteria.Limit > 0 && len(recommendations) > criteria.Limit {
+		recommendations = recommendations[:criteria.Limit]
+	}
+
+	return recommendations
+}
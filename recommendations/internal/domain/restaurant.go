@@ -0,0 +1,32 @@
+package domain
+
+// Restaurant is the local read model of a restaurant, built from the
+// restaurants and reviews integration events
+type Restaurant struct {
+	ID             string
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+	AverageRating  float64
+	RatingCount    int
+}
+
+// HasDietaryTags reports whether the restaurant serves every one of the tags
+func (r Restaurant) HasDietaryTags(tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, dietaryTag := range r.DietaryTags {
+			if dietaryTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
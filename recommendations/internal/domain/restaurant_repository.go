@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+)
+
+type RestaurantRepository interface {
+	Add(ctx context.Context, restaurant *Restaurant) error
+	UpdateRating(ctx context.Context, restaurantID string, average float64, count int) error
+	Remove(ctx context.Context, restaurantID string) error
+	FindAll(ctx context.Context) ([]*Restaurant, error)
+}
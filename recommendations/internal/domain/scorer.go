@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// Scorer rates how well a restaurant fits the criteria
+//
+// Scores are expected to be between 0 and 1. The reason explains the score to
+// the caller and may be left blank when there is nothing worth saying.
+type Scorer interface {
+	Name() string
+	Score(criteria Criteria, restaurant *Restaurant) (score float64, reason string)
+}
+
+type WeightedScorer struct {
+	Scorer
+	Weight float64
+}
+
+// RatingScorer favours restaurants with a high average rating; restaurants
+// without any ratings receive a neutral score
+type RatingScorer struct{}
+
+// DistanceScorer favours restaurants closer to the caller, falling to zero at
+// MaxDistance meters
+type DistanceScorer struct {
+	MaxDistance float64
+}
+
+// BudgetScorer favours restaurants that fit within the caller's budget
+type BudgetScorer struct{}
+
+var _ Scorer = (*RatingScorer)(nil)
+var _ Scorer = (*DistanceScorer)(nil)
+var _ Scorer = (*BudgetScorer)(nil)
+
+func (RatingScorer) Name() string { return "rating" }
+
+func (RatingScorer) Score(_ Criteria, restaurant *Restaurant) (float64, string) {
+	if restaurant.RatingCount == 0 {
+		return 0.5, "no ratings yet"
+	}
+
+	return restaurant.AverageRating / 5, fmt.Sprintf("rated %.1f from %d reviews", restaurant.AverageRating, restaurant.RatingCount)
+}
+
+func (DistanceScorer) Name() string { return "distance" }
+
+func (s DistanceScorer) Score(criteria Criteria, restaurant *Restaurant) (float64, string) {
+	if criteria.Location.IsZero() || restaurant.Location.IsZero() || s.MaxDistance <= 0 {
+		return 0.5, ""
+	}
+
+	distance := criteria.Location.DistanceTo(restaurant.Location)
+	if distance >= s.MaxDistance {
+		return 0, fmt.Sprintf("%.0fm away", distance)
+	}
+
+	return 1 - distance/s.MaxDistance, fmt.Sprintf("%.0fm away", distance)
+}
+
+func (BudgetScorer) Name() string { return "budget" }
+
+func (BudgetScorer) Score(criteria Criteria, restaurant *Restaurant) (float64, string) {
+	if criteria.Budget == 0 || restaurant.PricePerPerson == 0 {
+		return 0.5, ""
+	}
+
+	if restaurant.PricePerPerson > criteria.Budget {
+		over := float64(restaurant.PricePerPerson-criteria.Budget) / float64(criteria.Budget)
+		if over >= 1 {
+			return 0, "well over budget"
+		}
+		return (1 - over) / 2, "over budget"
+	}
+
+	return 1, "within budget"
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Visit is a single visit in the visit read model
+type Visit struct {
+	ID           string
+	RestaurantID string
+	UserID       string
+	TeamID       string
+	VisitedAt    time.Time
+}
+
+type VisitRepository interface {
+	Add(ctx context.Context, visit *Visit) error
+	Remove(ctx context.Context, visitID string) error
+	// MoveRestaurant moves all the visits of one restaurant over to another
+	MoveRestaurant(ctx context.Context, restaurantID, survivorID string) error
+	// FindVisitedSince returns the IDs of the restaurants the user or the team
+	// has visited at or after the given time; either id may be blank
+	FindVisitedSince(ctx context.Context, userID, teamID string, since time.Time) ([]string, error)
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/rpc"
+	"github.com/jongyunha/lunchbox/recommendations/internal/application"
+	"github.com/jongyunha/lunchbox/recommendations/internal/application/queries"
+	"github.com/jongyunha/lunchbox/recommendations/internal/constants"
+	"github.com/jongyunha/lunchbox/recommendations/internal/domain"
+	"github.com/jongyunha/lunchbox/recommendations/recommendationspb"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+type server struct {
+	c di.Container
+	recommendationspb.UnimplementedRecommendationsServiceServer
+}
+
+var _ recommendationspb.RecommendationsServiceServer = (*server)(nil)
+
+func RegisterServer(c di.Container, registrar grpc.ServiceRegistrar, logger zerolog.Logger) error {
+	recommendationspb.RegisterRecommendationsServiceServer(
+		rpc.NewTransactionalRegistrar(registrar, c, logger),
+		server{c: c},
+	)
+
+	return nil
+}
+
+// app returns the application of the scope attached to the context
+func (s server) app(ctx context.Context) application.App {
+	return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey)
+}
+
+func (s server) RecommendRestaurants(ctx context.Context, request *recommendationspb.RecommendRestaurantsRequest) (*recommendationspb.RecommendRestaurantsResponse, error) {
+	recommendations, err := s.app(ctx).RecommendRestaurants(ctx, queries.RecommendRestaurants{
+		Criteria: domain.Criteria{
+			UserID: request.GetUserId(),
+			TeamID: request.GetTeamId(),
+			Location: domain.Location{
+				Latitude:  request.GetLocation().GetLatitude(),
+				Longitude: request.GetLocation().GetLongitude(),
+			},
+			Budget:      int(request.GetBudget()),
+			DietaryTags: request.GetDietaryTags(),
+			GroupSize:   int(request.GetGroupSize()),
+			Limit:       int(request.GetLimit()),
+		},
+		AvoidVisitedDays: int(request.GetAvoidVisitedDays()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &recommendationspb.RecommendRestaurantsResponse{
+		Recommendations: make([]*recommendationspb.Recommendation, 0, len(recommendations)),
+	}
+	for _, recommendation := range recommendations {
+		resp.Recommendations = append(resp.Recommendations, s.recommendationFromDomain(recommendation))
+	}
+
+	return resp, nil
+}
+
+func (s server) recommendationFromDomain(recommendation domain.Recommendation) *recommendationspb.Recommendation {
+	return &recommendationspb.Recommendation{
+		RestaurantId:   recommendation.Restaurant.ID,
+		Name:           recommendation.Restaurant.Name,
+		Score:          recommendation.Score,
+		Reasons:        recommendation.Reasons,
+		DistanceMeters: recommendation.Distance,
+	}
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/recommendations/internal/constants"
+	"github.com/jongyunha/lunchbox/recommendations/internal/domain"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/jongyunha/lunchbox/reviews/reviewspb"
+	"github.com/jongyunha/lunchbox/visits/visitspb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type integrationHandlers[T ddd.Event] struct {
+	restaurants domain.RestaurantRepository
+	visits      domain.VisitRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*integrationHandlers[ddd.Event])(nil)
+
+func NewIntegrationEventHandlers(restaurants domain.RestaurantRepository, visits domain.VisitRepository) ddd.EventHandler[ddd.Event] {
+	return integrationHandlers[ddd.Event]{
+		restaurants: restaurants,
+		visits:      visits,
+	}
+}
+
+// RegisterIntegrationEventHandlers has four workers handle the restaurant
+// events, each restaurant's in order; the ratings and visits are not ordered,
+// so they keep to a single worker that handles them in the order they arrive
+func RegisterIntegrationEventHandlers(subscriber am.MessageSubscriber, handlers am.MessageHandler) (err error) {
+	_, err = subscriber.Subscribe(restaurantspb.RestaurantAggregateChannel, handlers, am.MessageFilter{
+		restaurantspb.RestaurantRegisteredEvent,
+		restaurantspb.RestaurantUpdatedEvent,
+		restaurantspb.RestaurantMergedEvent,
+	}, am.GroupName("recommendation-restaurants"), am.Ordered{}, am.PullConsumer{Workers: 4})
+	if err != nil {
+		return err
+	}
+
+	_, err = subscriber.Subscribe(reviewspb.RestaurantRatingChannel, handlers, am.MessageFilter{
+		reviewspb.RestaurantRatingChangedEvent,
+	}, am.GroupName("recommendation-ratings"), am.PullConsumer{})
+	if err != nil {
+		return err
+	}
+
+	_, err = subscriber.Subscribe(visitspb.VisitAggregateChannel, handlers, am.MessageFilter{
+		visitspb.VisitLoggedEvent,
+		visitspb.VisitRemovedEvent,
+	}, am.GroupName("recommendation-visits"), am.PullConsumer{})
+	return err
+}
+
+func RegisterIntegrationEventHandlersTx(container di.Container) error {
+	evtMsgHandler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		return di.WithinScope(ctx, container, func(ctx context.Context) error {
+			return di.MustGet[am.MessageHandler](di.FromContext(ctx), constants.IntegrationEventHandlersKey).HandleMessage(ctx, msg)
+		})
+	})
+
+	subscriber := di.MustGet[am.MessageSubscriber](container, constants.MessageSubscriberKey)
+
+	return RegisterIntegrationEventHandlers(subscriber, evtMsgHandler)
+}
+
+func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling integration event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled integration event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	span.AddEvent("Handling integration event", trace.WithAttributes(
+		attribute.String("Event", event.EventName()),
+	))
+
+	switch event.EventName() {
+	case restaurantspb.RestaurantRegisteredEvent:
+		return h.onRestaurantRegistered(ctx, event)
+	case restaurantspb.RestaurantUpdatedEvent:
+		return h.onRestaurantUpdated(ctx, event)
+	case restaurantspb.RestaurantMergedEvent:
+		return h.onRestaurantMerged(ctx, event)
+	case reviewspb.RestaurantRatingChangedEvent:
+		return h.onRestaurantRatingChanged(ctx, event)
+	case visitspb.VisitLoggedEvent:
+		return h.onVisitLogged(ctx, event)
+	case visitspb.VisitRemovedEvent:
+		return h.onVisitRemoved(ctx, event)
+	}
+
+	return nil
+}
+
+func (h integrationHandlers[T]) onRestaurantRegistered(ctx context.Context, event T) error {
+	payload := event.Payload().(*restaurantspb.RestaurantRegistered)
+	return h.restaurants.Add(ctx, &domain.Restaurant{
+		ID:   payload.GetId(),
+		Name: payload.GetName(),
+		Location: domain.Location{
+			Latitude:  payload.GetLatitude(),
+			Longitude: payload.GetLongitude(),
+		},
+		PricePerPerson: int(payload.GetPricePerPerson()),
+		DietaryTags:    payload.GetDietaryTags(),
+		Seats:          int(payload.GetSeats()),
+	})
+}
+
+// onRestaurantUpdated relies on Add replacing the details of known restaurants
+func (h integrationHandlers[T]) onRestaurantUpdated(ctx context.Context, event T) error {
+	payload := event.Payload().(*restaurantspb.RestaurantUpdated)
+	return h.restaurants.Add(ctx, &domain.Restaurant{
+		ID:   payload.GetId(),
+		Name: payload.GetName(),
+		Location: domain.Location{
+			Latitude:  payload.GetLatitude(),
+			Longitude: payload.GetLongitude(),
+		},
+		PricePerPerson: int(payload.GetPricePerPerson()),
+		DietaryTags:    payload.GetDietaryTags(),
+		Seats:          int(payload.GetSeats()),
+	})
+}
+
+// onRestaurantMerged moves the visits along with the restaurant; the visits
+// module does not publish the visits it moves for the same merge
+func (h integrationHandlers[T]) onRestaurantMerged(ctx context.Context, event T) error {
+	payload := event.Payload().(*restaurantspb.RestaurantMerged)
+
+	if err := h.visits.MoveRestaurant(ctx, payload.GetId(), payload.GetSurvivorId()); err != nil {
+		return err
+	}
+
+	return h.restaurants.Remove(ctx, payload.GetId())
+}
+
+func (h integrationHandlers[T]) onRestaurantRatingChanged(ctx context.Context, event T) error {
+	payload := event.Payload().(*reviewspb.RestaurantRatingChanged)
+	return h.restaurants.UpdateRating(ctx, payload.GetRestaurantId(), payload.GetAverage(), int(payload.GetCount()))
+}
+
+func (h integrationHandlers[T]) onVisitLogged(ctx context.Context, event T) error {
+	payload := event.Payload().(*visitspb.VisitLogged)
+	return h.visits.Add(ctx, &domain.Visit{
+		ID:           payload.GetId(),
+		RestaurantID: payload.GetRestaurantId(),
+		UserID:       payload.GetUserId(),
+		TeamID:       payload.GetTeamId(),
+		VisitedAt:    payload.GetVisitedAt().AsTime(),
+	})
+}
+
+func (h integrationHandlers[T]) onVisitRemoved(ctx context.Context, event T) error {
+	payload := event.Payload().(*visitspb.VisitRemoved)
+	return h.visits.Remove(ctx, payload.GetId())
+}
@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE recommendations.restaurants (
+  id               text             NOT NULL,
+  name             text             NOT NULL DEFAULT '',
+  latitude         double precision NOT NULL DEFAULT 0,
+  longitude        double precision NOT NULL DEFAULT 0,
+  price_per_person int              NOT NULL DEFAULT 0,
+  dietary_tags     text[]           NOT NULL DEFAULT '{}',
+  seats            int              NOT NULL DEFAULT 0,
+  average_rating   double precision NOT NULL DEFAULT 0,
+  rating_count     int              NOT NULL DEFAULT 0,
+  created_at       timestamptz      NOT NULL DEFAULT NOW(),
+  updated_at       timestamptz      NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+CREATE TRIGGER created_at_restaurants_trgr
+  BEFORE UPDATE
+  ON recommendations.restaurants
+  FOR EACH ROW EXECUTE PROCEDURE created_at_trigger();
+
+CREATE TRIGGER updated_at_restaurants_trgr
+  BEFORE UPDATE
+  ON recommendations.restaurants
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE recommendations.visits (
+  id            text        NOT NULL,
+  restaurant_id text        NOT NULL,
+  user_id       text        NOT NULL,
+  visited_at    timestamptz NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX visits_user_visited_at_idx ON recommendations.visits (user_id, visited_at);
+
+CREATE TABLE recommendations.inbox (
+  id          text        NOT NULL,
+  name        text        NOT NULL,
+  subject     text        NOT NULL,
+  data        bytea       NOT NULL,
+  metadata    bytea       NOT NULL,
+  sent_at     timestamptz NOT NULL,
+  received_at timestamptz NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- +goose Down
+DROP TABLE recommendations.inbox, recommendations.visits, recommendations.restaurants;
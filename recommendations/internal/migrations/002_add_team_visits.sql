@@ -0,0 +1,10 @@
+-- +goose Up
+-- the recommendations read model can now avoid places a team visited recently
+ALTER TABLE recommendations.visits ADD COLUMN team_id text NOT NULL DEFAULT '';
+
+CREATE INDEX visits_team_visited_at_idx ON recommendations.visits (team_id, visited_at) WHERE team_id <> '';
+
+-- +goose Down
+DROP INDEX recommendations.visits_team_visited_at_idx;
+
+ALTER TABLE recommendations.visits DROP COLUMN team_id;
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/recommendations/internal/domain"
+)
+
+type RestaurantRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.RestaurantRepository = (*RestaurantRepository)(nil)
+
+func NewRestaurantRepository(tableName string, db postgres.DBTX) RestaurantRepository {
+	return RestaurantRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r RestaurantRepository) Add(ctx context.Context, restaurant *domain.Restaurant) error {
+	const query = `INSERT INTO %s (id, name, latitude, longitude, price_per_person, dietary_tags, seats)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO UPDATE SET
+  name = EXCLUDED.name, latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude,
+  price_per_person = EXCLUDED.price_per_person, dietary_tags = EXCLUDED.dietary_tags, seats = EXCLUDED.seats`
+
+	_, err := r.db.Exec(ctx, r.table(query),
+		restaurant.ID, restaurant.Name, restaurant.Location.Latitude, restaurant.Location.Longitude,
+		restaurant.PricePerPerson, restaurant.DietaryTags, restaurant.Seats,
+	)
+
+	return err
+}
+
+// UpdateRating will create a placeholder row when the rating arrives before
+// the restaurant registration; Add fills in the rest
+func (r RestaurantRepository) UpdateRating(ctx context.Context, restaurantID string, average float64, count int) error {
+	const query = `INSERT INTO %s (id, average_rating, rating_count) VALUES ($1, $2, $3)
+ON CONFLICT (id) DO UPDATE SET average_rating = EXCLUDED.average_rating, rating_count = EXCLUDED.rating_count`
+
+	_, err := r.db.Exec(ctx, r.table(query), restaurantID, average, count)
+
+	return err
+}
+
+func (r RestaurantRepository) Remove(ctx context.Context, restaurantID string) error {
+	const query = "DELETE FROM %s WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), restaurantID)
+
+	return err
+}
+
+func (r RestaurantRepository) FindAll(ctx context.Context) ([]*domain.Restaurant, error) {
+	const query = `SELECT id, name, latitude, longitude, price_per_person, dietary_tags, seats, average_rating, rating_count
+FROM %s WHERE name <> ''`
+
+	rows, err := r.db.Query(ctx, r.table(query))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurants []*domain.Restaurant
+	for rows.Next() {
+		restaurant := &domain.Restaurant{}
+		err := rows.Scan(
+			&restaurant.ID, &restaurant.Name, &restaurant.Location.Latitude, &restaurant.Location.Longitude,
+			&restaurant.PricePerPerson, &restaurant.DietaryTags, &restaurant.Seats,
+			&restaurant.AverageRating, &restaurant.RatingCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		restaurants = append(restaurants, restaurant)
+	}
+
+	return restaurants, rows.Err()
+}
+
+func (r RestaurantRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
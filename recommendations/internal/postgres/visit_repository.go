@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/recommendations/internal/domain"
+)
+
+type VisitRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.VisitRepository = (*VisitRepository)(nil)
+
+func NewVisitRepository(tableName string, db postgres.DBTX) VisitRepository {
+	return VisitRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r VisitRepository) Add(ctx context.Context, visit *domain.Visit) error {
+	const query = `INSERT INTO %s (id, restaurant_id, user_id, team_id, visited_at) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (id) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, r.table(query), visit.ID, visit.RestaurantID, visit.UserID, visit.TeamID, visit.VisitedAt)
+
+	return err
+}
+
+func (r VisitRepository) Remove(ctx context.Context, visitID string) error {
+	const query = "DELETE FROM %s WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), visitID)
+
+	return err
+}
+
+func (r VisitRepository) MoveRestaurant(ctx context.Context, restaurantID, survivorID string) error {
+	const query = "UPDATE %s SET restaurant_id = $2 WHERE restaurant_id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), restaurantID, survivorID)
+
+	return err
+}
+
+func (r VisitRepository) FindVisitedSince(ctx context.Context, userID, teamID string, since time.Time) ([]string, error) {
+	const query = `SELECT DISTINCT restaurant_id FROM %s
+WHERE ((user_id = $1 AND $1 <> '') OR (team_id = $2 AND $2 <> '')) AND visited_at >= $3`
+
+	rows, err := r.db.Query(ctx, r.table(query), userID, teamID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurantIDs []string
+	for rows.Next() {
+		var restaurantID string
+		if err := rows.Scan(&restaurantID); err != nil {
+			return nil, err
+		}
+		restaurantIDs = append(restaurantIDs, restaurantID)
+	}
+
+	return restaurantIDs, rows.Err()
+}
+
+func (r VisitRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
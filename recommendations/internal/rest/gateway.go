@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/recommendations/recommendationspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func RegisterGateway(ctx context.Context, mux *chi.Mux, grpcAddr string) error {
+	const apiRoot = "/api/v1/recommendations"
+
+	gateway := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(auth.GatewayHeaderMatcher))
+	err := recommendationspb.RegisterRecommendationsServiceHandlerFromEndpoint(ctx, gateway, grpcAddr, []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	})
+	if err != nil {
+		return err
+	}
+
+	// mount the GRPC gateway
+	mux.Mount(apiRoot, gateway)
+
+	return nil
+}
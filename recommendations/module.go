@@ -0,0 +1,160 @@
+package recommendations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/amotel"
+	"github.com/jongyunha/lunchbox/internal/amprom"
+	"github.com/jongyunha/lunchbox/internal/di"
+	pg "github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/postgresotel"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/jongyunha/lunchbox/internal/tm"
+	"github.com/jongyunha/lunchbox/recommendations/internal/application"
+	"github.com/jongyunha/lunchbox/recommendations/internal/constants"
+	"github.com/jongyunha/lunchbox/recommendations/internal/domain"
+	"github.com/jongyunha/lunchbox/recommendations/internal/grpc"
+	"github.com/jongyunha/lunchbox/recommendations/internal/handlers"
+	"github.com/jongyunha/lunchbox/recommendations/internal/migrations"
+	"github.com/jongyunha/lunchbox/recommendations/internal/postgres"
+	"github.com/jongyunha/lunchbox/recommendations/internal/rest"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/jongyunha/lunchbox/reviews/reviewspb"
+	"github.com/jongyunha/lunchbox/visits/visitspb"
+)
+
+type Module struct{}
+
+func (m *Module) Startup(ctx context.Context, svc system.Service) (err error) {
+	return Root(ctx, svc)
+}
+
+// Migrations returns the migrations of the module schema
+func (m *Module) Migrations() pg.Migrations {
+	return pg.Migrations{
+		Schema: constants.ServiceName,
+		FS:     migrations.FS,
+	}
+}
+
+// Registry returns a registry with everything the module serializes
+func (m *Module) Registry() (registry.Registry, error) {
+	return newRegistry()
+}
+
+// Projections returns the read models that can be rebuilt from the event store
+func (m *Module) Projections() []pg.Projection {
+	return nil
+}
+
+func Root(ctx context.Context, svc system.Service) (err error) {
+	container := di.New()
+
+	// setup Driven adapters
+	di.Register(container, di.Singleton, constants.RegistryKey, func(c di.Container) (registry.Registry, error) {
+		return newRegistry()
+	})
+
+	stream := svc.Stream()
+
+	di.Register(container, di.Singleton, constants.MessageSubscriberKey, func(c di.Container) (am.MessageSubscriber, error) {
+		return am.NewMessageSubscriber(
+			stream,
+			amotel.OtelMessageContextExtractor(),
+			amprom.ReceivedMessagesCounter(constants.ServiceName),
+		), nil
+	})
+
+	di.Register(container, di.Singleton, constants.EngineKey, func(c di.Container) (*domain.Engine, error) {
+		cfg := svc.Config().Recommendations
+		return domain.NewEngine(
+			domain.WeightedScorer{Scorer: domain.RatingScorer{}, Weight: cfg.RatingWeight},
+			domain.WeightedScorer{Scorer: domain.DistanceScorer{MaxDistance: cfg.MaxDistance}, Weight: cfg.DistanceWeight},
+			domain.WeightedScorer{Scorer: domain.BudgetScorer{}, Weight: cfg.BudgetWeight},
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.DatabaseTransactionKey, func(c di.Container) (pgx.Tx, error) {
+		return svc.DB().Begin(context.Background())
+	}, di.Finalizer(pg.EndTransaction(svc.Logger())))
+
+	di.Register(container, di.Scoped, constants.InboxStoreKey, func(c di.Container) (tm.InboxStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewInboxStore(constants.ServiceName+".inbox", tx), nil
+	})
+
+	di.Register(container, di.Scoped, constants.RestaurantsRepoKey, func(c di.Container) (domain.RestaurantRepository, error) {
+		return postgres.NewRestaurantRepository(
+			constants.ServiceName+".restaurants",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.VisitsRepoKey, func(c di.Container) (domain.VisitRepository, error) {
+		return postgres.NewVisitRepository(
+			constants.ServiceName+".visits",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.ApplicationKey, func(c di.Container) (application.App, error) {
+		return application.New(
+			di.MustGet[*domain.Engine](c, constants.EngineKey),
+			di.MustGet[domain.RestaurantRepository](c, constants.RestaurantsRepoKey),
+			di.MustGet[domain.VisitRepository](c, constants.VisitsRepoKey),
+			svc.Config().Recommendations.AvoidVisitedDays,
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.IntegrationEventHandlersKey, func(c di.Container) (am.MessageHandler, error) {
+		return am.NewEventHandler(
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			handlers.NewIntegrationEventHandlers(
+				di.MustGet[domain.RestaurantRepository](c, constants.RestaurantsRepoKey),
+				di.MustGet[domain.VisitRepository](c, constants.VisitsRepoKey),
+			),
+			tm.InboxHandler(di.MustGet[tm.InboxStore](c, constants.InboxStoreKey)),
+		), nil
+	})
+
+	if err = container.Validate(ctx); err != nil {
+		return err
+	}
+	svc.Waiter().Cleanup(func() {
+		_ = container.Close(context.Background())
+	})
+
+	// setup Driver adapters
+	if err = grpc.RegisterServer(container, svc.RPC(), svc.Logger()); err != nil {
+		return err
+	}
+	if err = rest.RegisterGateway(ctx, svc.Mux(), svc.Config().Rpc.Address()); err != nil {
+		return err
+	}
+	if err = rest.RegisterSwagger(svc.Mux()); err != nil {
+		return err
+	}
+	if err = handlers.RegisterIntegrationEventHandlersTx(container); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newRegistry registers everything the module serializes
+func newRegistry() (reg registry.Registry, err error) {
+	reg = registry.New()
+	if err = restaurantspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = reviewspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = visitspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
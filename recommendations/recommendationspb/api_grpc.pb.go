@@ -0,0 +1,122 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: recommendationspb/api.proto
+
+package recommendationspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RecommendationsService_RecommendRestaurants_FullMethodName = "/recommendationspb.RecommendationsService/RecommendRestaurants"
+)
+
+// RecommendationsServiceClient is the client API for RecommendationsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RecommendationsServiceClient interface {
+	RecommendRestaurants(ctx context.Context, in *RecommendRestaurantsRequest, opts ...grpc.CallOption) (*RecommendRestaurantsResponse, error)
+}
+
+type recommendationsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecommendationsServiceClient(cc grpc.ClientConnInterface) RecommendationsServiceClient {
+	return &recommendationsServiceClient{cc}
+}
+
+func (c *recommendationsServiceClient) RecommendRestaurants(ctx context.Context, in *RecommendRestaurantsRequest, opts ...grpc.CallOption) (*RecommendRestaurantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendRestaurantsResponse)
+	err := c.cc.Invoke(ctx, RecommendationsService_RecommendRestaurants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecommendationsServiceServer is the server API for RecommendationsService service.
+// All implementations must embed UnimplementedRecommendationsServiceServer
+// for forward compatibility.
+type RecommendationsServiceServer interface {
+	RecommendRestaurants(context.Context, *RecommendRestaurantsRequest) (*RecommendRestaurantsResponse, error)
+	mustEmbedUnimplementedRecommendationsServiceServer()
+}
+
+// UnimplementedRecommendationsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecommendationsServiceServer struct{}
+
+func (UnimplementedRecommendationsServiceServer) RecommendRestaurants(context.Context, *RecommendRestaurantsRequest) (*RecommendRestaurantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendRestaurants not implemented")
+}
+func (UnimplementedRecommendationsServiceServer) mustEmbedUnimplementedRecommendationsServiceServer() {
+}
+func (UnimplementedRecommendationsServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRecommendationsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecommendationsServiceServer will
+// result in compilation errors.
+type UnsafeRecommendationsServiceServer interface {
+	mustEmbedUnimplementedRecommendationsServiceServer()
+}
+
+func RegisterRecommendationsServiceServer(s grpc.ServiceRegistrar, srv RecommendationsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRecommendationsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RecommendationsService_ServiceDesc, srv)
+}
+
+func _RecommendationsService_RecommendRestaurants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendRestaurantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationsServiceServer).RecommendRestaurants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationsService_RecommendRestaurants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationsServiceServer).RecommendRestaurants(ctx, req.(*RecommendRestaurantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecommendationsService_ServiceDesc is the grpc.ServiceDesc for RecommendationsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecommendationsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "recommendationspb.RecommendationsService",
+	HandlerType: (*RecommendationsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RecommendRestaurants",
+			Handler:    _RecommendationsService_RecommendRestaurants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "recommendationspb/api.proto",
+}
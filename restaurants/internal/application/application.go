@@ -5,6 +5,7 @@ import (
 
 	"github.com/jongyunha/lunchbox/internal/ddd"
 	"github.com/jongyunha/lunchbox/restaurants/internal/application/commands"
+	"github.com/jongyunha/lunchbox/restaurants/internal/application/queries"
 	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
 )
 
@@ -16,9 +17,17 @@ type (
 
 	Commands interface {
 		RegisterRestaurant(ctx context.Context, cmd commands.RegisterRestaurant) error
+		UpdateRestaurant(ctx context.Context, cmd commands.UpdateRestaurant) error
+		ImportRestaurant(ctx context.Context, cmd commands.ImportRestaurant) error
+		MergeRestaurants(ctx context.Context, cmd commands.MergeRestaurants) error
+		DismissDuplicate(ctx context.Context, cmd commands.DismissDuplicate) error
+		DetectDuplicates(ctx context.Context, cmd commands.DetectDuplicates) error
 	}
 
 	Queries interface {
+		ListRestaurants(ctx context.Context, query queries.ListRestaurants) ([]*domain.MallRestaurant, error)
+		GetRestaurantByExternalRef(ctx context.Context, query queries.GetRestaurantByExternalRef) (*domain.MallRestaurant, error)
+		ListDuplicateCandidates(ctx context.Context, query queries.ListDuplicateCandidates) ([]*domain.DuplicateCandidate, error)
 	}
 
 	Application struct {
@@ -28,9 +37,17 @@ type (
 
 	appCommands struct {
 		commands.RegisterRestaurantHandler
+		commands.UpdateRestaurantHandler
+		commands.ImportRestaurantHandler
+		commands.MergeRestaurantsHandler
+		commands.DismissDuplicateHandler
+		commands.DetectDuplicatesHandler
 	}
 
 	appQueries struct {
+		queries.ListRestaurantsHandler
+		queries.GetRestaurantByExternalRefHandler
+		queries.ListDuplicateCandidatesHandler
 	}
 )
 
@@ -38,11 +55,24 @@ var _ App = (*Application)(nil)
 
 func New(
 	restaurants domain.RestaurantRepository,
+	mall domain.MallRepository,
+	duplicates domain.DuplicateCandidateRepository,
+	detector domain.DuplicateDetector,
 	publisher ddd.EventPublisher[ddd.Event],
 ) *Application {
 	return &Application{
 		appCommands: appCommands{
 			RegisterRestaurantHandler: commands.NewRegisterRestaurantHandler(restaurants, publisher),
+			UpdateRestaurantHandler:   commands.NewUpdateRestaurantHandler(restaurants, publisher),
+			ImportRestaurantHandler:   commands.NewImportRestaurantHandler(restaurants, publisher),
+			MergeRestaurantsHandler:   commands.NewMergeRestaurantsHandler(restaurants, duplicates, publisher),
+			DismissDuplicateHandler:   commands.NewDismissDuplicateHandler(duplicates),
+			DetectDuplicatesHandler:   commands.NewDetectDuplicatesHandler(mall, duplicates, detector),
+		},
+		appQueries: appQueries{
+			ListRestaurantsHandler:            queries.NewListRestaurantsHandler(mall),
+			GetRestaurantByExternalRefHandler: queries.NewGetRestaurantByExternalRefHandler(mall),
+			ListDuplicateCandidatesHandler:    queries.NewListDuplicateCandidatesHandler(duplicates),
 		},
 	}
 }
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+type (
+	// DetectDuplicates compares every restaurant with every other one; new
+	// restaurants are already checked as they are registered so this is only
+	// needed after the detector settings change
+	DetectDuplicates struct{}
+
+	DetectDuplicatesHandler struct {
+		mall       domain.MallRepository
+		duplicates domain.DuplicateCandidateRepository
+		detector   domain.DuplicateDetector
+	}
+)
+
+func NewDetectDuplicatesHandler(mall domain.MallRepository, duplicates domain.DuplicateCandidateRepository, detector domain.DuplicateDetector) DetectDuplicatesHandler {
+	return DetectDuplicatesHandler{
+		mall:       mall,
+		duplicates: duplicates,
+		detector:   detector,
+	}
+}
+
+func (h DetectDuplicatesHandler) DetectDuplicates(ctx context.Context, _ DetectDuplicates) error {
+	restaurants, err := h.mall.FindAll(ctx, domain.SortByName)
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range h.detector.DetectAll(restaurants) {
+		if err = h.duplicates.Save(ctx, candidate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+type (
+	// DismissDuplicate records that the two restaurants are different places
+	// so the pair is not offered for review again
+	DismissDuplicate struct {
+		RestaurantID string
+		DuplicateID  string
+	}
+
+	DismissDuplicateHandler struct {
+		duplicates domain.DuplicateCandidateRepository
+	}
+)
+
+func NewDismissDuplicateHandler(duplicates domain.DuplicateCandidateRepository) DismissDuplicateHandler {
+	return DismissDuplicateHandler{
+		duplicates: duplicates,
+	}
+}
+
+func (h DismissDuplicateHandler) DismissDuplicate(ctx context.Context, cmd DismissDuplicate) error {
+	return h.duplicates.Resolve(ctx, cmd.RestaurantID, cmd.DuplicateID, domain.DuplicateDismissed)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+type (
+	ImportRestaurant struct {
+		// ID is the restaurant the reference was imported as before, or the ID
+		// to register a new restaurant with
+		ID             string
+		ExternalRef    string
+		Name           string
+		Location       domain.Location
+		PricePerPerson int
+		DietaryTags    []string
+		Seats          int
+	}
+
+	ImportRestaurantHandler struct {
+		restaurants domain.RestaurantRepository
+		publisher   ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewImportRestaurantHandler(restaurants domain.RestaurantRepository, publisher ddd.EventPublisher[ddd.Event]) ImportRestaurantHandler {
+	return ImportRestaurantHandler{
+		restaurants: restaurants,
+		publisher:   publisher,
+	}
+}
+
+func (h ImportRestaurantHandler) ImportRestaurant(ctx context.Context, cmd ImportRestaurant) error {
+	restaurant, err := h.restaurants.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := restaurant.ImportRestaurant(cmd.ExternalRef, cmd.Name, cmd.Location, cmd.PricePerPerson, cmd.DietaryTags, cmd.Seats)
+	if err != nil || event == nil {
+		return err
+	}
+
+	err = h.restaurants.Save(ctx, restaurant)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
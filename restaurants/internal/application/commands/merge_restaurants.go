@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type (
+	// MergeRestaurants retires MergedID as a duplicate of SurvivorID
+	MergeRestaurants struct {
+		SurvivorID string
+		MergedID   string
+	}
+
+	MergeRestaurantsHandler struct {
+		restaurants domain.RestaurantRepository
+		duplicates  domain.DuplicateCandidateRepository
+		publisher   ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewMergeRestaurantsHandler(restaurants domain.RestaurantRepository, duplicates domain.DuplicateCandidateRepository, publisher ddd.EventPublisher[ddd.Event]) MergeRestaurantsHandler {
+	return MergeRestaurantsHandler{
+		restaurants: restaurants,
+		duplicates:  duplicates,
+		publisher:   publisher,
+	}
+}
+
+func (h MergeRestaurantsHandler) MergeRestaurants(ctx context.Context, cmd MergeRestaurants) error {
+	survivor, err := h.restaurants.Load(ctx, cmd.SurvivorID)
+	if err != nil {
+		return err
+	}
+	if survivor.Name == "" {
+		return domain.ErrRestaurantNotFound
+	}
+	if survivor.MergedInto != "" {
+		return errors.Wrapf(domain.ErrRestaurantMerged, "the restaurant `%s` has been merged into `%s`", survivor.ID(), survivor.MergedInto)
+	}
+
+	restaurant, err := h.restaurants.Load(ctx, cmd.MergedID)
+	if err != nil {
+		return err
+	}
+
+	event, err := restaurant.MergeInto(survivor.ID())
+	if err != nil {
+		return err
+	}
+
+	// merges are not limited to detected pairs
+	err = h.duplicates.Resolve(ctx, survivor.ID(), restaurant.ID(), domain.DuplicateMerged)
+	if err != nil && !errors.Is(err, domain.ErrDuplicateCandidateNotFound) {
+		return err
+	}
+
+	err = h.restaurants.Save(ctx, restaurant)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
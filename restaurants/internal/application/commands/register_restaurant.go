@@ -9,8 +9,12 @@ import (
 
 type (
 	RegisterRestaurant struct {
-		ID   string
-		Name string
+		ID             string
+		Name           string
+		Location       domain.Location
+		PricePerPerson int
+		DietaryTags    []string
+		Seats          int
 	}
 
 	RegisterRestaurantHandler struct {
@@ -32,7 +36,7 @@ func (h RegisterRestaurantHandler) RegisterRestaurant(ctx context.Context, cmd R
 		return err
 	}
 
-	event, err := restaurant.InitRestaurant(cmd.ID, cmd.Name)
+	event, err := restaurant.InitRestaurant(cmd.ID, cmd.Name, cmd.Location, cmd.PricePerPerson, cmd.DietaryTags, cmd.Seats)
 	if err != nil {
 		return err
 	}
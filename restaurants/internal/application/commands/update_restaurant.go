@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+type (
+	UpdateRestaurant struct {
+		ID             string
+		Name           string
+		Location       domain.Location
+		PricePerPerson int
+		DietaryTags    []string
+		Seats          int
+	}
+
+	UpdateRestaurantHandler struct {
+		restaurants domain.RestaurantRepository
+		publisher   ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewUpdateRestaurantHandler(restaurants domain.RestaurantRepository, publisher ddd.EventPublisher[ddd.Event]) UpdateRestaurantHandler {
+	return UpdateRestaurantHandler{
+		restaurants: restaurants,
+		publisher:   publisher,
+	}
+}
+
+func (h UpdateRestaurantHandler) UpdateRestaurant(ctx context.Context, cmd UpdateRestaurant) error {
+	restaurant, err := h.restaurants.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := restaurant.UpdateRestaurant(cmd.Name, cmd.Location, cmd.PricePerPerson, cmd.DietaryTags, cmd.Seats)
+	if err != nil {
+		return err
+	}
+
+	err = h.restaurants.Save(ctx, restaurant)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+type (
+	GetRestaurantByExternalRef struct {
+		ExternalRef string
+	}
+
+	GetRestaurantByExternalRefHandler struct {
+		mall domain.MallRepository
+	}
+)
+
+func NewGetRestaurantByExternalRefHandler(mall domain.MallRepository) GetRestaurantByExternalRefHandler {
+	return GetRestaurantByExternalRefHandler{
+		mall: mall,
+	}
+}
+
+func (h GetRestaurantByExternalRefHandler) GetRestaurantByExternalRef(ctx context.Context, query GetRestaurantByExternalRef) (*domain.MallRestaurant, error) {
+	return h.mall.FindByExternalRef(ctx, query.ExternalRef)
+}
@@ -0,0 +1,34 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+const defaultDuplicateCandidateLimit = 50
+
+type (
+	ListDuplicateCandidates struct {
+		Limit int
+	}
+
+	ListDuplicateCandidatesHandler struct {
+		duplicates domain.DuplicateCandidateRepository
+	}
+)
+
+func NewListDuplicateCandidatesHandler(duplicates domain.DuplicateCandidateRepository) ListDuplicateCandidatesHandler {
+	return ListDuplicateCandidatesHandler{
+		duplicates: duplicates,
+	}
+}
+
+func (h ListDuplicateCandidatesHandler) ListDuplicateCandidates(ctx context.Context, query ListDuplicateCandidates) ([]*domain.DuplicateCandidate, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultDuplicateCandidateLimit
+	}
+
+	return h.duplicates.FindPending(ctx, limit)
+}
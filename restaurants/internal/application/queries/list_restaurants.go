@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+type (
+	ListRestaurants struct {
+		SortBy domain.RestaurantSortOrder
+	}
+
+	ListRestaurantsHandler struct {
+		mall domain.MallRepository
+	}
+)
+
+func NewListRestaurantsHandler(mall domain.MallRepository) ListRestaurantsHandler {
+	return ListRestaurantsHandler{
+		mall: mall,
+	}
+}
+
+func (h ListRestaurantsHandler) ListRestaurants(ctx context.Context, query ListRestaurants) ([]*domain.MallRestaurant, error) {
+	return h.mall.FindAll(ctx, query.SortBy)
+}
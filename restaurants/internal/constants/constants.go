@@ -13,6 +13,7 @@ const (
 	RegistryKey                 = "registry"
 	DomainDispatcherKey         = "domainDispatcher"
 	DatabaseTransactionKey      = "tx"
+	IdempotencyStoreKey         = "idempotencyStore"
 	MessagePublisherKey         = "messagePublisher"
 	MessageSubscriberKey        = "messageSubscriber"
 	EventPublisherKey           = "eventPublisher"
@@ -27,10 +28,12 @@ const (
 	CommandHandlersKey          = "commandHandlers"
 	ReplyHandlersKey            = "replyHandlers"
 
-	MallHandlersKey = "mallHandlers"
+	MallHandlersKey      = "mallHandlers"
+	DuplicateHandlersKey = "duplicateHandlers"
 
 	RestaurantsRepoKey = "restaurantsRepo"
 	MallRepoKey        = "mallRepo"
+	DuplicatesRepoKey  = "duplicatesRepo"
 	//StoresRepoKey   = "storesRepo"
 	//ProductsRepoKey = "productsRepo"
 	//CatalogRepoKey  = "catalogRepo"
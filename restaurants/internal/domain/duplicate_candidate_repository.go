@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/stackus/errors"
+)
+
+type DuplicateStatus string
+
+const (
+	DuplicatePending   DuplicateStatus = "pending"
+	DuplicateMerged    DuplicateStatus = "merged"
+	DuplicateDismissed DuplicateStatus = "dismissed"
+)
+
+var ErrDuplicateCandidateNotFound = errors.Wrap(errors.ErrNotFound, "the restaurants are not a duplicate candidate")
+
+// DuplicateCandidate is a pair of restaurants that look like the same place
+// waiting for someone to merge or dismiss them
+//
+// The pair is always stored with the lower ID first so it is only ever
+// detected once.
+type DuplicateCandidate struct {
+	RestaurantID   string
+	DuplicateID    string
+	Score          float64
+	NameSimilarity float64
+	// Distance is in meters; it is negative when either restaurant has no
+	// location
+	Distance   float64
+	Status     DuplicateStatus
+	DetectedAt time.Time
+}
+
+func NewDuplicateCandidate(restaurantID, duplicateID string) *DuplicateCandidate {
+	if duplicateID < restaurantID {
+		restaurantID, duplicateID = duplicateID, restaurantID
+	}
+
+	return &DuplicateCandidate{
+		RestaurantID: restaurantID,
+		DuplicateID:  duplicateID,
+		Status:       DuplicatePending,
+		DetectedAt:   time.Now(),
+	}
+}
+
+type DuplicateCandidateRepository interface {
+	// Save adds the candidate or updates its scores; the pair is not reopened
+	// when it was already merged or dismissed
+	Save(ctx context.Context, candidate *DuplicateCandidate) error
+	// Resolve sets the status of a pending pair
+	Resolve(ctx context.Context, restaurantID, duplicateID string, status DuplicateStatus) error
+	// RemovePending drops the pending pairs the restaurant is part of
+	RemovePending(ctx context.Context, restaurantID string) error
+	// FindPending returns the pending pairs with the highest scores first
+	FindPending(ctx context.Context, limit int) ([]*DuplicateCandidate, error)
+}
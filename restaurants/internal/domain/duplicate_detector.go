@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DuplicateDetector scores pairs of restaurants by how alike their names are
+// and how close together they are
+//
+// Names are compared with the Dice coefficient of their character bigrams
+// after dropping case, spaces and punctuation, so "김밥천국 역삼점" and
+// "김밥천국(역삼점)" are identical. Restaurants further apart than MaxDistance
+// are never duplicates, which keeps the branches of a chain apart.
+type DuplicateDetector struct {
+	// Threshold is the lowest score worth a review
+	Threshold float64
+	// MaxDistance is in meters
+	MaxDistance float64
+}
+
+const (
+	nameWeight     = 0.7
+	distanceWeight = 0.3
+	// unknownCloseness is used when either restaurant has no location so that
+	// only near identical names reach the threshold
+	unknownCloseness = 0.5
+)
+
+func NewDuplicateDetector(threshold, maxDistance float64) DuplicateDetector {
+	return DuplicateDetector{
+		Threshold:   threshold,
+		MaxDistance: maxDistance,
+	}
+}
+
+// Detect returns the candidates pairing the restaurant with the others
+func (d DuplicateDetector) Detect(restaurant *MallRestaurant, others []*MallRestaurant) []*DuplicateCandidate {
+	var candidates []*DuplicateCandidate
+	for _, other := range others {
+		if other.ID == restaurant.ID {
+			continue
+		}
+		if candidate := d.Compare(restaurant, other); candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+// DetectAll compares every pair of the restaurants
+func (d DuplicateDetector) DetectAll(restaurants []*MallRestaurant) []*DuplicateCandidate {
+	var candidates []*DuplicateCandidate
+	for i, restaurant := range restaurants {
+		candidates = append(candidates, d.Detect(restaurant, restaurants[i+1:])...)
+	}
+
+	return candidates
+}
+
+// Compare returns the pair as a candidate or nil when the score falls short
+func (d DuplicateDetector) Compare(a, b *MallRestaurant) *DuplicateCandidate {
+	similarity := NameSimilarity(a.Name, b.Name)
+
+	distance := -1.0
+	closeness := unknownCloseness
+	if !a.Location.IsZero() && !b.Location.IsZero() {
+		distance = a.Location.DistanceTo(b.Location)
+		if distance > d.MaxDistance {
+			return nil
+		}
+		closeness = 1 - distance/d.MaxDistance
+	}
+
+	score := nameWeight*similarity + distanceWeight*closeness
+	if score < d.Threshold {
+		return nil
+	}
+
+	candidate := NewDuplicateCandidate(a.ID, b.ID)
+	candidate.Score = score
+	candidate.NameSimilarity = similarity
+	candidate.Distance = distance
+
+	return candidate
+}
+
+// NameSimilarity is 1 for names that only differ in case, spacing and
+// punctuation and 0 for names without a bigram in common
+func NameSimilarity(a, b string) float64 {
+	a, b = normalizeName(a), normalizeName(b)
+	if a == b {
+		return 1
+	}
+
+	aBigrams, bBigrams := bigrams(a), bigrams(b)
+	total := len(aBigrams) + len(bBigrams)
+	if total == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(aBigrams))
+	for _, bigram := range aBigrams {
+		counts[bigram]++
+	}
+	shared := 0
+	for _, bigram := range bBigrams {
+		if counts[bigram] > 0 {
+			counts[bigram]--
+			shared++
+		}
+	}
+
+	return float64(2*shared) / float64(total)
+}
+
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func bigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return nil
+	}
+
+	pairs := make([]string, 0, len(runes)-1)
+	for i := 0; i < len(runes)-1; i++ {
+		pairs = append(pairs, string(runes[i:i+2]))
+	}
+	return pairs
+}
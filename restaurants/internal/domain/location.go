@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"math"
+
+	"github.com/stackus/errors"
+)
+
+const earthRadiusMeters = 6371000
+
+var (
+	ErrInvalidLocation = errors.Wrap(errors.ErrBadRequest, "the restaurant location is not a valid latitude and longitude")
+)
+
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (l Location) IsZero() bool {
+	return l.Latitude == 0 && l.Longitude == 0
+}
+
+// DistanceTo returns the great-circle distance in meters between the two
+// locations using the haversine formula
+func (l Location) DistanceTo(other Location) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := (other.Latitude - l.Latitude) * math.Pi / 180
+	dLng := (other.Longitude - l.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func (l Location) validate() error {
+	if l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180 {
+		return ErrInvalidLocation
+	}
+
+	return nil
+}
@@ -2,12 +2,35 @@ package domain
 
 import "context"
 
+type RestaurantSortOrder int
+
+const (
+	SortByName RestaurantSortOrder = iota
+	SortByRating
+)
+
 type MallRestaurant struct {
-	ID   string
-	Name string
+	ID             string
+	ExternalRef    string
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+	AverageRating  float64
+	RatingCount    int
+	MergedInto     string
 }
 
 type MallRepository interface {
-	RegisterRestaurant(ctx context.Context, restaurantID, name string) error
+	RegisterRestaurant(ctx context.Context, restaurant *MallRestaurant) error
+	UpdateRestaurant(ctx context.Context, restaurant *MallRestaurant) error
+	UpdateRating(ctx context.Context, restaurantID string, average float64, count int) error
+	MergeRestaurant(ctx context.Context, restaurantID, survivorID string) error
 	FindByID(ctx context.Context, restaurantID string) (*MallRestaurant, error)
+	// FindByExternalRef also matches restaurants whose ID is the reference,
+	// which is how restaurants without an external reference are exported
+	FindByExternalRef(ctx context.Context, externalRef string) (*MallRestaurant, error)
+	// FindAll leaves out the restaurants that have been merged into others
+	FindAll(ctx context.Context, sortBy RestaurantSortOrder) ([]*MallRestaurant, error)
 }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"slices"
+
 	"github.com/jongyunha/lunchbox/internal/ddd"
 	"github.com/jongyunha/lunchbox/internal/es"
 	"github.com/stackus/errors"
@@ -12,17 +14,49 @@ const (
 
 var (
 	ErrRestaurantNameIsBlank = errors.Wrap(errors.ErrBadRequest, "the restaurant name cannot be blank")
+	ErrInvalidPricePerPerson = errors.Wrap(errors.ErrBadRequest, "the price per person cannot be negative")
+	ErrInvalidSeats          = errors.Wrap(errors.ErrBadRequest, "the number of seats cannot be negative")
+	ErrRestaurantNotFound    = errors.Wrap(errors.ErrNotFound, "the restaurant does not exist")
+	ErrExternalRefIsBlank    = errors.Wrap(errors.ErrBadRequest, "the external reference cannot be blank")
+	ErrRestaurantMerged      = errors.Wrap(errors.ErrFailedPrecondition, "the restaurant has been merged into another restaurant")
+	ErrMergeIntoItself       = errors.Wrap(errors.ErrBadRequest, "a restaurant cannot be merged into itself")
 )
 
 type Restaurant struct {
 	es.Aggregate
-	Name string
+	ExternalRef    string
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+	// MergedInto is the restaurant that took this one's place when it was
+	// found to be a duplicate
+	MergedInto string
 }
 
+var _ interface {
+	es.EventApplier
+	es.Snapshotter
+} = (*Restaurant)(nil)
+
 func (r *Restaurant) ApplyEvent(event ddd.Event) error {
 	switch payload := event.Payload().(type) {
 	case *RestaurantRegistered:
+		r.ExternalRef = payload.ExternalRef
 		r.Name = payload.Name
+		r.Location = payload.Location
+		r.PricePerPerson = payload.PricePerPerson
+		r.DietaryTags = payload.DietaryTags
+		r.Seats = payload.Seats
+	case *RestaurantUpdated:
+		r.Name = payload.Name
+		r.Location = payload.Location
+		r.PricePerPerson = payload.PricePerPerson
+		r.DietaryTags = payload.DietaryTags
+		r.Seats = payload.Seats
+	case *RestaurantMerged:
+		r.MergedInto = payload.SurvivorID
 	default:
 		return errors.ErrInternal.Msgf("%T received the event %s with unexpected payload %T", r, event.EventName(), payload)
 	}
@@ -30,19 +64,159 @@ func (r *Restaurant) ApplyEvent(event ddd.Event) error {
 	return nil
 }
 
-func (r *Restaurant) InitRestaurant(id, name string) (ddd.Event, error) {
-	if name == "" {
-		return nil, ErrRestaurantNameIsBlank
+func (r *Restaurant) ApplySnapshot(snapshot es.Snapshot) error {
+	switch ss := snapshot.(type) {
+	case *RestaurantV1:
+		r.ExternalRef = ss.ExternalRef
+		r.Name = ss.Name
+		r.Location = ss.Location
+		r.PricePerPerson = ss.PricePerPerson
+		r.DietaryTags = ss.DietaryTags
+		r.Seats = ss.Seats
+		r.MergedInto = ss.MergedInto
+	default:
+		return errors.ErrInternal.Msgf("%T received the unexpected snapshot %T", r, snapshot)
+	}
+
+	return nil
+}
+
+func (r *Restaurant) ToSnapshot() es.Snapshot {
+	return RestaurantV1{
+		ExternalRef:    r.ExternalRef,
+		Name:           r.Name,
+		Location:       r.Location,
+		PricePerPerson: r.PricePerPerson,
+		DietaryTags:    r.DietaryTags,
+		Seats:          r.Seats,
+		MergedInto:     r.MergedInto,
+	}
+}
+
+func (r *Restaurant) InitRestaurant(id, name string, location Location, pricePerPerson int, dietaryTags []string, seats int) (ddd.Event, error) {
+	if err := validateDetails(name, location, pricePerPerson, seats); err != nil {
+		return nil, err
 	}
 	//restaurant := NewRestaurant(id)
 
 	r.AddEvent(RestaurantRegisteredEvent, &RestaurantRegistered{
-		Name: name,
+		Name:           name,
+		Location:       location,
+		PricePerPerson: pricePerPerson,
+		DietaryTags:    dietaryTags,
+		Seats:          seats,
 	})
 
 	return ddd.NewEvent(RestaurantRegisteredEvent, r), nil
 }
 
+// UpdateRestaurant replaces the details of a registered restaurant
+func (r *Restaurant) UpdateRestaurant(name string, location Location, pricePerPerson int, dietaryTags []string, seats int) (ddd.Event, error) {
+	if r.Name == "" {
+		return nil, ErrRestaurantNotFound
+	}
+	if r.MergedInto != "" {
+		return nil, ErrRestaurantMerged
+	}
+	if err := validateDetails(name, location, pricePerPerson, seats); err != nil {
+		return nil, err
+	}
+
+	r.AddEvent(RestaurantUpdatedEvent, &RestaurantUpdated{
+		Name:           name,
+		Location:       location,
+		PricePerPerson: pricePerPerson,
+		DietaryTags:    dietaryTags,
+		Seats:          seats,
+	})
+
+	return ddd.NewEvent(RestaurantUpdatedEvent, r), nil
+}
+
+// ImportRestaurant registers the restaurant the first time it is imported and
+// updates it on later imports; no event is returned when nothing has changed
+func (r *Restaurant) ImportRestaurant(externalRef, name string, location Location, pricePerPerson int, dietaryTags []string, seats int) (ddd.Event, error) {
+	if externalRef == "" {
+		return nil, ErrExternalRefIsBlank
+	}
+
+	if r.Name == "" {
+		if err := validateDetails(name, location, pricePerPerson, seats); err != nil {
+			return nil, err
+		}
+
+		r.AddEvent(RestaurantRegisteredEvent, &RestaurantRegistered{
+			ExternalRef:    externalRef,
+			Name:           name,
+			Location:       location,
+			PricePerPerson: pricePerPerson,
+			DietaryTags:    dietaryTags,
+			Seats:          seats,
+		})
+
+		return ddd.NewEvent(RestaurantRegisteredEvent, r), nil
+	}
+
+	if r.MergedInto != "" {
+		return nil, ErrRestaurantMerged
+	}
+	if r.hasDetails(name, location, pricePerPerson, dietaryTags, seats) {
+		return nil, nil
+	}
+
+	return r.UpdateRestaurant(name, location, pricePerPerson, dietaryTags, seats)
+}
+
+// MergeInto retires the restaurant as a duplicate of the survivor; everything
+// recorded against it is expected to move over to the survivor
+func (r *Restaurant) MergeInto(survivorID string) (ddd.Event, error) {
+	if r.Name == "" {
+		return nil, ErrRestaurantNotFound
+	}
+	if r.MergedInto != "" {
+		return nil, ErrRestaurantMerged
+	}
+	if survivorID == r.ID() {
+		return nil, ErrMergeIntoItself
+	}
+
+	r.AddEvent(RestaurantMergedEvent, &RestaurantMerged{
+		SurvivorID: survivorID,
+	})
+
+	return ddd.NewEvent(RestaurantMergedEvent, r), nil
+}
+
+func (r *Restaurant) hasDetails(name string, location Location, pricePerPerson int, dietaryTags []string, seats int) bool {
+	tags := slices.Clone(r.DietaryTags)
+	otherTags := slices.Clone(dietaryTags)
+	slices.Sort(tags)
+	slices.Sort(otherTags)
+
+	return r.Name == name &&
+		r.Location == location &&
+		r.PricePerPerson == pricePerPerson &&
+		r.Seats == seats &&
+		slices.Equal(tags, otherTags)
+}
+
+func validateDetails(name string, location Location, pricePerPerson int, seats int) error {
+	if name == "" {
+		return ErrRestaurantNameIsBlank
+	}
+	if err := location.validate(); err != nil {
+		return err
+	}
+	if pricePerPerson < 0 {
+		return ErrInvalidPricePerPerson
+	}
+	if seats < 0 {
+		return ErrInvalidSeats
+	}
+
+	return nil
+}
+
 func (Restaurant) Key() string {
 	return RestaurantAggregate
 }
@@ -2,10 +2,33 @@ package domain
 
 const (
 	RestaurantRegisteredEvent = "restaurant.RestaurantRegistered"
+	RestaurantUpdatedEvent    = "restaurant.RestaurantUpdated"
+	RestaurantMergedEvent     = "restaurant.RestaurantMerged"
 )
 
 type RestaurantRegistered struct {
-	Name string
+	ExternalRef    string
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
 }
 
 func (RestaurantRegistered) Key() string { return RestaurantRegisteredEvent }
+
+type RestaurantUpdated struct {
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+}
+
+func (RestaurantUpdated) Key() string { return RestaurantUpdatedEvent }
+
+type RestaurantMerged struct {
+	SurvivorID string
+}
+
+func (RestaurantMerged) Key() string { return RestaurantMergedEvent }
@@ -1,7 +1,13 @@
 package domain
 
 type RestaurantV1 struct {
-	Name string
+	ExternalRef    string
+	Name           string
+	Location       Location
+	PricePerPerson int
+	DietaryTags    []string
+	Seats          int
+	MergedInto     string
 }
 
 func (RestaurantV1) SnapshotName() string { return "restaurants.RestaurantV1" }
@@ -2,32 +2,69 @@ package grpc
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/internal/rpc"
 	"github.com/jongyunha/lunchbox/restaurants/internal/application"
 	"github.com/jongyunha/lunchbox/restaurants/internal/application/commands"
+	"github.com/jongyunha/lunchbox/restaurants/internal/application/queries"
+	"github.com/jongyunha/lunchbox/restaurants/internal/constants"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
 	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type server struct {
-	app application.App
+	c di.Container
 	restaurantspb.UnimplementedRestaurantsServiceServer
 }
 
 var _ restaurantspb.RestaurantsServiceServer = (*server)(nil)
 
-func RegisterServer(_ context.Context, app application.App, registrar grpc.ServiceRegistrar) error {
-	restaurantspb.RegisterRestaurantsServiceServer(registrar, server{app: app})
+// errDryRun rolls back records imported as a dry run
+var errDryRun = errors.Wrap(errors.ErrConflict, "dry run")
+
+func RegisterServer(c di.Container, registrar grpc.ServiceRegistrar, logger zerolog.Logger) error {
+	restaurantspb.RegisterRestaurantsServiceServer(
+		rpc.NewTransactionalRegistrar(registrar, c, logger,
+			idempotency.UnaryServerInterceptor(constants.IdempotencyStoreKey,
+				restaurantspb.RestaurantsService_RegisterRestaurant_FullMethodName,
+				restaurantspb.RestaurantsService_UpdateRestaurant_FullMethodName,
+				restaurantspb.RestaurantsService_MergeRestaurants_FullMethodName,
+				restaurantspb.RestaurantsService_DismissDuplicateCandidate_FullMethodName,
+				restaurantspb.RestaurantsService_DetectDuplicates_FullMethodName,
+			),
+		),
+		server{c: c},
+	)
+
 	return nil
 }
 
+// app returns the application of the scope attached to the context
+func (s server) app(ctx context.Context) application.App {
+	return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey)
+}
+
 func (s server) RegisterRestaurant(ctx context.Context, request *restaurantspb.RegisterRestaurantRequest) (*restaurantspb.RegisterRestaurantResponse, error) {
 	restaurantID := uuid.New().String()
 
-	err := s.app.RegisterRestaurant(ctx, commands.RegisterRestaurant{
+	err := s.app(ctx).RegisterRestaurant(ctx, commands.RegisterRestaurant{
 		ID:   restaurantID,
 		Name: request.GetName(),
+		Location: domain.Location{
+			Latitude:  request.GetLocation().GetLatitude(),
+			Longitude: request.GetLocation().GetLongitude(),
+		},
+		PricePerPerson: int(request.GetPricePerPerson()),
+		DietaryTags:    request.GetDietaryTags(),
+		Seats:          int(request.GetSeats()),
 	})
 	if err != nil {
 		return nil, err
@@ -37,3 +74,246 @@ func (s server) RegisterRestaurant(ctx context.Context, request *restaurantspb.R
 		Id: restaurantID,
 	}, nil
 }
+
+func (s server) UpdateRestaurant(ctx context.Context, request *restaurantspb.UpdateRestaurantRequest) (*restaurantspb.UpdateRestaurantResponse, error) {
+	err := s.app(ctx).UpdateRestaurant(ctx, commands.UpdateRestaurant{
+		ID:   request.GetId(),
+		Name: request.GetName(),
+		Location: domain.Location{
+			Latitude:  request.GetLocation().GetLatitude(),
+			Longitude: request.GetLocation().GetLongitude(),
+		},
+		PricePerPerson: int(request.GetPricePerPerson()),
+		DietaryTags:    request.GetDietaryTags(),
+		Seats:          int(request.GetSeats()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &restaurantspb.UpdateRestaurantResponse{}, nil
+}
+
+func (s server) ListRestaurants(ctx context.Context, request *restaurantspb.ListRestaurantsRequest) (*restaurantspb.ListRestaurantsResponse, error) {
+	sortBy := domain.SortByName
+	if request.GetSortBy() == restaurantspb.RestaurantSortOrder_RESTAURANT_SORT_ORDER_RATING {
+		sortBy = domain.SortByRating
+	}
+
+	restaurants, err := s.app(ctx).ListRestaurants(ctx, queries.ListRestaurants{
+		SortBy: sortBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &restaurantspb.ListRestaurantsResponse{
+		Restaurants: make([]*restaurantspb.Restaurant, len(restaurants)),
+	}
+	for i, restaurant := range restaurants {
+		resp.Restaurants[i] = s.restaurantFromDomain(restaurant)
+	}
+
+	return resp, nil
+}
+
+// ImportRestaurants imports each record in its own transaction so one bad
+// record does not undo the others; dry runs are rolled back after the record
+// has been checked
+func (s server) ImportRestaurants(stream restaurantspb.RestaurantsService_ImportRestaurantsServer) error {
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var resp *restaurantspb.ImportRestaurantsResponse
+		err = di.WithinScope(stream.Context(), s.c, func(ctx context.Context) (err error) {
+			resp, err = s.importRestaurant(ctx, request)
+			if err == nil && request.GetDryRun() {
+				return errDryRun
+			}
+			return err
+		})
+		if err != nil && err != errDryRun {
+			resp.Id, resp.Created, resp.Error = "", false, err.Error()
+		}
+
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// importRestaurant imports a single record; new references are registered
+// under a new ID and known references update the restaurant they came in as
+func (s server) importRestaurant(ctx context.Context, request *restaurantspb.ImportRestaurantsRequest) (*restaurantspb.ImportRestaurantsResponse, error) {
+	record := request.GetRestaurant()
+	resp := &restaurantspb.ImportRestaurantsResponse{
+		Row:     request.GetRow(),
+		Id:      uuid.New().String(),
+		Created: true,
+	}
+
+	if record.GetExternalRef() == "" {
+		return resp, domain.ErrExternalRefIsBlank
+	}
+
+	restaurant, err := s.app(ctx).GetRestaurantByExternalRef(ctx, queries.GetRestaurantByExternalRef{
+		ExternalRef: record.GetExternalRef(),
+	})
+	switch {
+	case err == nil && restaurant.MergedInto != "":
+		// the reference now belongs to the restaurant it was merged into
+		resp.Id, resp.Created = restaurant.MergedInto, false
+	case err == nil:
+		resp.Id, resp.Created = restaurant.ID, false
+	case !errors.Is(err, errors.ErrNotFound):
+		return resp, err
+	}
+
+	err = s.app(ctx).ImportRestaurant(ctx, commands.ImportRestaurant{
+		ID:          resp.GetId(),
+		ExternalRef: record.GetExternalRef(),
+		Name:        record.GetName(),
+		Location: domain.Location{
+			Latitude:  record.GetLocation().GetLatitude(),
+			Longitude: record.GetLocation().GetLongitude(),
+		},
+		PricePerPerson: int(record.GetPricePerPerson()),
+		DietaryTags:    record.GetDietaryTags(),
+		Seats:          int(record.GetSeats()),
+	})
+
+	return resp, err
+}
+
+func (s server) MergeRestaurants(ctx context.Context, request *restaurantspb.MergeRestaurantsRequest) (*restaurantspb.MergeRestaurantsResponse, error) {
+	err := s.app(ctx).MergeRestaurants(ctx, commands.MergeRestaurants{
+		SurvivorID: request.GetSurvivorId(),
+		MergedID:   request.GetMergedId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &restaurantspb.MergeRestaurantsResponse{}, nil
+}
+
+func (s server) ListDuplicateCandidates(ctx context.Context, request *restaurantspb.ListDuplicateCandidatesRequest) (*restaurantspb.ListDuplicateCandidatesResponse, error) {
+	candidates, err := s.app(ctx).ListDuplicateCandidates(ctx, queries.ListDuplicateCandidates{
+		Limit: int(request.GetLimit()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &restaurantspb.ListDuplicateCandidatesResponse{
+		Candidates: make([]*restaurantspb.DuplicateCandidate, len(candidates)),
+	}
+	for i, candidate := range candidates {
+		resp.Candidates[i] = s.duplicateCandidateFromDomain(candidate)
+	}
+
+	return resp, nil
+}
+
+func (s server) DismissDuplicateCandidate(ctx context.Context, request *restaurantspb.DismissDuplicateCandidateRequest) (*restaurantspb.DismissDuplicateCandidateResponse, error) {
+	err := s.app(ctx).DismissDuplicate(ctx, commands.DismissDuplicate{
+		RestaurantID: request.GetRestaurantId(),
+		DuplicateID:  request.GetDuplicateId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &restaurantspb.DismissDuplicateCandidateResponse{}, nil
+}
+
+func (s server) DetectDuplicates(ctx context.Context, _ *restaurantspb.DetectDuplicatesRequest) (*restaurantspb.DetectDuplicatesResponse, error) {
+	if err := s.app(ctx).DetectDuplicates(ctx, commands.DetectDuplicates{}); err != nil {
+		return nil, err
+	}
+
+	return &restaurantspb.DetectDuplicatesResponse{}, nil
+}
+
+// ExportRestaurants only holds a transaction while reading the restaurants
+func (s server) ExportRestaurants(_ *restaurantspb.ExportRestaurantsRequest, stream restaurantspb.RestaurantsService_ExportRestaurantsServer) error {
+	var restaurants []*domain.MallRestaurant
+	err := di.WithinScope(stream.Context(), s.c, func(ctx context.Context) (err error) {
+		restaurants, err = s.app(ctx).ListRestaurants(ctx, queries.ListRestaurants{
+			SortBy: domain.SortByName,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sendRestaurantRecords(stream, restaurants)
+}
+
+func (s server) sendRestaurantRecords(stream restaurantspb.RestaurantsService_ExportRestaurantsServer, restaurants []*domain.MallRestaurant) error {
+	for _, restaurant := range restaurants {
+		err := stream.Send(&restaurantspb.ExportRestaurantsResponse{
+			Restaurant: s.recordFromDomain(restaurant),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordFromDomain exports restaurants that were not imported with their ID
+// as the reference so importing the export again updates them in place
+func (s server) recordFromDomain(restaurant *domain.MallRestaurant) *restaurantspb.RestaurantRecord {
+	externalRef := restaurant.ExternalRef
+	if externalRef == "" {
+		externalRef = restaurant.ID
+	}
+
+	return &restaurantspb.RestaurantRecord{
+		ExternalRef: externalRef,
+		Name:        restaurant.Name,
+		Location: &restaurantspb.Location{
+			Latitude:  restaurant.Location.Latitude,
+			Longitude: restaurant.Location.Longitude,
+		},
+		PricePerPerson: int64(restaurant.PricePerPerson),
+		DietaryTags:    restaurant.DietaryTags,
+		Seats:          int32(restaurant.Seats),
+	}
+}
+
+func (s server) restaurantFromDomain(restaurant *domain.MallRestaurant) *restaurantspb.Restaurant {
+	return &restaurantspb.Restaurant{
+		Id:            restaurant.ID,
+		Name:          restaurant.Name,
+		AverageRating: restaurant.AverageRating,
+		RatingCount:   int32(restaurant.RatingCount),
+		Location: &restaurantspb.Location{
+			Latitude:  restaurant.Location.Latitude,
+			Longitude: restaurant.Location.Longitude,
+		},
+		PricePerPerson: int64(restaurant.PricePerPerson),
+		DietaryTags:    restaurant.DietaryTags,
+		Seats:          int32(restaurant.Seats),
+	}
+}
+
+func (s server) duplicateCandidateFromDomain(candidate *domain.DuplicateCandidate) *restaurantspb.DuplicateCandidate {
+	return &restaurantspb.DuplicateCandidate{
+		RestaurantId:   candidate.RestaurantID,
+		DuplicateId:    candidate.DuplicateID,
+		Score:          candidate.Score,
+		NameSimilarity: candidate.NameSimilarity,
+		Distance:       candidate.Distance,
+		DetectedAt:     timestamppb.New(candidate.DetectedAt),
+	}
+}
@@ -30,17 +30,19 @@ func NewDomainEventHandlers(publisher am.EventPublisher) ddd.EventHandler[ddd.Ev
 func RegisterDomainEventHandlers(subscriber ddd.EventSubscriber[ddd.Event], handlers ddd.EventHandler[ddd.Event]) {
 	subscriber.Subscribe(handlers,
 		domain.RestaurantRegisteredEvent,
+		domain.RestaurantUpdatedEvent,
+		domain.RestaurantMergedEvent,
 	)
 }
 
 func RegisterDomainEventHandlersTx(container di.Container) {
 	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
-		domainHandlers := di.Get(ctx, constants.DomainEventHandlersKey).(ddd.EventHandler[ddd.Event])
+		domainHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.DomainEventHandlersKey)
 
 		return domainHandlers.HandleEvent(ctx, event)
 	})
 
-	subscriber := container.Get(constants.DomainDispatcherKey).(*ddd.EventDispatcher[ddd.Event])
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
 	RegisterDomainEventHandlers(subscriber, handlers)
 }
 
@@ -65,6 +67,10 @@ func (d domainHandlers[T]) HandleEvent(ctx context.Context, event T) (err error)
 	switch event.EventName() {
 	case domain.RestaurantRegisteredEvent:
 		return d.onRestaurantRegistered(ctx, event)
+	case domain.RestaurantUpdatedEvent:
+		return d.onRestaurantUpdated(ctx, event)
+	case domain.RestaurantMergedEvent:
+		return d.onRestaurantMerged(ctx, event)
 	}
 	return nil
 }
@@ -74,8 +80,43 @@ func (d domainHandlers[T]) onRestaurantRegistered(ctx context.Context, event T)
 	return d.publisher.Publish(ctx, restaurantspb.RestaurantAggregateChannel, ddd.NewEvent(
 		restaurantspb.RestaurantRegisteredEvent,
 		&restaurantspb.RestaurantRegistered{
-			Id:   event.ID(),
-			Name: payload.Name,
+			Id:             payload.ID(),
+			Name:           payload.Name,
+			Latitude:       payload.Location.Latitude,
+			Longitude:      payload.Location.Longitude,
+			PricePerPerson: int64(payload.PricePerPerson),
+			DietaryTags:    payload.DietaryTags,
+			Seats:          int32(payload.Seats),
 		},
+		event.Metadata(),
+	))
+}
+
+func (d domainHandlers[T]) onRestaurantUpdated(ctx context.Context, event T) error {
+	payload := event.Payload().(*domain.Restaurant)
+	return d.publisher.Publish(ctx, restaurantspb.RestaurantAggregateChannel, ddd.NewEvent(
+		restaurantspb.RestaurantUpdatedEvent,
+		&restaurantspb.RestaurantUpdated{
+			Id:             payload.ID(),
+			Name:           payload.Name,
+			Latitude:       payload.Location.Latitude,
+			Longitude:      payload.Location.Longitude,
+			PricePerPerson: int64(payload.PricePerPerson),
+			DietaryTags:    payload.DietaryTags,
+			Seats:          int32(payload.Seats),
+		},
+		event.Metadata(),
+	))
+}
+
+func (d domainHandlers[T]) onRestaurantMerged(ctx context.Context, event T) error {
+	payload := event.Payload().(*domain.Restaurant)
+	return d.publisher.Publish(ctx, restaurantspb.RestaurantAggregateChannel, ddd.NewEvent(
+		restaurantspb.RestaurantMergedEvent,
+		&restaurantspb.RestaurantMerged{
+			Id:         payload.ID(),
+			SurvivorId: payload.MergedInto,
+		},
+		event.Metadata(),
 	))
 }
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/restaurants/internal/constants"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DuplicateHandlers look for duplicates of every restaurant that is
+// registered or updated; they must run after the mall has been updated
+type DuplicateHandlers[T ddd.Event] struct {
+	mall       domain.MallRepository
+	duplicates domain.DuplicateCandidateRepository
+	detector   domain.DuplicateDetector
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*DuplicateHandlers[ddd.Event])(nil)
+
+func NewDuplicateHandlers(mall domain.MallRepository, duplicates domain.DuplicateCandidateRepository, detector domain.DuplicateDetector) *DuplicateHandlers[ddd.Event] {
+	return &DuplicateHandlers[ddd.Event]{
+		mall:       mall,
+		duplicates: duplicates,
+		detector:   detector,
+	}
+}
+
+func (h DuplicateHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling duplicate event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled duplicate event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.RestaurantRegisteredEvent, domain.RestaurantUpdatedEvent:
+		return h.onRestaurantChanged(ctx, event)
+	case domain.RestaurantMergedEvent:
+		return h.onRestaurantMerged(ctx, event)
+	}
+	return nil
+}
+
+func (h DuplicateHandlers[T]) onRestaurantChanged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Restaurant)
+
+	others, err := h.mall.FindAll(ctx, domain.SortByName)
+	if err != nil {
+		return err
+	}
+
+	candidates := h.detector.Detect(&domain.MallRestaurant{
+		ID:       payload.ID(),
+		Name:     payload.Name,
+		Location: payload.Location,
+	}, others)
+	for _, candidate := range candidates {
+		if err = h.duplicates.Save(ctx, candidate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// onRestaurantMerged drops the other pairs the merged restaurant was in; they
+// would only ever be merged into a restaurant that no longer exists
+func (h DuplicateHandlers[T]) onRestaurantMerged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Restaurant)
+	return h.duplicates.RemovePending(ctx, payload.ID())
+}
+
+func RegisterDuplicateHandlers(duplicateHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(duplicateHandlers,
+		domain.RestaurantRegisteredEvent,
+		domain.RestaurantUpdatedEvent,
+		domain.RestaurantMergedEvent,
+	)
+}
+
+func RegisterDuplicateHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		duplicateHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.DuplicateHandlersKey)
+
+		return duplicateHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterDuplicateHandlers(handlers, subscriber)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/restaurants/internal/constants"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+	"github.com/jongyunha/lunchbox/reviews/reviewspb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type integrationHandlers[T ddd.Event] struct {
+	mall domain.MallRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*integrationHandlers[ddd.Event])(nil)
+
+func NewIntegrationEventHandlers(mall domain.MallRepository) ddd.EventHandler[ddd.Event] {
+	return integrationHandlers[ddd.Event]{
+		mall: mall,
+	}
+}
+
+func RegisterIntegrationEventHandlers(subscriber am.MessageSubscriber, handlers am.MessageHandler) (err error) {
+	_, err = subscriber.Subscribe(reviewspb.RestaurantRatingChannel, handlers, am.MessageFilter{
+		reviewspb.RestaurantRatingChangedEvent,
+	}, am.GroupName("restaurant-ratings"), am.PullConsumer{})
+	return err
+}
+
+func RegisterIntegrationEventHandlersTx(container di.Container) error {
+	evtMsgHandler := am.MessageHandlerFunc(func(ctx context.Context, msg am.IncomingMessage) error {
+		return di.WithinScope(ctx, container, func(ctx context.Context) error {
+			return di.MustGet[am.MessageHandler](di.FromContext(ctx), constants.IntegrationEventHandlersKey).HandleMessage(ctx, msg)
+		})
+	})
+
+	subscriber := di.MustGet[am.MessageSubscriber](container, constants.MessageSubscriberKey)
+
+	return RegisterIntegrationEventHandlers(subscriber, evtMsgHandler)
+}
+
+func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling integration event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled integration event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	span.AddEvent("Handling integration event", trace.WithAttributes(
+		attribute.String("Event", event.EventName()),
+	))
+
+	switch event.EventName() {
+	case reviewspb.RestaurantRatingChangedEvent:
+		return h.onRestaurantRatingChanged(ctx, event)
+	}
+
+	return nil
+}
+
+func (h integrationHandlers[T]) onRestaurantRatingChanged(ctx context.Context, event T) error {
+	payload := event.Payload().(*reviewspb.RestaurantRatingChanged)
+	return h.mall.UpdateRating(ctx, payload.GetRestaurantId(), payload.GetAverage(), int(payload.GetCount()))
+}
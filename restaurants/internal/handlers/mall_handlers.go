@@ -42,26 +42,59 @@ func (h MallHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
 	switch event.EventName() {
 	case domain.RestaurantRegisteredEvent:
 		return h.onRestaurantRegistered(ctx, event)
+	case domain.RestaurantUpdatedEvent:
+		return h.onRestaurantUpdated(ctx, event)
+	case domain.RestaurantMergedEvent:
+		return h.onRestaurantMerged(ctx, event)
 	}
 	return nil
 }
 
 func (h MallHandlers[T]) onRestaurantRegistered(ctx context.Context, event ddd.Event) error {
 	payload := event.Payload().(*domain.Restaurant)
-	return h.mall.RegisterRestaurant(ctx, event.ID(), payload.Name)
+	return h.mall.RegisterRestaurant(ctx, &domain.MallRestaurant{
+		ID:             payload.ID(),
+		ExternalRef:    payload.ExternalRef,
+		Name:           payload.Name,
+		Location:       payload.Location,
+		PricePerPerson: payload.PricePerPerson,
+		DietaryTags:    payload.DietaryTags,
+		Seats:          payload.Seats,
+	})
+}
+
+func (h MallHandlers[T]) onRestaurantUpdated(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Restaurant)
+	return h.mall.UpdateRestaurant(ctx, &domain.MallRestaurant{
+		ID:             payload.ID(),
+		Name:           payload.Name,
+		Location:       payload.Location,
+		PricePerPerson: payload.PricePerPerson,
+		DietaryTags:    payload.DietaryTags,
+		Seats:          payload.Seats,
+	})
+}
+
+func (h MallHandlers[T]) onRestaurantMerged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Restaurant)
+	return h.mall.MergeRestaurant(ctx, payload.ID(), payload.MergedInto)
 }
 
 func RegisterMallHandlers(mallHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
-	subscriber.Subscribe(mallHandlers, domain.RestaurantRegisteredEvent)
+	subscriber.Subscribe(mallHandlers,
+		domain.RestaurantRegisteredEvent,
+		domain.RestaurantUpdatedEvent,
+		domain.RestaurantMergedEvent,
+	)
 }
 
 func RegisterMallHandlersTx(container di.Container) {
 	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
-		mallHandlers := di.Get(ctx, constants.MallHandlersKey).(ddd.EventHandler[ddd.Event])
+		mallHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.MallHandlersKey)
 
 		return mallHandlers.HandleEvent(ctx, event)
 	})
 
-	subscriber := container.Get(constants.DomainDispatcherKey).(*ddd.EventDispatcher[ddd.Event])
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
 	RegisterMallHandlers(handlers, subscriber)
 }
@@ -1,29 +1,4 @@
 -- +goose Up
-CREATE OR REPLACE FUNCTION created_at_trigger()
-RETURNS TRIGGER AS $$
-BEGIN
-  IF NEW.created_at IS NULL THEN
-    NEW.created_at := NOW();
-  ELSE
-    NEW.created_at := OLD.created_at;
-  END IF;
-  RETURN NEW;
-END;
-$$ LANGUAGE plpgsql;
-
-CREATE OR REPLACE FUNCTION updated_at_trigger()
-RETURNS TRIGGER AS $$
-BEGIN
-  IF row(NEW.*) IS DISTINCT FROM row(OLD.*) THEN
-    NEW.updated_at := NOW();
-  END IF;
-  RETURN NEW;
-END;
-$$ LANGUAGE plpgsql;
-
-
-CREATE SCHEMA restaurants;
-
 CREATE TABLE restaurants.restaurants (
   id            text        NOT NULL,
   name          text        NOT NULL,
@@ -92,3 +67,6 @@ CREATE TABLE restaurants.outbox (
 );
 
 CREATE INDEX restaurants_unpublished_idx ON restaurants.outbox (published_at) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE restaurants.outbox, restaurants.inbox, restaurants.snapshots, restaurants.events, restaurants.restaurants;
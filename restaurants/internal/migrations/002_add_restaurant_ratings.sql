@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE restaurants.restaurants
+  ADD COLUMN average_rating double precision NOT NULL DEFAULT 0,
+  ADD COLUMN rating_count   int              NOT NULL DEFAULT 0;
+
+CREATE INDEX restaurants_rating_idx ON restaurants.restaurants (average_rating DESC, rating_count DESC);
+
+-- +goose Down
+DROP INDEX restaurants.restaurants_rating_idx;
+
+ALTER TABLE restaurants.restaurants
+  DROP COLUMN average_rating,
+  DROP COLUMN rating_count;
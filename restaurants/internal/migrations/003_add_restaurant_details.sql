@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE restaurants.restaurants
+  ADD COLUMN latitude         double precision NOT NULL DEFAULT 0,
+  ADD COLUMN longitude        double precision NOT NULL DEFAULT 0,
+  ADD COLUMN price_per_person int              NOT NULL DEFAULT 0,
+  ADD COLUMN dietary_tags     text[]           NOT NULL DEFAULT '{}',
+  ADD COLUMN seats            int              NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE restaurants.restaurants
+  DROP COLUMN latitude,
+  DROP COLUMN longitude,
+  DROP COLUMN price_per_person,
+  DROP COLUMN dietary_tags,
+  DROP COLUMN seats;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE restaurants.restaurants
+  ADD COLUMN external_ref text NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX restaurants_external_ref_idx ON restaurants.restaurants (external_ref) WHERE external_ref <> '';
+
+-- +goose Down
+DROP INDEX restaurants.restaurants_external_ref_idx;
+
+ALTER TABLE restaurants.restaurants
+  DROP COLUMN external_ref;
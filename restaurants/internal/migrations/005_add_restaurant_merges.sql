@@ -0,0 +1,22 @@
+-- +goose Up
+ALTER TABLE restaurants.restaurants
+  ADD COLUMN merged_into text NOT NULL DEFAULT '';
+
+CREATE TABLE restaurants.duplicate_candidates (
+  restaurant_id   text             NOT NULL,
+  duplicate_id    text             NOT NULL,
+  score           double precision NOT NULL,
+  name_similarity double precision NOT NULL,
+  distance        double precision NOT NULL,
+  status          text             NOT NULL DEFAULT 'pending',
+  detected_at     timestamptz      NOT NULL,
+  PRIMARY KEY (restaurant_id, duplicate_id)
+);
+
+CREATE INDEX duplicate_candidates_pending_idx ON restaurants.duplicate_candidates (score DESC) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE restaurants.duplicate_candidates;
+
+ALTER TABLE restaurants.restaurants
+  DROP COLUMN merged_into;
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+type DuplicateCandidateRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.DuplicateCandidateRepository = (*DuplicateCandidateRepository)(nil)
+
+func NewDuplicateCandidateRepository(tableName string, db postgres.DBTX) DuplicateCandidateRepository {
+	return DuplicateCandidateRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r DuplicateCandidateRepository) Save(ctx context.Context, candidate *domain.DuplicateCandidate) error {
+	const query = `INSERT INTO %[1]s (restaurant_id, duplicate_id, score, name_similarity, distance, status, detected_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (restaurant_id, duplicate_id) DO UPDATE SET
+  score = EXCLUDED.score, name_similarity = EXCLUDED.name_similarity, distance = EXCLUDED.distance,
+  detected_at = EXCLUDED.detected_at
+WHERE %[1]s.status = 'pending'`
+
+	_, err := r.db.Exec(ctx, r.table(query),
+		candidate.RestaurantID, candidate.DuplicateID, candidate.Score, candidate.NameSimilarity,
+		candidate.Distance, candidate.Status, candidate.DetectedAt,
+	)
+
+	return err
+}
+
+func (r DuplicateCandidateRepository) Resolve(ctx context.Context, restaurantID, duplicateID string, status domain.DuplicateStatus) error {
+	const query = `UPDATE %s SET status = $3
+WHERE restaurant_id = LEAST($1, $2) AND duplicate_id = GREATEST($1, $2) AND status = 'pending'`
+
+	tag, err := r.db.Exec(ctx, r.table(query), restaurantID, duplicateID, status)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDuplicateCandidateNotFound
+	}
+
+	return nil
+}
+
+func (r DuplicateCandidateRepository) RemovePending(ctx context.Context, restaurantID string) error {
+	const query = "DELETE FROM %s WHERE (restaurant_id = $1 OR duplicate_id = $1) AND status = 'pending'"
+
+	_, err := r.db.Exec(ctx, r.table(query), restaurantID)
+
+	return err
+}
+
+func (r DuplicateCandidateRepository) FindPending(ctx context.Context, limit int) ([]*domain.DuplicateCandidate, error) {
+	const query = `SELECT restaurant_id, duplicate_id, score, name_similarity, distance, status, detected_at
+FROM %s WHERE status = 'pending' ORDER BY score DESC, detected_at LIMIT $1`
+
+	rows, err := r.db.Query(ctx, r.table(query), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*domain.DuplicateCandidate
+	for rows.Next() {
+		candidate := &domain.DuplicateCandidate{}
+		err := rows.Scan(
+			&candidate.RestaurantID, &candidate.DuplicateID, &candidate.Score, &candidate.NameSimilarity,
+			&candidate.Distance, &candidate.Status, &candidate.DetectedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+func (r DuplicateCandidateRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
@@ -3,31 +3,131 @@ package postgres
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jongyunha/lunchbox/internal/postgres"
 	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+	"github.com/stackus/errors"
 )
 
+const mallRestaurantColumns = "id, external_ref, name, latitude, longitude, price_per_person, dietary_tags, seats, average_rating, rating_count, merged_into"
+
 type MallRepository struct {
-	queries *postgres.Queries
+	db postgres.DBTX
 }
 
 var _ domain.MallRepository = (*MallRepository)(nil)
 
 func NewMallRepository(db postgres.DBTX) *MallRepository {
 	return &MallRepository{
-		queries: postgres.New(db),
+		db: db,
 	}
 }
 
-func (m MallRepository) RegisterRestaurant(ctx context.Context, restaurantID, name string) error {
-	err := m.queries.SaveRestaurant(ctx, postgres.SaveRestaurantParams{
-		ID:   restaurantID,
-		Name: name,
-	})
+func (m MallRepository) RegisterRestaurant(ctx context.Context, restaurant *domain.MallRestaurant) error {
+	const query = `INSERT INTO restaurants.restaurants (id, external_ref, name, latitude, longitude, price_per_person, dietary_tags, seats)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := m.db.Exec(ctx, query,
+		restaurant.ID, restaurant.ExternalRef, restaurant.Name, restaurant.Location.Latitude, restaurant.Location.Longitude,
+		restaurant.PricePerPerson, restaurant.DietaryTags, restaurant.Seats,
+	)
+
+	return err
+}
+
+func (m MallRepository) UpdateRestaurant(ctx context.Context, restaurant *domain.MallRestaurant) error {
+	const query = `UPDATE restaurants.restaurants
+SET name = $2, latitude = $3, longitude = $4, price_per_person = $5, dietary_tags = $6, seats = $7
+WHERE id = $1`
+
+	_, err := m.db.Exec(ctx, query,
+		restaurant.ID, restaurant.Name, restaurant.Location.Latitude, restaurant.Location.Longitude,
+		restaurant.PricePerPerson, restaurant.DietaryTags, restaurant.Seats,
+	)
+
+	return err
+}
+
+func (m MallRepository) UpdateRating(ctx context.Context, restaurantID string, average float64, count int) error {
+	const query = "UPDATE restaurants.restaurants SET average_rating = $2, rating_count = $3 WHERE id = $1"
+
+	_, err := m.db.Exec(ctx, query, restaurantID, average, count)
+
+	return err
+}
+
+func (m MallRepository) MergeRestaurant(ctx context.Context, restaurantID, survivorID string) error {
+	const query = "UPDATE restaurants.restaurants SET merged_into = $2 WHERE id = $1"
+
+	_, err := m.db.Exec(ctx, query, restaurantID, survivorID)
 
 	return err
 }
 
 func (m MallRepository) FindByID(ctx context.Context, restaurantID string) (*domain.MallRestaurant, error) {
-	panic("implement me")
+	const query = "SELECT " + mallRestaurantColumns + " FROM restaurants.restaurants WHERE id = $1 LIMIT 1"
+
+	restaurant, err := m.scan(m.db.QueryRow(ctx, query, restaurantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrNotFound.Msgf("restaurant `%s` was not found", restaurantID)
+		}
+		return nil, err
+	}
+
+	return restaurant, nil
+}
+
+func (m MallRepository) FindByExternalRef(ctx context.Context, externalRef string) (*domain.MallRestaurant, error) {
+	const query = "SELECT " + mallRestaurantColumns + ` FROM restaurants.restaurants
+WHERE external_ref = $1 OR id = $1 ORDER BY external_ref = $1 DESC LIMIT 1`
+
+	restaurant, err := m.scan(m.db.QueryRow(ctx, query, externalRef))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrNotFound.Msgf("restaurant with the external reference `%s` was not found", externalRef)
+		}
+		return nil, err
+	}
+
+	return restaurant, nil
+}
+
+func (m MallRepository) FindAll(ctx context.Context, sortBy domain.RestaurantSortOrder) ([]*domain.MallRestaurant, error) {
+	query := "SELECT " + mallRestaurantColumns + " FROM restaurants.restaurants WHERE merged_into = '' ORDER BY name"
+	if sortBy == domain.SortByRating {
+		query = "SELECT " + mallRestaurantColumns + " FROM restaurants.restaurants WHERE merged_into = '' ORDER BY average_rating DESC, rating_count DESC, name"
+	}
+
+	rows, err := m.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurants []*domain.MallRestaurant
+	for rows.Next() {
+		restaurant, err := m.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		restaurants = append(restaurants, restaurant)
+	}
+
+	return restaurants, rows.Err()
+}
+
+func (m MallRepository) scan(row pgx.Row) (*domain.MallRestaurant, error) {
+	restaurant := &domain.MallRestaurant{}
+
+	err := row.Scan(
+		&restaurant.ID, &restaurant.ExternalRef, &restaurant.Name, &restaurant.Location.Latitude, &restaurant.Location.Longitude,
+		&restaurant.PricePerPerson, &restaurant.DietaryTags, &restaurant.Seats,
+		&restaurant.AverageRating, &restaurant.RatingCount, &restaurant.MergedInto,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return restaurant, nil
 }
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/restaurants/internal/application"
+	"github.com/jongyunha/lunchbox/restaurants/internal/application/queries"
+	"github.com/jongyunha/lunchbox/restaurants/internal/constants"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantsio"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/stackus/errors"
+)
+
+// RegisterExport serves the restaurants as a file download in the format
+// given by the format query parameter; csv is used when none is given
+func RegisterExport(mux *chi.Mux, container di.Container, authenticator *auth.Authenticator) error {
+	const exportRoute = "/api/v1/restaurants/export"
+
+	// the download is held to the same policy as the ExportRestaurants RPC
+	mux.With(authenticator.Protect(restaurantspb.RestaurantsService_ExportRestaurants_FullMethodName)).Get(exportRoute, func(w http.ResponseWriter, r *http.Request) {
+		format := restaurantsio.CSV
+		if param := r.URL.Query().Get("format"); param != "" {
+			var err error
+			if format, err = restaurantsio.ParseFormat(param); err != nil {
+				http.Error(w, err.Error(), errors.HTTPCode(err))
+				return
+			}
+		}
+
+		restaurants, err := listRestaurants(r.Context(), container)
+		if err != nil {
+			http.Error(w, err.Error(), errors.HTTPCode(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="restaurants.%s"`, format))
+		w.WriteHeader(http.StatusOK)
+
+		writer, _ := restaurantsio.NewWriter(w, format)
+		for _, restaurant := range restaurants {
+			if err = writer.Write(recordFromDomain(restaurant)); err != nil {
+				return
+			}
+		}
+		_ = writer.Close()
+	})
+
+	return nil
+}
+
+// listRestaurants only holds a transaction while reading the restaurants
+func listRestaurants(ctx context.Context, container di.Container) (restaurants []*domain.MallRestaurant, err error) {
+	err = di.WithinScope(ctx, container, func(ctx context.Context) error {
+		restaurants, err = di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey).ListRestaurants(ctx, queries.ListRestaurants{
+			SortBy: domain.SortByName,
+		})
+		return err
+	})
+
+	return restaurants, err
+}
+
+// recordFromDomain matches the records sent by the ExportRestaurants RPC
+func recordFromDomain(restaurant *domain.MallRestaurant) *restaurantspb.RestaurantRecord {
+	externalRef := restaurant.ExternalRef
+	if externalRef == "" {
+		externalRef = restaurant.ID
+	}
+
+	return &restaurantspb.RestaurantRecord{
+		ExternalRef: externalRef,
+		Name:        restaurant.Name,
+		Location: &restaurantspb.Location{
+			Latitude:  restaurant.Location.Latitude,
+			Longitude: restaurant.Location.Longitude,
+		},
+		PricePerPerson: int64(restaurant.PricePerPerson),
+		DietaryTags:    restaurant.DietaryTags,
+		Seats:          int32(restaurant.Seats),
+	}
+}
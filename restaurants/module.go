@@ -4,14 +4,13 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jongyunha/lunchbox/internal/am"
 	"github.com/jongyunha/lunchbox/internal/amotel"
 	"github.com/jongyunha/lunchbox/internal/amprom"
 	"github.com/jongyunha/lunchbox/internal/ddd"
 	"github.com/jongyunha/lunchbox/internal/di"
 	"github.com/jongyunha/lunchbox/internal/es"
-	"github.com/jongyunha/lunchbox/internal/jetstream"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
 	pg "github.com/jongyunha/lunchbox/internal/postgres"
 	"github.com/jongyunha/lunchbox/internal/postgresotel"
 	"github.com/jongyunha/lunchbox/internal/registry"
@@ -23,9 +22,11 @@ import (
 	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
 	"github.com/jongyunha/lunchbox/restaurants/internal/grpc"
 	"github.com/jongyunha/lunchbox/restaurants/internal/handlers"
+	"github.com/jongyunha/lunchbox/restaurants/internal/migrations"
 	"github.com/jongyunha/lunchbox/restaurants/internal/postgres"
 	"github.com/jongyunha/lunchbox/restaurants/internal/rest"
 	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/jongyunha/lunchbox/reviews/reviewspb"
 	"github.com/rs/zerolog"
 )
 
@@ -35,34 +36,51 @@ func (m *Module) Startup(ctx context.Context, svc system.Service) (err error) {
 	return Root(ctx, svc)
 }
 
+// Migrations returns the migrations of the module schema
+func (m *Module) Migrations() pg.Migrations {
+	return pg.Migrations{
+		Schema: constants.ServiceName,
+		FS:     migrations.FS,
+	}
+}
+
+// Registry returns a registry with everything the module serializes
+func (m *Module) Registry() (registry.Registry, error) {
+	return newRegistry()
+}
+
+// Projections returns the read models that can be rebuilt from the event store
+func (m *Module) Projections() []pg.Projection {
+	// the restaurant read model also holds the ratings sent over from reviews,
+	// which are not in the event store, so it cannot be rebuilt from it
+	return nil
+}
+
 func Root(ctx context.Context, svc system.Service) (err error) {
 	container := di.New()
 
 	// setup Driven adapters
-	container.AddSingleton(constants.RegistryKey, func(c di.Container) (any, error) {
-		reg := registry.New()
-		if err = registrations(reg); err != nil {
-			return nil, err
-		}
-		if err = restaurantspb.Registrations(reg); err != nil {
-			return nil, err
-		}
-		return reg, nil
+	di.Register(container, di.Singleton, constants.RegistryKey, func(c di.Container) (registry.Registry, error) {
+		return newRegistry()
 	})
 
-	stream := jetstream.NewStream(svc.Config().Nats.Stream, svc.JS(), svc.Logger())
+	stream := svc.Stream()
 
-	container.AddSingleton(constants.DomainDispatcherKey, func(c di.Container) (any, error) {
+	di.Register(container, di.Singleton, constants.DomainDispatcherKey, func(c di.Container) (*ddd.EventDispatcher[ddd.Event], error) {
 		return ddd.NewEventDispatcher[ddd.Event](), nil
 	})
 
-	container.AddScoped(constants.DatabaseTransactionKey, func(c di.Container) (any, error) {
+	di.Register(container, di.Scoped, constants.DatabaseTransactionKey, func(c di.Container) (pgx.Tx, error) {
 		return svc.DB().Begin(context.Background())
+	}, di.Finalizer(pg.EndTransaction(svc.Logger())))
+	di.Register(container, di.Scoped, constants.IdempotencyStoreKey, func(c di.Container) (idempotency.Store, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewIdempotencyStore(constants.ServiceName+".idempotency_keys", tx), nil
 	})
 	sentCounter := amprom.SentMessagesCounter(constants.ServiceName)
-	container.AddScoped(constants.MessagePublisherKey, func(c di.Container) (any, error) {
-		tx := postgresotel.Trace(c.Get(constants.DatabaseTransactionKey).(pgx.Tx))
-		outboxRestaurants := pg.NewOutboxStore(tx)
+	di.Register(container, di.Scoped, constants.MessagePublisherKey, func(c di.Container) (am.MessagePublisher, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		outboxRestaurants := pg.NewOutboxStore(constants.ServiceName+".outbox", tx)
 		return am.NewMessagePublisher(
 			stream,
 			amotel.OtelMessageContextInjector(),
@@ -71,7 +89,7 @@ func Root(ctx context.Context, svc system.Service) (err error) {
 		), nil
 	})
 
-	container.AddSingleton(constants.MessageSubscriberKey, func(c di.Container) (any, error) {
+	di.Register(container, di.Singleton, constants.MessageSubscriberKey, func(c di.Container) (am.MessageSubscriber, error) {
 		return am.NewMessageSubscriber(
 			stream,
 			amotel.OtelMessageContextExtractor(),
@@ -79,76 +97,137 @@ func Root(ctx context.Context, svc system.Service) (err error) {
 		), nil
 	})
 
-	container.AddScoped(constants.EventPublisherKey, func(c di.Container) (any, error) {
+	di.Register(container, di.Scoped, constants.EventPublisherKey, func(c di.Container) (am.EventPublisher, error) {
 		return am.NewEventPublisher(
-			c.Get(constants.RegistryKey).(registry.Registry),
-			c.Get(constants.MessagePublisherKey).(am.MessagePublisher),
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[am.MessagePublisher](c, constants.MessagePublisherKey),
 		), nil
 	})
 
-	container.AddScoped(constants.InboxRestaurantKey, func(c di.Container) (any, error) {
-		tx := postgresotel.Trace(c.Get(constants.DatabaseTransactionKey).(*pgxpool.Tx))
-		return pg.NewInboxStore(tx), nil
+	di.Register(container, di.Scoped, constants.InboxRestaurantKey, func(c di.Container) (tm.InboxStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewInboxStore(constants.ServiceName+".inbox", tx), nil
 	})
 
-	container.AddScoped(constants.AggregateStoreKey, func(c di.Container) (any, error) {
-		tx := postgresotel.Trace(c.Get(constants.DatabaseTransactionKey).(*pgxpool.Tx))
-		reg := c.Get(constants.RegistryKey).(registry.Registry)
+	di.Register(container, di.Scoped, constants.AggregateStoreKey, func(c di.Container) (es.AggregateStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		reg := di.MustGet[registry.Registry](c, constants.RegistryKey)
 		return es.AggregateStoreWithMiddleware(
 			pg.NewEventStore(constants.ServiceName+".events", tx, reg),
 			pg.NewSnapshotStore(constants.ServiceName+".snapshots", tx, reg),
 		), nil
 	})
 
-	container.AddScoped(constants.RestaurantsRepoKey, func(c di.Container) (any, error) {
+	di.Register(container, di.Scoped, constants.RestaurantsRepoKey, func(c di.Container) (es.AggregateRepository[*domain.Restaurant], error) {
 		return es.NewAggregateRepository[*domain.Restaurant](
 			domain.RestaurantAggregate,
-			c.Get(constants.RegistryKey).(registry.Registry),
-			c.Get(constants.AggregateStoreKey).(es.AggregateStore),
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[es.AggregateStore](c, constants.AggregateStoreKey),
 		), nil
 	})
 
-	container.AddScoped(constants.MallRepoKey, func(c di.Container) (any, error) {
+	di.Register(container, di.Scoped, constants.MallRepoKey, func(c di.Container) (domain.MallRepository, error) {
 		return postgres.NewMallRepository(
-			postgresotel.Trace(c.Get(constants.DatabaseTransactionKey).(*pgxpool.Tx)),
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
 		), nil
 	})
 
-	container.AddScoped(constants.ApplicationKey, func(c di.Container) (any, error) {
+	di.Register(container, di.Scoped, constants.DuplicatesRepoKey, func(c di.Container) (domain.DuplicateCandidateRepository, error) {
+		return postgres.NewDuplicateCandidateRepository(
+			constants.ServiceName+".duplicate_candidates",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	detector := domain.NewDuplicateDetector(
+		svc.Config().Restaurants.DuplicateThreshold,
+		svc.Config().Restaurants.DuplicateMaxDistance,
+	)
+
+	di.Register(container, di.Scoped, constants.ApplicationKey, func(c di.Container) (application.App, error) {
 		return application.New(
-			c.Get(constants.RestaurantsRepoKey).(es.AggregateRepository[*domain.Restaurant]),
-			c.Get(constants.DomainDispatcherKey).(ddd.EventPublisher[ddd.Event]),
+			di.MustGet[es.AggregateRepository[*domain.Restaurant]](c, constants.RestaurantsRepoKey),
+			di.MustGet[domain.MallRepository](c, constants.MallRepoKey),
+			di.MustGet[domain.DuplicateCandidateRepository](c, constants.DuplicatesRepoKey),
+			detector,
+			di.MustGet[ddd.EventPublisher[ddd.Event]](c, constants.DomainDispatcherKey),
 		), nil
 	})
 
-	container.AddScoped(constants.MallHandlersKey, func(c di.Container) (any, error) {
-		return handlers.NewMallHandlers(c.Get(constants.MallRepoKey).(domain.MallRepository)), nil
+	di.Register(container, di.Scoped, constants.MallHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewMallHandlers(di.MustGet[domain.MallRepository](c, constants.MallRepoKey)), nil
+	})
+	di.Register(container, di.Scoped, constants.DuplicateHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewDuplicateHandlers(
+			di.MustGet[domain.MallRepository](c, constants.MallRepoKey),
+			di.MustGet[domain.DuplicateCandidateRepository](c, constants.DuplicatesRepoKey),
+			detector,
+		), nil
+	})
+	di.Register(container, di.Scoped, constants.DomainEventHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewDomainEventHandlers(di.MustGet[am.EventPublisher](c, constants.EventPublisherKey)), nil
 	})
-	container.AddScoped(constants.DomainEventHandlersKey, func(c di.Container) (any, error) {
-		return handlers.NewDomainEventHandlers(c.Get(constants.EventPublisherKey).(am.EventPublisher)), nil
+	di.Register(container, di.Scoped, constants.IntegrationEventHandlersKey, func(c di.Container) (am.MessageHandler, error) {
+		return am.NewEventHandler(
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			handlers.NewIntegrationEventHandlers(di.MustGet[domain.MallRepository](c, constants.MallRepoKey)),
+			tm.InboxHandler(di.MustGet[tm.InboxStore](c, constants.InboxRestaurantKey)),
+		), nil
 	})
 
 	outboxProcessor := tm.NewOutboxProcessor(
 		stream,
-		pg.NewOutboxStore(svc.DB()),
+		pg.NewOutboxStore(constants.ServiceName+".outbox", svc.DB()),
 	)
 
+	if err = container.Validate(ctx); err != nil {
+		return err
+	}
+	svc.Waiter().Cleanup(func() {
+		_ = container.Close(context.Background())
+	})
+	svc.Health().Register(constants.ServiceName+".outbox", pg.OutboxBacklogCheck(
+		constants.ServiceName+".outbox", svc.DB(), svc.Config().Health.OutboxBacklog,
+	))
+
 	// setup Driver adapters
-	if err = grpc.RegisterServerTx(container, svc.RPC(), svc.Logger()); err != nil {
+	if err = grpc.RegisterServer(container, svc.RPC(), svc.Logger()); err != nil {
 		return err
 	}
 	if err = rest.RegisterGateway(ctx, svc.Mux(), svc.Config().Rpc.Address()); err != nil {
 		return err
 	}
+	if err = rest.RegisterExport(svc.Mux(), container, svc.Auth()); err != nil {
+		return err
+	}
 	if err = rest.RegisterSwagger(svc.Mux()); err != nil {
 		return err
 	}
 	handlers.RegisterMallHandlersTx(container)
+	handlers.RegisterDuplicateHandlersTx(container)
 	handlers.RegisterDomainEventHandlersTx(container)
+	if err = handlers.RegisterIntegrationEventHandlersTx(container); err != nil {
+		return err
+	}
 	startOutboxProcessor(ctx, outboxProcessor, svc.Logger())
 	return nil
 }
 
+// newRegistry registers everything the module serializes
+func newRegistry() (reg registry.Registry, err error) {
+	reg = registry.New()
+	if err = registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = restaurantspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = reviewspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
 func registrations(reg registry.Registry) (err error) {
 	serde := serdes.NewJsonSerde(reg)
 
@@ -165,6 +244,12 @@ func registrations(reg registry.Registry) (err error) {
 	if err = serde.Register(domain.RestaurantRegistered{}); err != nil {
 		return
 	}
+	if err = serde.Register(domain.RestaurantUpdated{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.RestaurantMerged{}); err != nil {
+		return
+	}
 
 	// Restaurant snapshot
 	if err = serde.RegisterKey(domain.RestaurantV1{}.SnapshotName(), domain.RestaurantV1{}); err != nil {
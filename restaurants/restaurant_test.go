@@ -0,0 +1,225 @@
+package restaurants
+
+import (
+	"testing"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es/estest"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/restaurants/internal/domain"
+)
+
+var (
+	gangnam = domain.Location{Latitude: 37.4979, Longitude: 127.0276}
+	jongno  = domain.Location{Latitude: 37.5704, Longitude: 126.9922}
+)
+
+func registered() ddd.Event {
+	return ddd.NewEvent(domain.RestaurantRegisteredEvent, &domain.RestaurantRegistered{
+		ExternalRef:    "listing-1",
+		Name:           "Noodles",
+		Location:       gangnam,
+		PricePerPerson: 9000,
+		DietaryTags:    []string{"vegan", "halal"},
+		Seats:          20,
+	})
+}
+
+func merged() ddd.Event {
+	return ddd.NewEvent(domain.RestaurantMergedEvent, &domain.RestaurantMerged{SurvivorID: "survivor-id"})
+}
+
+func TestRestaurant(t *testing.T) {
+	reg := registry.New()
+	if err := registrations(reg); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		given   []ddd.Event
+		when    func(r *domain.Restaurant) error
+		then    []ddd.Event
+		wantErr error
+	}{
+		"registering": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.InitRestaurant("restaurant-id", "Noodles", gangnam, 9000, []string{"vegan"}, 20)
+				return err
+			},
+			then: []ddd.Event{ddd.NewEvent(domain.RestaurantRegisteredEvent, &domain.RestaurantRegistered{
+				Name:           "Noodles",
+				Location:       gangnam,
+				PricePerPerson: 9000,
+				DietaryTags:    []string{"vegan"},
+				Seats:          20,
+			})},
+		},
+		"registering without a name": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.InitRestaurant("restaurant-id", "", gangnam, 9000, nil, 20)
+				return err
+			},
+			wantErr: domain.ErrRestaurantNameIsBlank,
+		},
+		"registering at an invalid location": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.InitRestaurant("restaurant-id", "Noodles", domain.Location{Latitude: 91}, 9000, nil, 20)
+				return err
+			},
+			wantErr: domain.ErrInvalidLocation,
+		},
+		"registering with a negative price": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.InitRestaurant("restaurant-id", "Noodles", gangnam, -1, nil, 20)
+				return err
+			},
+			wantErr: domain.ErrInvalidPricePerPerson,
+		},
+		"updating": {
+			given: []ddd.Event{registered()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.UpdateRestaurant("Noodle Bar", jongno, 10000, nil, 24)
+				return err
+			},
+			then: []ddd.Event{ddd.NewEvent(domain.RestaurantUpdatedEvent, &domain.RestaurantUpdated{
+				Name:           "Noodle Bar",
+				Location:       jongno,
+				PricePerPerson: 10000,
+				Seats:          24,
+			})},
+		},
+		"updating an unknown restaurant": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.UpdateRestaurant("Noodle Bar", jongno, 10000, nil, 24)
+				return err
+			},
+			wantErr: domain.ErrRestaurantNotFound,
+		},
+		"updating a merged restaurant": {
+			given: []ddd.Event{registered(), merged()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.UpdateRestaurant("Noodle Bar", jongno, 10000, nil, 24)
+				return err
+			},
+			wantErr: domain.ErrRestaurantMerged,
+		},
+		"updating with negative seats": {
+			given: []ddd.Event{registered()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.UpdateRestaurant("Noodle Bar", jongno, 10000, nil, -1)
+				return err
+			},
+			wantErr: domain.ErrInvalidSeats,
+		},
+		"importing a new restaurant": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.ImportRestaurant("listing-1", "Noodles", gangnam, 9000, []string{"vegan", "halal"}, 20)
+				return err
+			},
+			then: []ddd.Event{registered()},
+		},
+		"importing an unchanged restaurant": {
+			given: []ddd.Event{registered()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.ImportRestaurant("listing-1", "Noodles", gangnam, 9000, []string{"halal", "vegan"}, 20)
+				return err
+			},
+			then: []ddd.Event{},
+		},
+		"importing a changed restaurant": {
+			given: []ddd.Event{registered()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.ImportRestaurant("listing-1", "Noodles", gangnam, 9500, []string{"vegan", "halal"}, 20)
+				return err
+			},
+			then: []ddd.Event{ddd.NewEvent(domain.RestaurantUpdatedEvent, &domain.RestaurantUpdated{
+				Name:           "Noodles",
+				Location:       gangnam,
+				PricePerPerson: 9500,
+				DietaryTags:    []string{"vegan", "halal"},
+				Seats:          20,
+			})},
+		},
+		"importing without an external reference": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.ImportRestaurant("", "Noodles", gangnam, 9000, nil, 20)
+				return err
+			},
+			wantErr: domain.ErrExternalRefIsBlank,
+		},
+		"importing a merged restaurant": {
+			given: []ddd.Event{registered(), merged()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.ImportRestaurant("listing-1", "Noodles", gangnam, 9500, nil, 20)
+				return err
+			},
+			wantErr: domain.ErrRestaurantMerged,
+		},
+		"merging": {
+			given: []ddd.Event{registered()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.MergeInto("survivor-id")
+				return err
+			},
+			then: []ddd.Event{merged()},
+		},
+		"merging an unknown restaurant": {
+			when: func(r *domain.Restaurant) error {
+				_, err := r.MergeInto("survivor-id")
+				return err
+			},
+			wantErr: domain.ErrRestaurantNotFound,
+		},
+		"merging a merged restaurant": {
+			given: []ddd.Event{registered(), merged()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.MergeInto("another-survivor-id")
+				return err
+			},
+			wantErr: domain.ErrRestaurantMerged,
+		},
+		"merging into itself": {
+			given: []ddd.Event{registered()},
+			when: func(r *domain.Restaurant) error {
+				_, err := r.MergeInto("restaurant-id")
+				return err
+			},
+			wantErr: domain.ErrMergeIntoItself,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scenario := estest.For[*domain.Restaurant](t, reg, domain.RestaurantAggregate, "restaurant-id").
+				Given(tc.given...).
+				When(tc.when)
+			if tc.wantErr != nil {
+				scenario.ThenError(tc.wantErr)
+				return
+			}
+			scenario.Then(tc.then...)
+		})
+	}
+}
+
+func TestRestaurantSnapshot(t *testing.T) {
+	reg := registry.New()
+	if err := registrations(reg); err != nil {
+		t.Fatal(err)
+	}
+
+	restaurant := estest.For[*domain.Restaurant](t, reg, domain.RestaurantAggregate, "restaurant-id").
+		Given(registered()).
+		When(func(r *domain.Restaurant) error {
+			_, err := r.MergeInto("survivor-id")
+			return err
+		}).
+		Then(merged())
+
+	// the snapshot has to carry everything the commands check, not only
+	// the details that are shown
+	snapshot := restaurant.ToSnapshot().(domain.RestaurantV1)
+	if snapshot.ExternalRef != "listing-1" || snapshot.MergedInto != "survivor-id" {
+		t.Errorf("expected the snapshot to keep the external reference and the survivor, got %+v", snapshot)
+	}
+	estest.AssertSnapshot(t, reg, domain.RestaurantAggregate, restaurant)
+}
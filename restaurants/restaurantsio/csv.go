@@ -0,0 +1,167 @@
+package restaurantsio
+
+import (
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/stackus/errors"
+)
+
+// the dietary tags share a single column
+const tagSeparator = ";"
+
+var csvColumns = []string{"external_ref", "name", "latitude", "longitude", "price_per_person", "dietary_tags", "seats"}
+
+type (
+	// csvReader expects a header row naming the columns, in any order; only
+	// external_ref and name are required
+	csvReader struct {
+		r       *csv.Reader
+		columns map[string]int
+	}
+
+	csvWriter struct {
+		w           *csv.Writer
+		wroteHeader bool
+	}
+)
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.ErrBadRequest.Msg("the csv file has no header row")
+		}
+		return nil, errors.ErrBadRequest.Err(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(csvColumns, column) {
+			return nil, errors.ErrBadRequest.Msgf("the csv column `%s` is not one of %s", column, strings.Join(csvColumns, ", "))
+		}
+		columns[column] = i
+	}
+	for _, column := range []string{"external_ref", "name"} {
+		if _, exists := columns[column]; !exists {
+			return nil, errors.ErrBadRequest.Msgf("the csv file has no `%s` column", column)
+		}
+	}
+
+	return &csvReader{
+		r:       reader,
+		columns: columns,
+	}, nil
+}
+
+func (r *csvReader) Read() (int, *restaurantspb.RestaurantRecord, error) {
+	fields, err := r.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return parseErr.StartLine, nil, &RowError{Row: parseErr.StartLine, Err: parseErr.Err}
+		}
+		return 0, nil, err
+	}
+	row, _ := r.r.FieldPos(0)
+
+	field := func(column string) string {
+		if i, exists := r.columns[column]; exists && i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+
+	record := &restaurantspb.RestaurantRecord{
+		ExternalRef: field("external_ref"),
+		Name:        field("name"),
+		Location:    &restaurantspb.Location{},
+		DietaryTags: splitTags(field("dietary_tags")),
+	}
+
+	if record.Location.Latitude, err = parseFloat(field("latitude")); err != nil {
+		return row, nil, &RowError{Row: row, Err: errors.ErrBadRequest.Msgf("latitude `%s` is not a number", field("latitude"))}
+	}
+	if record.Location.Longitude, err = parseFloat(field("longitude")); err != nil {
+		return row, nil, &RowError{Row: row, Err: errors.ErrBadRequest.Msgf("longitude `%s` is not a number", field("longitude"))}
+	}
+	if record.PricePerPerson, err = parseInt(field("price_per_person"), 64); err != nil {
+		return row, nil, &RowError{Row: row, Err: errors.ErrBadRequest.Msgf("price_per_person `%s` is not a whole number", field("price_per_person"))}
+	}
+	seats, err := parseInt(field("seats"), 32)
+	if err != nil {
+		return row, nil, &RowError{Row: row, Err: errors.ErrBadRequest.Msgf("seats `%s` is not a whole number", field("seats"))}
+	}
+	record.Seats = int32(seats)
+
+	return row, record, nil
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) Write(record *restaurantspb.RestaurantRecord) error {
+	if !w.wroteHeader {
+		if err := w.w.Write(csvColumns); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+
+	return w.w.Write([]string{
+		record.GetExternalRef(),
+		record.GetName(),
+		strconv.FormatFloat(record.GetLocation().GetLatitude(), 'f', -1, 64),
+		strconv.FormatFloat(record.GetLocation().GetLongitude(), 'f', -1, 64),
+		strconv.FormatInt(record.GetPricePerPerson(), 10),
+		strings.Join(record.GetDietaryTags(), tagSeparator),
+		strconv.FormatInt(int64(record.GetSeats()), 10),
+	})
+}
+
+// Close writes the header even when there were no records
+func (w *csvWriter) Close() error {
+	if !w.wroteHeader {
+		if err := w.w.Write(csvColumns); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	w.w.Flush()
+
+	return w.w.Error()
+}
+
+func splitTags(tags string) []string {
+	var split []string
+	for _, tag := range strings.Split(tags, tagSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			split = append(split, tag)
+		}
+	}
+	return split
+}
+
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseInt(s string, bitSize int) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, bitSize)
+}
@@ -0,0 +1,121 @@
+// Package restaurantsio reads and writes restaurant records as CSV, JSON
+// Lines and GeoJSON for the bulk import and export of restaurants
+package restaurantsio
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/stackus/errors"
+)
+
+type Format string
+
+const (
+	CSV       Format = "csv"
+	JSONLines Format = "jsonl"
+	GeoJSON   Format = "geojson"
+)
+
+var ErrUnknownFormat = errors.Wrap(errors.ErrBadRequest, "the format is not one of csv, jsonl or geojson")
+
+type (
+	// Reader returns the records one at a time along with the row they were
+	// read from; io.EOF is returned after the last record
+	//
+	// A record that cannot be read is returned as a *RowError and reading may
+	// continue with the next record.
+	Reader interface {
+		Read() (row int, record *restaurantspb.RestaurantRecord, err error)
+	}
+
+	// Writer writes the records one at a time; Close finishes the output but
+	// does not close the underlying writer
+	Writer interface {
+		Write(record *restaurantspb.RestaurantRecord) error
+		Close() error
+	}
+
+	RowError struct {
+		Row int
+		Err error
+	}
+)
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+func ParseFormat(format string) (Format, error) {
+	switch strings.ToLower(format) {
+	case "csv":
+		return CSV, nil
+	case "jsonl", "ndjson":
+		return JSONLines, nil
+	case "geojson":
+		return GeoJSON, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+// FormatFromFilename picks the format from the file extension
+func FormatFromFilename(filename string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return CSV, nil
+	case ".jsonl", ".ndjson":
+		return JSONLines, nil
+	case ".geojson", ".json":
+		return GeoJSON, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+// ContentType is the media type to serve the format with
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case JSONLines:
+		return "application/x-ndjson"
+	case GeoJSON:
+		return "application/geo+json"
+	}
+
+	return "application/octet-stream"
+}
+
+func NewReader(r io.Reader, format Format) (Reader, error) {
+	switch format {
+	case CSV:
+		return newCSVReader(r)
+	case JSONLines:
+		return newJSONLinesReader(r), nil
+	case GeoJSON:
+		return newGeoJSONReader(r)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+func NewWriter(w io.Writer, format Format) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w), nil
+	case JSONLines:
+		return newJSONLinesWriter(w), nil
+	case GeoJSON:
+		return newGeoJSONWriter(w), nil
+	}
+
+	return nil, ErrUnknownFormat
+}
@@ -0,0 +1,154 @@
+package restaurantsio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/stackus/errors"
+)
+
+type (
+	featureCollection struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+
+	// feature keeps the restaurant details other than the location in its
+	// properties; the feature id stands in for a missing external_ref
+	feature struct {
+		Type       string            `json:"type"`
+		ID         any               `json:"id,omitempty"`
+		Geometry   *point            `json:"geometry"`
+		Properties featureProperties `json:"properties"`
+	}
+
+	point struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+	}
+
+	featureProperties struct {
+		ExternalRef    string   `json:"external_ref"`
+		Name           string   `json:"name"`
+		PricePerPerson int64    `json:"price_per_person"`
+		DietaryTags    []string `json:"dietary_tags"`
+		Seats          int32    `json:"seats"`
+	}
+
+	// geoJSONReader reads the whole collection up front; rows are the
+	// positions of the features counting from one
+	geoJSONReader struct {
+		features []json.RawMessage
+		next     int
+	}
+
+	geoJSONWriter struct {
+		w        io.Writer
+		features int
+	}
+)
+
+func newGeoJSONReader(r io.Reader) (*geoJSONReader, error) {
+	var collection featureCollection
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, errors.ErrBadRequest.Err(err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, errors.ErrBadRequest.Msg("the geojson document is not a FeatureCollection")
+	}
+
+	return &geoJSONReader{features: collection.Features}, nil
+}
+
+func (r *geoJSONReader) Read() (int, *restaurantspb.RestaurantRecord, error) {
+	if r.next >= len(r.features) {
+		return 0, nil, io.EOF
+	}
+	r.next++
+	row := r.next
+
+	var f feature
+	if err := json.Unmarshal(r.features[row-1], &f); err != nil {
+		return row, nil, &RowError{Row: row, Err: errors.ErrBadRequest.Err(err)}
+	}
+	if f.Type != "Feature" {
+		return row, nil, &RowError{Row: row, Err: errors.ErrBadRequest.Msgf("`%s` is not a Feature", f.Type)}
+	}
+
+	record := &restaurantspb.RestaurantRecord{
+		ExternalRef:    f.Properties.ExternalRef,
+		Name:           f.Properties.Name,
+		Location:       &restaurantspb.Location{},
+		PricePerPerson: f.Properties.PricePerPerson,
+		DietaryTags:    f.Properties.DietaryTags,
+		Seats:          f.Properties.Seats,
+	}
+	if record.ExternalRef == "" && f.ID != nil {
+		record.ExternalRef = fmt.Sprint(f.ID)
+	}
+	if f.Geometry != nil {
+		if f.Geometry.Type != "Point" || len(f.Geometry.Coordinates) < 2 {
+			return row, nil, &RowError{Row: row, Err: errors.ErrBadRequest.Msg("the geometry is not a Point")}
+		}
+		// positions are longitude first
+		record.Location.Longitude = f.Geometry.Coordinates[0]
+		record.Location.Latitude = f.Geometry.Coordinates[1]
+	}
+
+	return row, record, nil
+}
+
+func newGeoJSONWriter(w io.Writer) *geoJSONWriter {
+	return &geoJSONWriter{w: w}
+}
+
+func (w *geoJSONWriter) Write(record *restaurantspb.RestaurantRecord) error {
+	tags := record.GetDietaryTags()
+	if tags == nil {
+		tags = []string{}
+	}
+
+	data, err := json.Marshal(feature{
+		Type: "Feature",
+		ID:   record.GetExternalRef(),
+		Geometry: &point{
+			Type:        "Point",
+			Coordinates: []float64{record.GetLocation().GetLongitude(), record.GetLocation().GetLatitude()},
+		},
+		Properties: featureProperties{
+			ExternalRef:    record.GetExternalRef(),
+			Name:           record.GetName(),
+			PricePerPerson: record.GetPricePerPerson(),
+			DietaryTags:    tags,
+			Seats:          record.GetSeats(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if w.features == 0 {
+		buf.WriteString(`{"type":"FeatureCollection","features":[` + "\n")
+	} else {
+		buf.WriteString(",\n")
+	}
+	buf.Write(data)
+	w.features++
+
+	_, err = w.w.Write(buf.Bytes())
+	return err
+}
+
+func (w *geoJSONWriter) Close() error {
+	closing := "\n]}\n"
+	if w.features == 0 {
+		closing = `{"type":"FeatureCollection","features":[]}` + "\n"
+	}
+
+	_, err := io.WriteString(w.w, closing)
+	return err
+}
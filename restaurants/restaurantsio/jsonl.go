@@ -0,0 +1,110 @@
+package restaurantsio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/stackus/errors"
+)
+
+// maxLineSize is the longest JSON Lines record that will be read
+const maxLineSize = 1 << 20
+
+type (
+	// jsonRecord uses the same flat fields as the csv columns
+	jsonRecord struct {
+		ExternalRef    string   `json:"external_ref"`
+		Name           string   `json:"name"`
+		Latitude       float64  `json:"latitude"`
+		Longitude      float64  `json:"longitude"`
+		PricePerPerson int64    `json:"price_per_person"`
+		DietaryTags    []string `json:"dietary_tags"`
+		Seats          int32    `json:"seats"`
+	}
+
+	jsonLinesReader struct {
+		s    *bufio.Scanner
+		line int
+	}
+
+	jsonLinesWriter struct {
+		enc *json.Encoder
+	}
+)
+
+func newJSONLinesReader(r io.Reader) *jsonLinesReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &jsonLinesReader{s: scanner}
+}
+
+func (r *jsonLinesReader) Read() (int, *restaurantspb.RestaurantRecord, error) {
+	for r.s.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var record jsonRecord
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			return r.line, nil, &RowError{Row: r.line, Err: errors.ErrBadRequest.Err(err)}
+		}
+
+		return r.line, record.toProto(), nil
+	}
+	if err := r.s.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	return 0, nil, io.EOF
+}
+
+func newJSONLinesWriter(w io.Writer) *jsonLinesWriter {
+	return &jsonLinesWriter{enc: json.NewEncoder(w)}
+}
+
+func (w *jsonLinesWriter) Write(record *restaurantspb.RestaurantRecord) error {
+	return w.enc.Encode(jsonRecordFromProto(record))
+}
+
+func (w *jsonLinesWriter) Close() error {
+	return nil
+}
+
+func (r jsonRecord) toProto() *restaurantspb.RestaurantRecord {
+	return &restaurantspb.RestaurantRecord{
+		ExternalRef: r.ExternalRef,
+		Name:        r.Name,
+		Location: &restaurantspb.Location{
+			Latitude:  r.Latitude,
+			Longitude: r.Longitude,
+		},
+		PricePerPerson: r.PricePerPerson,
+		DietaryTags:    r.DietaryTags,
+		Seats:          r.Seats,
+	}
+}
+
+func jsonRecordFromProto(record *restaurantspb.RestaurantRecord) jsonRecord {
+	tags := record.GetDietaryTags()
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return jsonRecord{
+		ExternalRef:    record.GetExternalRef(),
+		Name:           record.GetName(),
+		Latitude:       record.GetLocation().GetLatitude(),
+		Longitude:      record.GetLocation().GetLongitude(),
+		PricePerPerson: record.GetPricePerPerson(),
+		DietaryTags:    tags,
+		Seats:          record.GetSeats(),
+	}
+}
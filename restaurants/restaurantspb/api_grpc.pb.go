@@ -19,7 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RestaurantsService_RegisterRestaurant_FullMethodName = "/restaurantspb.RestaurantsService/RegisterRestaurant"
+	RestaurantsService_RegisterRestaurant_FullMethodName        = "/restaurantspb.RestaurantsService/RegisterRestaurant"
+	RestaurantsService_UpdateRestaurant_FullMethodName          = "/restaurantspb.RestaurantsService/UpdateRestaurant"
+	RestaurantsService_ListRestaurants_FullMethodName           = "/restaurantspb.RestaurantsService/ListRestaurants"
+	RestaurantsService_ImportRestaurants_FullMethodName         = "/restaurantspb.RestaurantsService/ImportRestaurants"
+	RestaurantsService_ExportRestaurants_FullMethodName         = "/restaurantspb.RestaurantsService/ExportRestaurants"
+	RestaurantsService_MergeRestaurants_FullMethodName          = "/restaurantspb.RestaurantsService/MergeRestaurants"
+	RestaurantsService_ListDuplicateCandidates_FullMethodName   = "/restaurantspb.RestaurantsService/ListDuplicateCandidates"
+	RestaurantsService_DismissDuplicateCandidate_FullMethodName = "/restaurantspb.RestaurantsService/DismissDuplicateCandidate"
+	RestaurantsService_DetectDuplicates_FullMethodName          = "/restaurantspb.RestaurantsService/DetectDuplicates"
 )
 
 // RestaurantsServiceClient is the client API for RestaurantsService service.
@@ -27,6 +35,18 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RestaurantsServiceClient interface {
 	RegisterRestaurant(ctx context.Context, in *RegisterRestaurantRequest, opts ...grpc.CallOption) (*RegisterRestaurantResponse, error)
+	UpdateRestaurant(ctx context.Context, in *UpdateRestaurantRequest, opts ...grpc.CallOption) (*UpdateRestaurantResponse, error)
+	ListRestaurants(ctx context.Context, in *ListRestaurantsRequest, opts ...grpc.CallOption) (*ListRestaurantsResponse, error)
+	// ImportRestaurants answers every record sent with its outcome; records
+	// that fail do not stop the rest of the import
+	ImportRestaurants(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportRestaurantsRequest, ImportRestaurantsResponse], error)
+	ExportRestaurants(ctx context.Context, in *ExportRestaurantsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportRestaurantsResponse], error)
+	// MergeRestaurants retires the merged restaurant as a duplicate of the
+	// survivor; its reviews and visits move over to the survivor
+	MergeRestaurants(ctx context.Context, in *MergeRestaurantsRequest, opts ...grpc.CallOption) (*MergeRestaurantsResponse, error)
+	ListDuplicateCandidates(ctx context.Context, in *ListDuplicateCandidatesRequest, opts ...grpc.CallOption) (*ListDuplicateCandidatesResponse, error)
+	DismissDuplicateCandidate(ctx context.Context, in *DismissDuplicateCandidateRequest, opts ...grpc.CallOption) (*DismissDuplicateCandidateResponse, error)
+	DetectDuplicates(ctx context.Context, in *DetectDuplicatesRequest, opts ...grpc.CallOption) (*DetectDuplicatesResponse, error)
 }
 
 type restaurantsServiceClient struct {
@@ -47,11 +67,115 @@ func (c *restaurantsServiceClient) RegisterRestaurant(ctx context.Context, in *R
 	return out, nil
 }
 
+func (c *restaurantsServiceClient) UpdateRestaurant(ctx context.Context, in *UpdateRestaurantRequest, opts ...grpc.CallOption) (*UpdateRestaurantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRestaurantResponse)
+	err := c.cc.Invoke(ctx, RestaurantsService_UpdateRestaurant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantsServiceClient) ListRestaurants(ctx context.Context, in *ListRestaurantsRequest, opts ...grpc.CallOption) (*ListRestaurantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRestaurantsResponse)
+	err := c.cc.Invoke(ctx, RestaurantsService_ListRestaurants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantsServiceClient) ImportRestaurants(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportRestaurantsRequest, ImportRestaurantsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RestaurantsService_ServiceDesc.Streams[0], RestaurantsService_ImportRestaurants_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportRestaurantsRequest, ImportRestaurantsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RestaurantsService_ImportRestaurantsClient = grpc.BidiStreamingClient[ImportRestaurantsRequest, ImportRestaurantsResponse]
+
+func (c *restaurantsServiceClient) ExportRestaurants(ctx context.Context, in *ExportRestaurantsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportRestaurantsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RestaurantsService_ServiceDesc.Streams[1], RestaurantsService_ExportRestaurants_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRestaurantsRequest, ExportRestaurantsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RestaurantsService_ExportRestaurantsClient = grpc.ServerStreamingClient[ExportRestaurantsResponse]
+
+func (c *restaurantsServiceClient) MergeRestaurants(ctx context.Context, in *MergeRestaurantsRequest, opts ...grpc.CallOption) (*MergeRestaurantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeRestaurantsResponse)
+	err := c.cc.Invoke(ctx, RestaurantsService_MergeRestaurants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantsServiceClient) ListDuplicateCandidates(ctx context.Context, in *ListDuplicateCandidatesRequest, opts ...grpc.CallOption) (*ListDuplicateCandidatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDuplicateCandidatesResponse)
+	err := c.cc.Invoke(ctx, RestaurantsService_ListDuplicateCandidates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantsServiceClient) DismissDuplicateCandidate(ctx context.Context, in *DismissDuplicateCandidateRequest, opts ...grpc.CallOption) (*DismissDuplicateCandidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DismissDuplicateCandidateResponse)
+	err := c.cc.Invoke(ctx, RestaurantsService_DismissDuplicateCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantsServiceClient) DetectDuplicates(ctx context.Context, in *DetectDuplicatesRequest, opts ...grpc.CallOption) (*DetectDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectDuplicatesResponse)
+	err := c.cc.Invoke(ctx, RestaurantsService_DetectDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RestaurantsServiceServer is the server API for RestaurantsService service.
 // All implementations must embed UnimplementedRestaurantsServiceServer
 // for forward compatibility.
 type RestaurantsServiceServer interface {
 	RegisterRestaurant(context.Context, *RegisterRestaurantRequest) (*RegisterRestaurantResponse, error)
+	UpdateRestaurant(context.Context, *UpdateRestaurantRequest) (*UpdateRestaurantResponse, error)
+	ListRestaurants(context.Context, *ListRestaurantsRequest) (*ListRestaurantsResponse, error)
+	// ImportRestaurants answers every record sent with its outcome; records
+	// that fail do not stop the rest of the import
+	ImportRestaurants(grpc.BidiStreamingServer[ImportRestaurantsRequest, ImportRestaurantsResponse]) error
+	ExportRestaurants(*ExportRestaurantsRequest, grpc.ServerStreamingServer[ExportRestaurantsResponse]) error
+	// MergeRestaurants retires the merged restaurant as a duplicate of the
+	// survivor; its reviews and visits move over to the survivor
+	MergeRestaurants(context.Context, *MergeRestaurantsRequest) (*MergeRestaurantsResponse, error)
+	ListDuplicateCandidates(context.Context, *ListDuplicateCandidatesRequest) (*ListDuplicateCandidatesResponse, error)
+	DismissDuplicateCandidate(context.Context, *DismissDuplicateCandidateRequest) (*DismissDuplicateCandidateResponse, error)
+	DetectDuplicates(context.Context, *DetectDuplicatesRequest) (*DetectDuplicatesResponse, error)
 	mustEmbedUnimplementedRestaurantsServiceServer()
 }
 
@@ -65,6 +189,30 @@ type UnimplementedRestaurantsServiceServer struct{}
 func (UnimplementedRestaurantsServiceServer) RegisterRestaurant(context.Context, *RegisterRestaurantRequest) (*RegisterRestaurantResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterRestaurant not implemented")
 }
+func (UnimplementedRestaurantsServiceServer) UpdateRestaurant(context.Context, *UpdateRestaurantRequest) (*UpdateRestaurantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRestaurant not implemented")
+}
+func (UnimplementedRestaurantsServiceServer) ListRestaurants(context.Context, *ListRestaurantsRequest) (*ListRestaurantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRestaurants not implemented")
+}
+func (UnimplementedRestaurantsServiceServer) ImportRestaurants(grpc.BidiStreamingServer[ImportRestaurantsRequest, ImportRestaurantsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportRestaurants not implemented")
+}
+func (UnimplementedRestaurantsServiceServer) ExportRestaurants(*ExportRestaurantsRequest, grpc.ServerStreamingServer[ExportRestaurantsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRestaurants not implemented")
+}
+func (UnimplementedRestaurantsServiceServer) MergeRestaurants(context.Context, *MergeRestaurantsRequest) (*MergeRestaurantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeRestaurants not implemented")
+}
+func (UnimplementedRestaurantsServiceServer) ListDuplicateCandidates(context.Context, *ListDuplicateCandidatesRequest) (*ListDuplicateCandidatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDuplicateCandidates not implemented")
+}
+func (UnimplementedRestaurantsServiceServer) DismissDuplicateCandidate(context.Context, *DismissDuplicateCandidateRequest) (*DismissDuplicateCandidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DismissDuplicateCandidate not implemented")
+}
+func (UnimplementedRestaurantsServiceServer) DetectDuplicates(context.Context, *DetectDuplicatesRequest) (*DetectDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetectDuplicates not implemented")
+}
 func (UnimplementedRestaurantsServiceServer) mustEmbedUnimplementedRestaurantsServiceServer() {}
 func (UnimplementedRestaurantsServiceServer) testEmbeddedByValue()                            {}
 
@@ -104,6 +252,132 @@ func _RestaurantsService_RegisterRestaurant_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RestaurantsService_UpdateRestaurant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRestaurantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantsServiceServer).UpdateRestaurant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantsService_UpdateRestaurant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantsServiceServer).UpdateRestaurant(ctx, req.(*UpdateRestaurantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantsService_ListRestaurants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRestaurantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantsServiceServer).ListRestaurants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantsService_ListRestaurants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantsServiceServer).ListRestaurants(ctx, req.(*ListRestaurantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantsService_ImportRestaurants_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RestaurantsServiceServer).ImportRestaurants(&grpc.GenericServerStream[ImportRestaurantsRequest, ImportRestaurantsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RestaurantsService_ImportRestaurantsServer = grpc.BidiStreamingServer[ImportRestaurantsRequest, ImportRestaurantsResponse]
+
+func _RestaurantsService_ExportRestaurants_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRestaurantsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RestaurantsServiceServer).ExportRestaurants(m, &grpc.GenericServerStream[ExportRestaurantsRequest, ExportRestaurantsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RestaurantsService_ExportRestaurantsServer = grpc.ServerStreamingServer[ExportRestaurantsResponse]
+
+func _RestaurantsService_MergeRestaurants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRestaurantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantsServiceServer).MergeRestaurants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantsService_MergeRestaurants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantsServiceServer).MergeRestaurants(ctx, req.(*MergeRestaurantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantsService_ListDuplicateCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDuplicateCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantsServiceServer).ListDuplicateCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantsService_ListDuplicateCandidates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantsServiceServer).ListDuplicateCandidates(ctx, req.(*ListDuplicateCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantsService_DismissDuplicateCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DismissDuplicateCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantsServiceServer).DismissDuplicateCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantsService_DismissDuplicateCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantsServiceServer).DismissDuplicateCandidate(ctx, req.(*DismissDuplicateCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantsService_DetectDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantsServiceServer).DetectDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantsService_DetectDuplicates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantsServiceServer).DetectDuplicates(ctx, req.(*DetectDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RestaurantsService_ServiceDesc is the grpc.ServiceDesc for RestaurantsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +389,43 @@ var RestaurantsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterRestaurant",
 			Handler:    _RestaurantsService_RegisterRestaurant_Handler,
 		},
+		{
+			MethodName: "UpdateRestaurant",
+			Handler:    _RestaurantsService_UpdateRestaurant_Handler,
+		},
+		{
+			MethodName: "ListRestaurants",
+			Handler:    _RestaurantsService_ListRestaurants_Handler,
+		},
+		{
+			MethodName: "MergeRestaurants",
+			Handler:    _RestaurantsService_MergeRestaurants_Handler,
+		},
+		{
+			MethodName: "ListDuplicateCandidates",
+			Handler:    _RestaurantsService_ListDuplicateCandidates_Handler,
+		},
+		{
+			MethodName: "DismissDuplicateCandidate",
+			Handler:    _RestaurantsService_DismissDuplicateCandidate_Handler,
+		},
+		{
+			MethodName: "DetectDuplicates",
+			Handler:    _RestaurantsService_DetectDuplicates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportRestaurants",
+			Handler:       _RestaurantsService_ImportRestaurants_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportRestaurants",
+			Handler:       _RestaurantsService_ExportRestaurants_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "restaurantspb/api.proto",
 }
@@ -9,6 +9,8 @@ const (
 	RestaurantAggregateChannel = "lunchbox.restaurant.events.Restaurant"
 
 	RestaurantRegisteredEvent = "restaurantsapi.RestaurantRegistered"
+	RestaurantUpdatedEvent    = "restaurantsapi.RestaurantUpdated"
+	RestaurantMergedEvent     = "restaurantsapi.RestaurantMerged"
 )
 
 func Registrations(reg registry.Registry) error {
@@ -18,6 +20,12 @@ func Registrations(reg registry.Registry) error {
 	if err := serde.Register(&RestaurantRegistered{}); err != nil {
 		return err
 	}
+	if err := serde.Register(&RestaurantUpdated{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&RestaurantMerged{}); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -25,3 +33,11 @@ func Registrations(reg registry.Registry) error {
 func (*RestaurantRegistered) Key() string {
 	return RestaurantRegisteredEvent
 }
+
+func (*RestaurantUpdated) Key() string {
+	return RestaurantUpdatedEvent
+}
+
+func (*RestaurantMerged) Key() string {
+	return RestaurantMergedEvent
+}
@@ -0,0 +1,3 @@
+package reviews
+
+//go:generate buf generate
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/reviews/internal/application/commands"
+	"github.com/jongyunha/lunchbox/reviews/internal/application/queries"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+type (
+	App interface {
+		Commands
+		Queries
+	}
+
+	Commands interface {
+		SubmitReview(ctx context.Context, cmd commands.SubmitReview) error
+		EditReview(ctx context.Context, cmd commands.EditReview) error
+		DeleteReview(ctx context.Context, cmd commands.DeleteReview) error
+		MoveRestaurantReviews(ctx context.Context, cmd commands.MoveRestaurantReviews) error
+	}
+
+	Queries interface {
+		GetRestaurantRating(ctx context.Context, query queries.GetRestaurantRating) (*domain.RestaurantRating, error)
+		ListRestaurantReviews(ctx context.Context, query queries.ListRestaurantReviews) ([]*domain.RestaurantReview, error)
+	}
+
+	Application struct {
+		appCommands
+		appQueries
+	}
+
+	appCommands struct {
+		commands.SubmitReviewHandler
+		commands.EditReviewHandler
+		commands.DeleteReviewHandler
+		commands.MoveRestaurantReviewsHandler
+	}
+
+	appQueries struct {
+		queries.GetRestaurantRatingHandler
+		queries.ListRestaurantReviewsHandler
+	}
+)
+
+var _ App = (*Application)(nil)
+
+func New(
+	reviews domain.ReviewRepository,
+	restaurantReviews domain.RestaurantReviewRepository,
+	ratings domain.RatingRepository,
+	publisher ddd.EventPublisher[ddd.Event],
+) *Application {
+	return &Application{
+		appCommands: appCommands{
+			SubmitReviewHandler:          commands.NewSubmitReviewHandler(reviews, restaurantReviews, publisher),
+			EditReviewHandler:            commands.NewEditReviewHandler(reviews, publisher),
+			DeleteReviewHandler:          commands.NewDeleteReviewHandler(reviews, publisher),
+			MoveRestaurantReviewsHandler: commands.NewMoveRestaurantReviewsHandler(reviews, restaurantReviews, publisher),
+		},
+		appQueries: appQueries{
+			GetRestaurantRatingHandler:   queries.NewGetRestaurantRatingHandler(ratings),
+			ListRestaurantReviewsHandler: queries.NewListRestaurantReviewsHandler(restaurantReviews),
+		},
+	}
+}
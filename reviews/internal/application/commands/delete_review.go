@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+type (
+	DeleteReview struct {
+		ID     string
+		UserID string
+	}
+
+	DeleteReviewHandler struct {
+		reviews   domain.ReviewRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewDeleteReviewHandler(reviews domain.ReviewRepository, publisher ddd.EventPublisher[ddd.Event]) DeleteReviewHandler {
+	return DeleteReviewHandler{
+		reviews:   reviews,
+		publisher: publisher,
+	}
+}
+
+func (h DeleteReviewHandler) DeleteReview(ctx context.Context, cmd DeleteReview) error {
+	review, err := h.reviews.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := review.DeleteReview(cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = h.reviews.Save(ctx, review)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
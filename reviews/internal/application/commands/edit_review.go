@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+type (
+	EditReview struct {
+		ID      string
+		UserID  string
+		Rating  int
+		Comment string
+	}
+
+	EditReviewHandler struct {
+		reviews   domain.ReviewRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewEditReviewHandler(reviews domain.ReviewRepository, publisher ddd.EventPublisher[ddd.Event]) EditReviewHandler {
+	return EditReviewHandler{
+		reviews:   reviews,
+		publisher: publisher,
+	}
+}
+
+func (h EditReviewHandler) EditReview(ctx context.Context, cmd EditReview) error {
+	review, err := h.reviews.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := review.EditReview(cmd.UserID, cmd.Rating, cmd.Comment)
+	if err != nil {
+		return err
+	}
+
+	err = h.reviews.Save(ctx, review)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
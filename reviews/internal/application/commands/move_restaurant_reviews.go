@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+type (
+	// MoveRestaurantReviews moves the reviews of a restaurant that was merged
+	// into another one over to the survivor
+	MoveRestaurantReviews struct {
+		RestaurantID string
+		SurvivorID   string
+	}
+
+	MoveRestaurantReviewsHandler struct {
+		reviews           domain.ReviewRepository
+		restaurantReviews domain.RestaurantReviewRepository
+		publisher         ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewMoveRestaurantReviewsHandler(reviews domain.ReviewRepository, restaurantReviews domain.RestaurantReviewRepository, publisher ddd.EventPublisher[ddd.Event]) MoveRestaurantReviewsHandler {
+	return MoveRestaurantReviewsHandler{
+		reviews:           reviews,
+		restaurantReviews: restaurantReviews,
+		publisher:         publisher,
+	}
+}
+
+func (h MoveRestaurantReviewsHandler) MoveRestaurantReviews(ctx context.Context, cmd MoveRestaurantReviews) error {
+	restaurantReviews, err := h.restaurantReviews.FindByRestaurant(ctx, cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	for _, restaurantReview := range restaurantReviews {
+		if err = h.moveReview(ctx, restaurantReview, cmd.SurvivorID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moveReview keeps the one review per user per restaurant rule; a user who
+// reviewed both restaurants keeps the review of the survivor
+func (h MoveRestaurantReviewsHandler) moveReview(ctx context.Context, restaurantReview *domain.RestaurantReview, survivorID string) error {
+	existing, err := h.restaurantReviews.FindByRestaurantAndUser(ctx, survivorID, restaurantReview.UserID)
+	if err != nil {
+		return err
+	}
+
+	review, err := h.reviews.Load(ctx, restaurantReview.ID)
+	if err != nil {
+		return err
+	}
+
+	var event ddd.Event
+	if existing != nil {
+		event, err = review.DeleteReview(review.UserID)
+	} else {
+		event, err = review.MoveToRestaurant(survivorID)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = h.reviews.Save(ctx, review)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+type (
+	SubmitReview struct {
+		ID           string
+		RestaurantID string
+		UserID       string
+		Rating       int
+		Comment      string
+	}
+
+	SubmitReviewHandler struct {
+		reviews           domain.ReviewRepository
+		restaurantReviews domain.RestaurantReviewRepository
+		publisher         ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewSubmitReviewHandler(reviews domain.ReviewRepository, restaurantReviews domain.RestaurantReviewRepository, publisher ddd.EventPublisher[ddd.Event]) SubmitReviewHandler {
+	return SubmitReviewHandler{
+		reviews:           reviews,
+		restaurantReviews: restaurantReviews,
+		publisher:         publisher,
+	}
+}
+
+func (h SubmitReviewHandler) SubmitReview(ctx context.Context, cmd SubmitReview) error {
+	// one review per user per restaurant
+	existing, err := h.restaurantReviews.FindByRestaurantAndUser(ctx, cmd.RestaurantID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrReviewAlreadySubmitted
+	}
+
+	review, err := h.reviews.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := review.SubmitReview(cmd.RestaurantID, cmd.UserID, cmd.Rating, cmd.Comment)
+	if err != nil {
+		return err
+	}
+
+	err = h.reviews.Save(ctx, review)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+type (
+	GetRestaurantRating struct {
+		RestaurantID string
+	}
+
+	GetRestaurantRatingHandler struct {
+		ratings domain.RatingRepository
+	}
+)
+
+func NewGetRestaurantRatingHandler(ratings domain.RatingRepository) GetRestaurantRatingHandler {
+	return GetRestaurantRatingHandler{
+		ratings: ratings,
+	}
+}
+
+func (h GetRestaurantRatingHandler) GetRestaurantRating(ctx context.Context, query GetRestaurantRating) (*domain.RestaurantRating, error) {
+	return h.ratings.Find(ctx, query.RestaurantID)
+}
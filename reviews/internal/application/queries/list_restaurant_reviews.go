@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+type (
+	ListRestaurantReviews struct {
+		RestaurantID string
+	}
+
+	ListRestaurantReviewsHandler struct {
+		restaurantReviews domain.RestaurantReviewRepository
+	}
+)
+
+func NewListRestaurantReviewsHandler(restaurantReviews domain.RestaurantReviewRepository) ListRestaurantReviewsHandler {
+	return ListRestaurantReviewsHandler{
+		restaurantReviews: restaurantReviews,
+	}
+}
+
+func (h ListRestaurantReviewsHandler) ListRestaurantReviews(ctx context.Context, query ListRestaurantReviews) ([]*domain.RestaurantReview, error) {
+	return h.restaurantReviews.FindByRestaurant(ctx, query.RestaurantID)
+}
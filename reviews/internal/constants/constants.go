@@ -0,0 +1,33 @@
+package constants
+
+// ServiceName The name of this module/service
+const ServiceName = "reviews"
+
+// GRPC Service Names
+const (
+	ReviewsServiceName = "REVIEWS"
+)
+
+// Dependency Injection Keys
+const (
+	RegistryKey            = "registry"
+	DomainDispatcherKey    = "domainDispatcher"
+	DatabaseTransactionKey = "tx"
+	IdempotencyStoreKey    = "idempotencyStore"
+	MessagePublisherKey    = "messagePublisher"
+	MessageSubscriberKey   = "messageSubscriber"
+	EventPublisherKey      = "eventPublisher"
+	AggregateStoreKey      = "aggregateStore"
+	ApplicationKey         = "app"
+	DomainEventHandlersKey = "domainEventHandlers"
+	InboxStoreKey          = "inboxStore"
+
+	IntegrationEventHandlersKey = "integrationEventHandlers"
+
+	ReviewHandlersKey = "reviewHandlers"
+	RatingHandlersKey = "ratingHandlers"
+
+	ReviewsRepoKey           = "reviewsRepo"
+	RestaurantReviewsRepoKey = "restaurantReviewsRepo"
+	RatingsRepoKey           = "ratingsRepo"
+)
@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// RestaurantRating is the aggregated rating projection for a single restaurant
+type RestaurantRating struct {
+	RestaurantID string
+	Average      float64
+	Count        int
+	// Histogram holds the number of reviews for each star; index 0 is one star
+	Histogram [MaxRating]int
+}
+
+type RatingRepository interface {
+	// Refresh recalculates the rating of a restaurant from its current reviews
+	Refresh(ctx context.Context, restaurantID string) error
+	Find(ctx context.Context, restaurantID string) (*RestaurantRating, error)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+type RestaurantReview struct {
+	ID           string
+	RestaurantID string
+	UserID       string
+	Rating       int
+	Comment      string
+}
+
+type RestaurantReviewRepository interface {
+	Add(ctx context.Context, review *RestaurantReview) error
+	Update(ctx context.Context, review *RestaurantReview) error
+	Remove(ctx context.Context, reviewID string) error
+	FindByRestaurantAndUser(ctx context.Context, restaurantID, userID string) (*RestaurantReview, error)
+	FindByRestaurant(ctx context.Context, restaurantID string) ([]*RestaurantReview, error)
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/stackus/errors"
+)
+
+const (
+	ReviewAggregate = "reviews.Review"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+var (
+	ErrRestaurantIDIsBlank    = errors.Wrap(errors.ErrBadRequest, "the restaurant id cannot be blank")
+	ErrUserIDIsBlank          = errors.Wrap(errors.ErrBadRequest, "the user id cannot be blank")
+	ErrRatingOutOfRange       = errors.Wrap(errors.ErrBadRequest, "the rating must be between 1 and 5")
+	ErrReviewAlreadySubmitted = errors.Wrap(errors.ErrAlreadyExists, "the user has already reviewed this restaurant")
+	ErrReviewNotFound         = errors.Wrap(errors.ErrNotFound, "the review does not exist")
+	ErrReviewNotOwned         = errors.Wrap(errors.ErrPermissionDenied, "only the author of a review can change it")
+)
+
+type Review struct {
+	es.Aggregate
+	RestaurantID string
+	UserID       string
+	Rating       int
+	Comment      string
+	Deleted      bool
+}
+
+var _ interface {
+	es.EventApplier
+	es.Snapshotter
+} = (*Review)(nil)
+
+func (r *Review) ApplyEvent(event ddd.Event) error {
+	switch payload := event.Payload().(type) {
+	case *ReviewSubmitted:
+		r.RestaurantID = payload.RestaurantID
+		r.UserID = payload.UserID
+		r.Rating = payload.Rating
+		r.Comment = payload.Comment
+		r.Deleted = false
+	case *ReviewEdited:
+		r.Rating = payload.Rating
+		r.Comment = payload.Comment
+	case *ReviewDeleted:
+		r.Deleted = true
+	case *ReviewMoved:
+		r.RestaurantID = payload.RestaurantID
+	default:
+		return errors.ErrInternal.Msgf("%T received the event %s with unexpected payload %T", r, event.EventName(), payload)
+	}
+
+	return nil
+}
+
+func (r *Review) ApplySnapshot(snapshot es.Snapshot) error {
+	switch ss := snapshot.(type) {
+	case *ReviewV1:
+		r.RestaurantID = ss.RestaurantID
+		r.UserID = ss.UserID
+		r.Rating = ss.Rating
+		r.Comment = ss.Comment
+		r.Deleted = ss.Deleted
+	default:
+		return errors.ErrInternal.Msgf("%T received the unexpected snapshot %T", r, snapshot)
+	}
+
+	return nil
+}
+
+func (r *Review) ToSnapshot() es.Snapshot {
+	return ReviewV1{
+		RestaurantID: r.RestaurantID,
+		UserID:       r.UserID,
+		Rating:       r.Rating,
+		Comment:      r.Comment,
+		Deleted:      r.Deleted,
+	}
+}
+
+func (r *Review) SubmitReview(restaurantID, userID string, rating int, comment string) (ddd.Event, error) {
+	if restaurantID == "" {
+		return nil, ErrRestaurantIDIsBlank
+	}
+	if userID == "" {
+		return nil, ErrUserIDIsBlank
+	}
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+	if r.RestaurantID != "" && !r.Deleted {
+		return nil, ErrReviewAlreadySubmitted
+	}
+
+	r.AddEvent(ReviewSubmittedEvent, &ReviewSubmitted{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Rating:       rating,
+		Comment:      comment,
+	})
+
+	return ddd.NewEvent(ReviewSubmittedEvent, r), nil
+}
+
+func (r *Review) EditReview(userID string, rating int, comment string) (ddd.Event, error) {
+	if err := r.checkOwner(userID); err != nil {
+		return nil, err
+	}
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+
+	r.AddEvent(ReviewEditedEvent, &ReviewEdited{
+		Rating:  rating,
+		Comment: comment,
+	})
+
+	return ddd.NewEvent(ReviewEditedEvent, r), nil
+}
+
+func (r *Review) DeleteReview(userID string) (ddd.Event, error) {
+	if err := r.checkOwner(userID); err != nil {
+		return nil, err
+	}
+
+	r.AddEvent(ReviewDeletedEvent, &ReviewDeleted{})
+
+	return ddd.NewEvent(ReviewDeletedEvent, r), nil
+}
+
+// MoveToRestaurant moves the review over to the restaurant its restaurant was
+// merged into
+func (r *Review) MoveToRestaurant(restaurantID string) (ddd.Event, error) {
+	if restaurantID == "" {
+		return nil, ErrRestaurantIDIsBlank
+	}
+	if r.RestaurantID == "" || r.Deleted {
+		return nil, ErrReviewNotFound
+	}
+
+	previousRestaurantID := r.RestaurantID
+
+	r.AddEvent(ReviewMovedEvent, &ReviewMoved{
+		RestaurantID: restaurantID,
+	})
+
+	return ddd.NewEvent(ReviewMovedEvent, r, ddd.Metadata{PreviousRestaurantIDKey: previousRestaurantID}), nil
+}
+
+func (r *Review) checkOwner(userID string) error {
+	if r.RestaurantID == "" || r.Deleted {
+		return ErrReviewNotFound
+	}
+	if r.UserID != userID {
+		return ErrReviewNotOwned
+	}
+
+	return nil
+}
+
+func (Review) Key() string {
+	return ReviewAggregate
+}
+
+func validateRating(rating int) error {
+	if rating < MinRating || rating > MaxRating {
+		return ErrRatingOutOfRange
+	}
+
+	return nil
+}
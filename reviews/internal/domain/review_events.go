@@ -0,0 +1,38 @@
+package domain
+
+const (
+	ReviewSubmittedEvent = "reviews.ReviewSubmitted"
+	ReviewEditedEvent    = "reviews.ReviewEdited"
+	ReviewDeletedEvent   = "reviews.ReviewDeleted"
+	ReviewMovedEvent     = "reviews.ReviewMoved"
+)
+
+// PreviousRestaurantIDKey is the event metadata key holding the restaurant a
+// moved review was taken from
+const PreviousRestaurantIDKey = "PreviousRestaurantID"
+
+type ReviewSubmitted struct {
+	RestaurantID string
+	UserID       string
+	Rating       int
+	Comment      string
+}
+
+func (ReviewSubmitted) Key() string { return ReviewSubmittedEvent }
+
+type ReviewEdited struct {
+	Rating  int
+	Comment string
+}
+
+func (ReviewEdited) Key() string { return ReviewEditedEvent }
+
+type ReviewDeleted struct{}
+
+func (ReviewDeleted) Key() string { return ReviewDeletedEvent }
+
+type ReviewMoved struct {
+	RestaurantID string
+}
+
+func (ReviewMoved) Key() string { return ReviewMovedEvent }
@@ -0,0 +1,11 @@
+package domain
+
+type ReviewV1 struct {
+	RestaurantID string
+	UserID       string
+	Rating       int
+	Comment      string
+	Deleted      bool
+}
+
+func (ReviewV1) SnapshotName() string { return "reviews.ReviewV1" }
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/internal/rpc"
+	"github.com/jongyunha/lunchbox/reviews/internal/application"
+	"github.com/jongyunha/lunchbox/reviews/internal/application/commands"
+	"github.com/jongyunha/lunchbox/reviews/internal/application/queries"
+	"github.com/jongyunha/lunchbox/reviews/internal/constants"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+	"github.com/jongyunha/lunchbox/reviews/reviewspb"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+type server struct {
+	c di.Container
+	reviewspb.UnimplementedReviewsServiceServer
+}
+
+var _ reviewspb.ReviewsServiceServer = (*server)(nil)
+
+func RegisterServer(c di.Container, registrar grpc.ServiceRegistrar, logger zerolog.Logger) error {
+	reviewspb.RegisterReviewsServiceServer(
+		rpc.NewTransactionalRegistrar(registrar, c, logger,
+			idempotency.UnaryServerInterceptor(constants.IdempotencyStoreKey,
+				reviewspb.ReviewsService_SubmitReview_FullMethodName,
+				reviewspb.ReviewsService_EditReview_FullMethodName,
+				reviewspb.ReviewsService_DeleteReview_FullMethodName,
+			),
+		),
+		server{c: c},
+	)
+
+	return nil
+}
+
+// app returns the application of the scope attached to the context
+func (s server) app(ctx context.Context) application.App {
+	return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey)
+}
+
+func (s server) SubmitReview(ctx context.Context, request *reviewspb.SubmitReviewRequest) (*reviewspb.SubmitReviewResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	reviewID := uuid.New().String()
+
+	err = s.app(ctx).SubmitReview(ctx, commands.SubmitReview{
+		ID:           reviewID,
+		RestaurantID: request.GetRestaurantId(),
+		UserID:       userID,
+		Rating:       int(request.GetRating()),
+		Comment:      request.GetComment(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewspb.SubmitReviewResponse{
+		Id: reviewID,
+	}, nil
+}
+
+func (s server) EditReview(ctx context.Context, request *reviewspb.EditReviewRequest) (*reviewspb.EditReviewResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).EditReview(ctx, commands.EditReview{
+		ID:      request.GetId(),
+		UserID:  userID,
+		Rating:  int(request.GetRating()),
+		Comment: request.GetComment(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewspb.EditReviewResponse{}, nil
+}
+
+func (s server) DeleteReview(ctx context.Context, request *reviewspb.DeleteReviewRequest) (*reviewspb.DeleteReviewResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).DeleteReview(ctx, commands.DeleteReview{
+		ID:     request.GetId(),
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewspb.DeleteReviewResponse{}, nil
+}
+
+func (s server) GetRestaurantRating(ctx context.Context, request *reviewspb.GetRestaurantRatingRequest) (*reviewspb.GetRestaurantRatingResponse, error) {
+	rating, err := s.app(ctx).GetRestaurantRating(ctx, queries.GetRestaurantRating{
+		RestaurantID: request.GetRestaurantId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewspb.GetRestaurantRatingResponse{
+		Rating: s.ratingFromDomain(rating),
+	}, nil
+}
+
+func (s server) ListRestaurantReviews(ctx context.Context, request *reviewspb.ListRestaurantReviewsRequest) (*reviewspb.ListRestaurantReviewsResponse, error) {
+	reviews, err := s.app(ctx).ListRestaurantReviews(ctx, queries.ListRestaurantReviews{
+		RestaurantID: request.GetRestaurantId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &reviewspb.ListRestaurantReviewsResponse{
+		Reviews: make([]*reviewspb.Review, len(reviews)),
+	}
+	for i, review := range reviews {
+		resp.Reviews[i] = s.reviewFromDomain(review)
+	}
+
+	return resp, nil
+}
+
+func (s server) reviewFromDomain(review *domain.RestaurantReview) *reviewspb.Review {
+	return &reviewspb.Review{
+		Id:           review.ID,
+		RestaurantId: review.RestaurantID,
+		UserId:       review.UserID,
+		Rating:       int32(review.Rating),
+		Comment:      review.Comment,
+	}
+}
+
+func (s server) ratingFromDomain(rating *domain.RestaurantRating) *reviewspb.RestaurantRating {
+	histogram := make(map[int32]int32, len(rating.Histogram))
+	for i, count := range rating.Histogram {
+		histogram[int32(i+domain.MinRating)] = int32(count)
+	}
+
+	return &reviewspb.RestaurantRating{
+		RestaurantId: rating.RestaurantID,
+		Average:      rating.Average,
+		Count:        int32(rating.Count),
+		Histogram:    histogram,
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/reviews/internal/constants"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+	"github.com/jongyunha/lunchbox/reviews/reviewspb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type domainHandlers[T ddd.Event] struct {
+	publisher am.EventPublisher
+	ratings   domain.RatingRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*domainHandlers[ddd.Event])(nil)
+
+func NewDomainEventHandlers(publisher am.EventPublisher, ratings domain.RatingRepository) ddd.EventHandler[ddd.Event] {
+	return &domainHandlers[ddd.Event]{
+		publisher: publisher,
+		ratings:   ratings,
+	}
+}
+
+func RegisterDomainEventHandlers(subscriber ddd.EventSubscriber[ddd.Event], handlers ddd.EventHandler[ddd.Event]) {
+	subscriber.Subscribe(handlers,
+		domain.ReviewSubmittedEvent,
+		domain.ReviewEditedEvent,
+		domain.ReviewDeletedEvent,
+		domain.ReviewMovedEvent,
+	)
+}
+
+func RegisterDomainEventHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		domainHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.DomainEventHandlersKey)
+
+		return domainHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterDomainEventHandlers(subscriber, handlers)
+}
+
+func (d domainHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling domain event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled domain event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	span.AddEvent("Handling domain event", trace.WithAttributes(
+		attribute.String("Event", event.EventName()),
+	))
+
+	switch event.EventName() {
+	case domain.ReviewSubmittedEvent:
+		err = d.onReviewSubmitted(ctx, event)
+	case domain.ReviewEditedEvent:
+		err = d.onReviewEdited(ctx, event)
+	case domain.ReviewDeletedEvent:
+		err = d.onReviewDeleted(ctx, event)
+	case domain.ReviewMovedEvent:
+		// only the rating of the survivor matters; the restaurant the review
+		// was taken from has been merged away
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return d.publishRatingChanged(ctx, event.Payload().(*domain.Review).RestaurantID)
+}
+
+func (d domainHandlers[T]) onReviewSubmitted(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Review)
+	return d.publisher.Publish(ctx, reviewspb.ReviewAggregateChannel, ddd.NewEvent(
+		reviewspb.ReviewSubmittedEvent,
+		&reviewspb.ReviewSubmitted{
+			Id:           payload.ID(),
+			RestaurantId: payload.RestaurantID,
+			UserId:       payload.UserID,
+			Rating:       int32(payload.Rating),
+			Comment:      payload.Comment,
+		},
+	))
+}
+
+func (d domainHandlers[T]) onReviewEdited(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Review)
+	return d.publisher.Publish(ctx, reviewspb.ReviewAggregateChannel, ddd.NewEvent(
+		reviewspb.ReviewEditedEvent,
+		&reviewspb.ReviewEdited{
+			Id:           payload.ID(),
+			RestaurantId: payload.RestaurantID,
+			UserId:       payload.UserID,
+			Rating:       int32(payload.Rating),
+			Comment:      payload.Comment,
+		},
+	))
+}
+
+func (d domainHandlers[T]) onReviewDeleted(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Review)
+	return d.publisher.Publish(ctx, reviewspb.ReviewAggregateChannel, ddd.NewEvent(
+		reviewspb.ReviewDeletedEvent,
+		&reviewspb.ReviewDeleted{
+			Id:           payload.ID(),
+			RestaurantId: payload.RestaurantID,
+			UserId:       payload.UserID,
+			Rating:       int32(payload.Rating),
+		},
+	))
+}
+
+// publishRatingChanged relies on the rating handlers having refreshed the
+// projection earlier in the same transaction
+func (d domainHandlers[T]) publishRatingChanged(ctx context.Context, restaurantID string) error {
+	rating, err := d.ratings.Find(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	return d.publisher.Publish(ctx, reviewspb.RestaurantRatingChannel, ddd.NewEvent(
+		reviewspb.RestaurantRatingChangedEvent,
+		&reviewspb.RestaurantRatingChanged{
+			RestaurantId: rating.RestaurantID,
+			Average:      rating.Average,
+			Count:        int32(rating.Count),
+		},
+	))
+}
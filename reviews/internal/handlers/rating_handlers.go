@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/reviews/internal/constants"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type RatingHandlers[T ddd.Event] struct {
+	ratings domain.RatingRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*RatingHandlers[ddd.Event])(nil)
+
+func NewRatingHandlers(ratings domain.RatingRepository) *RatingHandlers[ddd.Event] {
+	return &RatingHandlers[ddd.Event]{
+		ratings: ratings,
+	}
+}
+
+func (h RatingHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling rating event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled rating event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.ReviewSubmittedEvent, domain.ReviewEditedEvent, domain.ReviewDeletedEvent:
+		return h.onReviewChanged(ctx, event)
+	case domain.ReviewMovedEvent:
+		return h.onReviewMoved(ctx, event)
+	}
+	return nil
+}
+
+func (h RatingHandlers[T]) onReviewChanged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Review)
+	return h.ratings.Refresh(ctx, payload.RestaurantID)
+}
+
+func (h RatingHandlers[T]) onReviewMoved(ctx context.Context, event ddd.Event) error {
+	if err := h.ratings.Refresh(ctx, event.Metadata().Get(domain.PreviousRestaurantIDKey).(string)); err != nil {
+		return err
+	}
+
+	return h.onReviewChanged(ctx, event)
+}
+
+func RegisterRatingHandlers(ratingHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(ratingHandlers,
+		domain.ReviewSubmittedEvent,
+		domain.ReviewEditedEvent,
+		domain.ReviewDeletedEvent,
+		domain.ReviewMovedEvent,
+	)
+}
+
+func RegisterRatingHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		ratingHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.RatingHandlersKey)
+
+		return ratingHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterRatingHandlers(handlers, subscriber)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/reviews/internal/constants"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ReviewHandlers[T ddd.Event] struct {
+	restaurantReviews domain.RestaurantReviewRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*ReviewHandlers[ddd.Event])(nil)
+
+func NewReviewHandlers(restaurantReviews domain.RestaurantReviewRepository) *ReviewHandlers[ddd.Event] {
+	return &ReviewHandlers[ddd.Event]{
+		restaurantReviews: restaurantReviews,
+	}
+}
+
+func (h ReviewHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling review event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled review event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.ReviewSubmittedEvent:
+		return h.onReviewSubmitted(ctx, event)
+	case domain.ReviewEditedEvent, domain.ReviewMovedEvent:
+		return h.onReviewEdited(ctx, event)
+	case domain.ReviewDeletedEvent:
+		return h.onReviewDeleted(ctx, event)
+	}
+	return nil
+}
+
+func (h ReviewHandlers[T]) onReviewSubmitted(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Review)
+	return h.restaurantReviews.Add(ctx, toRestaurantReview(payload))
+}
+
+func (h ReviewHandlers[T]) onReviewEdited(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Review)
+	return h.restaurantReviews.Update(ctx, toRestaurantReview(payload))
+}
+
+func (h ReviewHandlers[T]) onReviewDeleted(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Review)
+	return h.restaurantReviews.Remove(ctx, payload.ID())
+}
+
+func toRestaurantReview(review *domain.Review) *domain.RestaurantReview {
+	return &domain.RestaurantReview{
+		ID:           review.ID(),
+		RestaurantID: review.RestaurantID,
+		UserID:       review.UserID,
+		Rating:       review.Rating,
+		Comment:      review.Comment,
+	}
+}
+
+func RegisterReviewHandlers(reviewHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(reviewHandlers,
+		domain.ReviewSubmittedEvent,
+		domain.ReviewEditedEvent,
+		domain.ReviewDeletedEvent,
+		domain.ReviewMovedEvent,
+	)
+}
+
+func RegisterReviewHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		reviewHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.ReviewHandlersKey)
+
+		return reviewHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterReviewHandlers(handlers, subscriber)
+}
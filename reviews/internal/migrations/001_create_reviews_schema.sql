@@ -0,0 +1,80 @@
+-- +goose Up
+CREATE TABLE reviews.reviews (
+  id            text        NOT NULL,
+  restaurant_id text        NOT NULL,
+  user_id       text        NOT NULL,
+  rating        int         NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  comment       text        NOT NULL DEFAULT '',
+  created_at    timestamptz NOT NULL DEFAULT NOW(),
+  updated_at    timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+-- one review per user per restaurant
+CREATE UNIQUE INDEX reviews_restaurant_user_idx ON reviews.reviews (restaurant_id, user_id);
+
+CREATE TRIGGER created_at_reviews_trgr
+  BEFORE UPDATE
+  ON reviews.reviews
+  FOR EACH ROW EXECUTE PROCEDURE created_at_trigger();
+
+CREATE TRIGGER updated_at_reviews_trgr
+  BEFORE UPDATE
+  ON reviews.reviews
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE reviews.restaurant_ratings (
+  restaurant_id text        NOT NULL,
+  rating_count  int         NOT NULL DEFAULT 0,
+  rating_total  int         NOT NULL DEFAULT 0,
+  histogram     int[]       NOT NULL DEFAULT '{0,0,0,0,0}',
+  updated_at    timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (restaurant_id)
+);
+
+CREATE TRIGGER updated_at_restaurant_ratings_trgr
+  BEFORE UPDATE
+  ON reviews.restaurant_ratings
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE reviews.events (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  event_id       text        NOT NULL,
+  event_name     text        NOT NULL,
+  event_data     bytea       NOT NULL,
+  occurred_at    timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name, stream_version)
+);
+
+CREATE TABLE reviews.snapshots (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  snapshot_name  text        NOT NULL,
+  snapshot_data  bytea       NOT NULL,
+  updated_at     timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name)
+);
+
+CREATE TRIGGER updated_at_snapshots_trgr
+  BEFORE UPDATE
+  ON reviews.snapshots
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE reviews.outbox (
+  id           text        NOT NULL,
+  name         text        NOT NULL,
+  subject      text        NOT NULL,
+  data         bytea       NOT NULL,
+  metadata     bytea       NOT NULL,
+  sent_at      timestamptz NOT NULL,
+  published_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX reviews_unpublished_idx ON reviews.outbox (published_at) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE reviews.outbox, reviews.snapshots, reviews.events, reviews.restaurant_ratings, reviews.reviews;
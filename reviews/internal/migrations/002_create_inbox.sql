@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE reviews.inbox (
+  id          text        NOT NULL,
+  name        text        NOT NULL,
+  subject     text        NOT NULL,
+  data        bytea       NOT NULL,
+  metadata    bytea       NOT NULL,
+  sent_at     timestamptz NOT NULL,
+  received_at timestamptz NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- +goose Down
+DROP TABLE reviews.inbox;
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type RatingRepository struct {
+	tableName        string
+	reviewsTableName string
+	db               postgres.DBTX
+}
+
+var _ domain.RatingRepository = (*RatingRepository)(nil)
+
+func NewRatingRepository(tableName, reviewsTableName string, db postgres.DBTX) RatingRepository {
+	return RatingRepository{
+		tableName:        tableName,
+		reviewsTableName: reviewsTableName,
+		db:               db,
+	}
+}
+
+func (r RatingRepository) Refresh(ctx context.Context, restaurantID string) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (restaurant_id, rating_count, rating_total, histogram)
+		SELECT $1, count(*), coalesce(sum(rating), 0), ARRAY[
+			count(*) FILTER (WHERE rating = 1),
+			count(*) FILTER (WHERE rating = 2),
+			count(*) FILTER (WHERE rating = 3),
+			count(*) FILTER (WHERE rating = 4),
+			count(*) FILTER (WHERE rating = 5)
+		]
+		FROM %s
+		WHERE restaurant_id = $1
+		ON CONFLICT (restaurant_id) DO UPDATE
+		SET rating_count = EXCLUDED.rating_count,
+		    rating_total = EXCLUDED.rating_total,
+		    histogram = EXCLUDED.histogram;`, r.tableName, r.reviewsTableName)
+
+	_, err := r.db.Exec(ctx, query, restaurantID)
+
+	return err
+}
+
+func (r RatingRepository) Find(ctx context.Context, restaurantID string) (*domain.RestaurantRating, error) {
+	query := fmt.Sprintf("SELECT rating_count, rating_total, histogram FROM %s WHERE restaurant_id = $1", r.tableName)
+
+	rating := &domain.RestaurantRating{
+		RestaurantID: restaurantID,
+	}
+
+	var total int
+	var histogram []int32
+
+	err := r.db.QueryRow(ctx, query, restaurantID).Scan(&rating.Count, &total, &histogram)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// restaurants without any reviews have an empty rating
+			return rating, nil
+		}
+		return nil, err
+	}
+
+	if rating.Count > 0 {
+		rating.Average = float64(total) / float64(rating.Count)
+	}
+	for i := 0; i < len(rating.Histogram) && i < len(histogram); i++ {
+		rating.Histogram[i] = int(histogram[i])
+	}
+
+	return rating, nil
+}
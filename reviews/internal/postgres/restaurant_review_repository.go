@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type RestaurantReviewRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.RestaurantReviewRepository = (*RestaurantReviewRepository)(nil)
+
+func NewRestaurantReviewRepository(tableName string, db postgres.DBTX) RestaurantReviewRepository {
+	return RestaurantReviewRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r RestaurantReviewRepository) Add(ctx context.Context, review *domain.RestaurantReview) error {
+	const query = "INSERT INTO %s (id, restaurant_id, user_id, rating, comment) VALUES ($1, $2, $3, $4, $5)"
+
+	_, err := r.db.Exec(ctx, r.table(query), review.ID, review.RestaurantID, review.UserID, review.Rating, review.Comment)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return domain.ErrReviewAlreadySubmitted
+		}
+	}
+
+	return err
+}
+
+func (r RestaurantReviewRepository) Update(ctx context.Context, review *domain.RestaurantReview) error {
+	const query = "UPDATE %s SET restaurant_id = $2, rating = $3, comment = $4 WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), review.ID, review.RestaurantID, review.Rating, review.Comment)
+
+	return err
+}
+
+func (r RestaurantReviewRepository) Remove(ctx context.Context, reviewID string) error {
+	const query = "DELETE FROM %s WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), reviewID)
+
+	return err
+}
+
+func (r RestaurantReviewRepository) FindByRestaurantAndUser(ctx context.Context, restaurantID, userID string) (*domain.RestaurantReview, error) {
+	const query = "SELECT id, restaurant_id, user_id, rating, comment FROM %s WHERE restaurant_id = $1 AND user_id = $2 LIMIT 1"
+
+	review := &domain.RestaurantReview{}
+
+	err := r.db.QueryRow(ctx, r.table(query), restaurantID, userID).Scan(
+		&review.ID, &review.RestaurantID, &review.UserID, &review.Rating, &review.Comment,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r RestaurantReviewRepository) FindByRestaurant(ctx context.Context, restaurantID string) ([]*domain.RestaurantReview, error) {
+	const query = "SELECT id, restaurant_id, user_id, rating, comment FROM %s WHERE restaurant_id = $1 ORDER BY created_at DESC"
+
+	rows, err := r.db.Query(ctx, r.table(query), restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []*domain.RestaurantReview
+	for rows.Next() {
+		review := &domain.RestaurantReview{}
+		if err := rows.Scan(&review.ID, &review.RestaurantID, &review.UserID, &review.Rating, &review.Comment); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r RestaurantReviewRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
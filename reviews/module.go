@@ -0,0 +1,282 @@
+package reviews
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/amotel"
+	"github.com/jongyunha/lunchbox/internal/amprom"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	pg "github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/postgresotel"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/jongyunha/lunchbox/internal/tm"
+	"github.com/jongyunha/lunchbox/restaurants/restaurantspb"
+	"github.com/jongyunha/lunchbox/reviews/internal/application"
+	"github.com/jongyunha/lunchbox/reviews/internal/constants"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+	"github.com/jongyunha/lunchbox/reviews/internal/grpc"
+	"github.com/jongyunha/lunchbox/reviews/internal/handlers"
+	"github.com/jongyunha/lunchbox/reviews/internal/migrations"
+	"github.com/jongyunha/lunchbox/reviews/internal/postgres"
+	"github.com/jongyunha/lunchbox/reviews/internal/rest"
+	"github.com/jongyunha/lunchbox/reviews/reviewspb"
+	"github.com/rs/zerolog"
+)
+
+type Module struct{}
+
+func (m *Module) Startup(ctx context.Context, svc system.Service) (err error) {
+	return Root(ctx, svc)
+}
+
+// Migrations returns the migrations of the module schema
+func (m *Module) Migrations() pg.Migrations {
+	return pg.Migrations{
+		Schema: constants.ServiceName,
+		FS:     migrations.FS,
+	}
+}
+
+// Registry returns a registry with everything the module serializes
+func (m *Module) Registry() (registry.Registry, error) {
+	return newRegistry()
+}
+
+// Projections returns the read models that can be rebuilt from the event store
+func (m *Module) Projections() []pg.Projection {
+	return []pg.Projection{{
+		Name:          "reviews",
+		AggregateName: domain.ReviewAggregate,
+		Tables: []string{
+			constants.ServiceName + ".reviews",
+			constants.ServiceName + ".restaurant_ratings",
+		},
+		Handlers: func(db pg.DBTX) []ddd.EventHandler[ddd.Event] {
+			// the order matters; the ratings are refreshed from the reviews
+			return []ddd.EventHandler[ddd.Event]{
+				handlers.NewReviewHandlers(postgres.NewRestaurantReviewRepository(constants.ServiceName+".reviews", db)),
+				handlers.NewRatingHandlers(postgres.NewRatingRepository(
+					constants.ServiceName+".restaurant_ratings",
+					constants.ServiceName+".reviews",
+					db,
+				)),
+			}
+		},
+	}}
+}
+
+func Root(ctx context.Context, svc system.Service) (err error) {
+	container := di.New()
+
+	// setup Driven adapters
+	di.Register(container, di.Singleton, constants.RegistryKey, func(c di.Container) (registry.Registry, error) {
+		return newRegistry()
+	})
+
+	stream := svc.Stream()
+
+	di.Register(container, di.Singleton, constants.DomainDispatcherKey, func(c di.Container) (*ddd.EventDispatcher[ddd.Event], error) {
+		return ddd.NewEventDispatcher[ddd.Event](), nil
+	})
+
+	di.Register(container, di.Scoped, constants.DatabaseTransactionKey, func(c di.Container) (pgx.Tx, error) {
+		return svc.DB().Begin(context.Background())
+	}, di.Finalizer(pg.EndTransaction(svc.Logger())))
+	di.Register(container, di.Scoped, constants.IdempotencyStoreKey, func(c di.Container) (idempotency.Store, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewIdempotencyStore(constants.ServiceName+".idempotency_keys", tx), nil
+	})
+	sentCounter := amprom.SentMessagesCounter(constants.ServiceName)
+	di.Register(container, di.Scoped, constants.MessagePublisherKey, func(c di.Container) (am.MessagePublisher, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		outboxStore := pg.NewOutboxStore(constants.ServiceName+".outbox", tx)
+		return am.NewMessagePublisher(
+			stream,
+			amotel.OtelMessageContextInjector(),
+			sentCounter,
+			tm.OutboxPublisher(outboxStore),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.EventPublisherKey, func(c di.Container) (am.EventPublisher, error) {
+		return am.NewEventPublisher(
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[am.MessagePublisher](c, constants.MessagePublisherKey),
+		), nil
+	})
+
+	di.Register(container, di.Singleton, constants.MessageSubscriberKey, func(c di.Container) (am.MessageSubscriber, error) {
+		return am.NewMessageSubscriber(
+			stream,
+			amotel.OtelMessageContextExtractor(),
+			amprom.ReceivedMessagesCounter(constants.ServiceName),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.InboxStoreKey, func(c di.Container) (tm.InboxStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewInboxStore(constants.ServiceName+".inbox", tx), nil
+	})
+
+	di.Register(container, di.Scoped, constants.AggregateStoreKey, func(c di.Container) (es.AggregateStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		reg := di.MustGet[registry.Registry](c, constants.RegistryKey)
+		return es.AggregateStoreWithMiddleware(
+			pg.NewEventStore(constants.ServiceName+".events", tx, reg),
+			pg.NewSnapshotStore(constants.ServiceName+".snapshots", tx, reg),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.ReviewsRepoKey, func(c di.Container) (es.AggregateRepository[*domain.Review], error) {
+		return es.NewAggregateRepository[*domain.Review](
+			domain.ReviewAggregate,
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[es.AggregateStore](c, constants.AggregateStoreKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.RestaurantReviewsRepoKey, func(c di.Container) (domain.RestaurantReviewRepository, error) {
+		return postgres.NewRestaurantReviewRepository(
+			constants.ServiceName+".reviews",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.RatingsRepoKey, func(c di.Container) (domain.RatingRepository, error) {
+		return postgres.NewRatingRepository(
+			constants.ServiceName+".restaurant_ratings",
+			constants.ServiceName+".reviews",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.ApplicationKey, func(c di.Container) (application.App, error) {
+		return application.New(
+			di.MustGet[es.AggregateRepository[*domain.Review]](c, constants.ReviewsRepoKey),
+			di.MustGet[domain.RestaurantReviewRepository](c, constants.RestaurantReviewsRepoKey),
+			di.MustGet[domain.RatingRepository](c, constants.RatingsRepoKey),
+			di.MustGet[ddd.EventPublisher[ddd.Event]](c, constants.DomainDispatcherKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.ReviewHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewReviewHandlers(di.MustGet[domain.RestaurantReviewRepository](c, constants.RestaurantReviewsRepoKey)), nil
+	})
+	di.Register(container, di.Scoped, constants.RatingHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewRatingHandlers(di.MustGet[domain.RatingRepository](c, constants.RatingsRepoKey)), nil
+	})
+	di.Register(container, di.Scoped, constants.DomainEventHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewDomainEventHandlers(
+			di.MustGet[am.EventPublisher](c, constants.EventPublisherKey),
+			di.MustGet[domain.RatingRepository](c, constants.RatingsRepoKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.IntegrationEventHandlersKey, func(c di.Container) (am.MessageHandler, error) {
+		return am.NewEventHandler(
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			handlers.NewIntegrationEventHandlers(di.MustGet[application.App](c, constants.ApplicationKey)),
+			tm.InboxHandler(di.MustGet[tm.InboxStore](c, constants.InboxStoreKey)),
+		), nil
+	})
+
+	outboxProcessor := tm.NewOutboxProcessor(
+		stream,
+		pg.NewOutboxStore(constants.ServiceName+".outbox", svc.DB()),
+	)
+
+	if err = container.Validate(ctx); err != nil {
+		return err
+	}
+	svc.Waiter().Cleanup(func() {
+		_ = container.Close(context.Background())
+	})
+	svc.Health().Register(constants.ServiceName+".outbox", pg.OutboxBacklogCheck(
+		constants.ServiceName+".outbox", svc.DB(), svc.Config().Health.OutboxBacklog,
+	))
+
+	// setup Driver adapters
+	if err = grpc.RegisterServer(container, svc.RPC(), svc.Logger()); err != nil {
+		return err
+	}
+	if err = rest.RegisterGateway(ctx, svc.Mux(), svc.Config().Rpc.Address()); err != nil {
+		return err
+	}
+	if err = rest.RegisterSwagger(svc.Mux()); err != nil {
+		return err
+	}
+	// the order matters; the rating projection must be refreshed before the
+	// domain event handlers publish the new rating
+	handlers.RegisterReviewHandlersTx(container)
+	handlers.RegisterRatingHandlersTx(container)
+	handlers.RegisterDomainEventHandlersTx(container)
+	if err = handlers.RegisterIntegrationEventHandlersTx(container); err != nil {
+		return err
+	}
+	startOutboxProcessor(ctx, outboxProcessor, svc.Logger())
+	return nil
+}
+
+// newRegistry registers everything the module serializes
+func newRegistry() (reg registry.Registry, err error) {
+	reg = registry.New()
+	if err = registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = reviewspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = restaurantspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+func registrations(reg registry.Registry) (err error) {
+	serde := serdes.NewJsonSerde(reg)
+
+	// Review
+	if err = serde.Register(domain.Review{}, func(v any) error {
+		review := v.(*domain.Review)
+		review.Aggregate = es.NewAggregate("", domain.ReviewAggregate)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// Review events
+	if err = serde.Register(domain.ReviewSubmitted{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.ReviewEdited{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.ReviewDeleted{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.ReviewMoved{}); err != nil {
+		return
+	}
+
+	// Review snapshot
+	if err = serde.RegisterKey(domain.ReviewV1{}.SnapshotName(), domain.ReviewV1{}); err != nil {
+		return
+	}
+	return nil
+}
+
+func startOutboxProcessor(ctx context.Context, outboxProcessor tm.OutboxProcessor, logger zerolog.Logger) {
+	go func() {
+		err := outboxProcessor.Start(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msg("reviews outbox processor encountered an error")
+		}
+	}()
+}
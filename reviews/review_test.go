@@ -0,0 +1,107 @@
+package reviews
+
+import (
+	"testing"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es/estest"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/reviews/internal/domain"
+)
+
+func submitted() ddd.Event {
+	return ddd.NewEvent(domain.ReviewSubmittedEvent, &domain.ReviewSubmitted{
+		RestaurantID: "restaurant-id",
+		UserID:       "author-id",
+		Rating:       4,
+		Comment:      "Good noodles",
+	})
+}
+
+func deleted() ddd.Event {
+	return ddd.NewEvent(domain.ReviewDeletedEvent, &domain.ReviewDeleted{})
+}
+
+// TestReviewOwnership covers the checks made before a review is changed
+func TestReviewOwnership(t *testing.T) {
+	reg := registry.New()
+	if err := registrations(reg); err != nil {
+		t.Fatal(err)
+	}
+
+	edit := func(userID string) func(r *domain.Review) error {
+		return func(r *domain.Review) error {
+			_, err := r.EditReview(userID, 5, "Great noodles")
+			return err
+		}
+	}
+	remove := func(userID string) func(r *domain.Review) error {
+		return func(r *domain.Review) error {
+			_, err := r.DeleteReview(userID)
+			return err
+		}
+	}
+	edited := ddd.NewEvent(domain.ReviewEditedEvent, &domain.ReviewEdited{Rating: 5, Comment: "Great noodles"})
+
+	tests := map[string]struct {
+		given   []ddd.Event
+		when    func(r *domain.Review) error
+		then    []ddd.Event
+		wantErr error
+	}{
+		"the author edits": {
+			given: []ddd.Event{submitted()},
+			when:  edit("author-id"),
+			then:  []ddd.Event{edited},
+		},
+		"another user edits": {
+			given:   []ddd.Event{submitted()},
+			when:    edit("someone-else"),
+			wantErr: domain.ErrReviewNotOwned,
+		},
+		"editing a review never submitted": {
+			when:    edit("author-id"),
+			wantErr: domain.ErrReviewNotFound,
+		},
+		"editing a deleted review": {
+			given:   []ddd.Event{submitted(), deleted()},
+			when:    edit("author-id"),
+			wantErr: domain.ErrReviewNotFound,
+		},
+		"the author deletes": {
+			given: []ddd.Event{submitted()},
+			when:  remove("author-id"),
+			then:  []ddd.Event{deleted()},
+		},
+		"another user deletes": {
+			given:   []ddd.Event{submitted()},
+			when:    remove("someone-else"),
+			wantErr: domain.ErrReviewNotOwned,
+		},
+		"deleting a deleted review": {
+			given:   []ddd.Event{submitted(), deleted()},
+			when:    remove("author-id"),
+			wantErr: domain.ErrReviewNotFound,
+		},
+		"the author of a moved review edits": {
+			given: []ddd.Event{
+				submitted(),
+				ddd.NewEvent(domain.ReviewMovedEvent, &domain.ReviewMoved{RestaurantID: "survivor-id"}),
+			},
+			when: edit("author-id"),
+			then: []ddd.Event{edited},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scenario := estest.For[*domain.Review](t, reg, domain.ReviewAggregate, "review-id").
+				Given(tc.given...).
+				When(tc.when)
+			if tc.wantErr != nil {
+				scenario.ThenError(tc.wantErr)
+				return
+			}
+			scenario.Then(tc.then...)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: reviewspb/api.proto
+
+package reviewspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReviewsService_SubmitReview_FullMethodName          = "/reviewspb.ReviewsService/SubmitReview"
+	ReviewsService_EditReview_FullMethodName            = "/reviewspb.ReviewsService/EditReview"
+	ReviewsService_DeleteReview_FullMethodName          = "/reviewspb.ReviewsService/DeleteReview"
+	ReviewsService_GetRestaurantRating_FullMethodName   = "/reviewspb.ReviewsService/GetRestaurantRating"
+	ReviewsService_ListRestaurantReviews_FullMethodName = "/reviewspb.ReviewsService/ListRestaurantReviews"
+)
+
+// ReviewsServiceClient is the client API for ReviewsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReviewsServiceClient interface {
+	SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*SubmitReviewResponse, error)
+	EditReview(ctx context.Context, in *EditReviewRequest, opts ...grpc.CallOption) (*EditReviewResponse, error)
+	DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpc.CallOption) (*DeleteReviewResponse, error)
+	GetRestaurantRating(ctx context.Context, in *GetRestaurantRatingRequest, opts ...grpc.CallOption) (*GetRestaurantRatingResponse, error)
+	ListRestaurantReviews(ctx context.Context, in *ListRestaurantReviewsRequest, opts ...grpc.CallOption) (*ListRestaurantReviewsResponse, error)
+}
+
+type reviewsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReviewsServiceClient(cc grpc.ClientConnInterface) ReviewsServiceClient {
+	return &reviewsServiceClient{cc}
+}
+
+func (c *reviewsServiceClient) SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*SubmitReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitReviewResponse)
+	err := c.cc.Invoke(ctx, ReviewsService_SubmitReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewsServiceClient) EditReview(ctx context.Context, in *EditReviewRequest, opts ...grpc.CallOption) (*EditReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditReviewResponse)
+	err := c.cc.Invoke(ctx, ReviewsService_EditReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewsServiceClient) DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpc.CallOption) (*DeleteReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteReviewResponse)
+	err := c.cc.Invoke(ctx, ReviewsService_DeleteReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewsServiceClient) GetRestaurantRating(ctx context.Context, in *GetRestaurantRatingRequest, opts ...grpc.CallOption) (*GetRestaurantRatingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRestaurantRatingResponse)
+	err := c.cc.Invoke(ctx, ReviewsService_GetRestaurantRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewsServiceClient) ListRestaurantReviews(ctx context.Context, in *ListRestaurantReviewsRequest, opts ...grpc.CallOption) (*ListRestaurantReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRestaurantReviewsResponse)
+	err := c.cc.Invoke(ctx, ReviewsService_ListRestaurantReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReviewsServiceServer is the server API for ReviewsService service.
+// All implementations must embed UnimplementedReviewsServiceServer
+// for forward compatibility.
+type ReviewsServiceServer interface {
+	SubmitReview(context.Context, *SubmitReviewRequest) (*SubmitReviewResponse, error)
+	EditReview(context.Context, *EditReviewRequest) (*EditReviewResponse, error)
+	DeleteReview(context.Context, *DeleteReviewRequest) (*DeleteReviewResponse, error)
+	GetRestaurantRating(context.Context, *GetRestaurantRatingRequest) (*GetRestaurantRatingResponse, error)
+	ListRestaurantReviews(context.Context, *ListRestaurantReviewsRequest) (*ListRestaurantReviewsResponse, error)
+	mustEmbedUnimplementedReviewsServiceServer()
+}
+
+// UnimplementedReviewsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReviewsServiceServer struct{}
+
+func (UnimplementedReviewsServiceServer) SubmitReview(context.Context, *SubmitReviewRequest) (*SubmitReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitReview not implemented")
+}
+func (UnimplementedReviewsServiceServer) EditReview(context.Context, *EditReviewRequest) (*EditReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditReview not implemented")
+}
+func (UnimplementedReviewsServiceServer) DeleteReview(context.Context, *DeleteReviewRequest) (*DeleteReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReview not implemented")
+}
+func (UnimplementedReviewsServiceServer) GetRestaurantRating(context.Context, *GetRestaurantRatingRequest) (*GetRestaurantRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRestaurantRating not implemented")
+}
+func (UnimplementedReviewsServiceServer) ListRestaurantReviews(context.Context, *ListRestaurantReviewsRequest) (*ListRestaurantReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRestaurantReviews not implemented")
+}
+func (UnimplementedReviewsServiceServer) mustEmbedUnimplementedReviewsServiceServer() {}
+func (UnimplementedReviewsServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeReviewsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReviewsServiceServer will
+// result in compilation errors.
+type UnsafeReviewsServiceServer interface {
+	mustEmbedUnimplementedReviewsServiceServer()
+}
+
+func RegisterReviewsServiceServer(s grpc.ServiceRegistrar, srv ReviewsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReviewsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReviewsService_ServiceDesc, srv)
+}
+
+func _ReviewsService_SubmitReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewsServiceServer).SubmitReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewsService_SubmitReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewsServiceServer).SubmitReview(ctx, req.(*SubmitReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewsService_EditReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewsServiceServer).EditReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewsService_EditReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewsServiceServer).EditReview(ctx, req.(*EditReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewsService_DeleteReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewsServiceServer).DeleteReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewsService_DeleteReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewsServiceServer).DeleteReview(ctx, req.(*DeleteReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewsService_GetRestaurantRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRestaurantRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewsServiceServer).GetRestaurantRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewsService_GetRestaurantRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewsServiceServer).GetRestaurantRating(ctx, req.(*GetRestaurantRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewsService_ListRestaurantReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRestaurantReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewsServiceServer).ListRestaurantReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewsService_ListRestaurantReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewsServiceServer).ListRestaurantReviews(ctx, req.(*ListRestaurantReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReviewsService_ServiceDesc is the grpc.ServiceDesc for ReviewsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReviewsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reviewspb.ReviewsService",
+	HandlerType: (*ReviewsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitReview",
+			Handler:    _ReviewsService_SubmitReview_Handler,
+		},
+		{
+			MethodName: "EditReview",
+			Handler:    _ReviewsService_EditReview_Handler,
+		},
+		{
+			MethodName: "DeleteReview",
+			Handler:    _ReviewsService_DeleteReview_Handler,
+		},
+		{
+			MethodName: "GetRestaurantRating",
+			Handler:    _ReviewsService_GetRestaurantRating_Handler,
+		},
+		{
+			MethodName: "ListRestaurantReviews",
+			Handler:    _ReviewsService_ListRestaurantReviews_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reviewspb/api.proto",
+}
@@ -0,0 +1,45 @@
+package reviewspb
+
+import (
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+)
+
+const (
+	ReviewAggregateChannel  = "lunchbox.reviews.events.Review"
+	RestaurantRatingChannel = "lunchbox.reviews.events.RestaurantRating"
+
+	ReviewSubmittedEvent = "reviewsapi.ReviewSubmitted"
+	ReviewEditedEvent    = "reviewsapi.ReviewEdited"
+	ReviewDeletedEvent   = "reviewsapi.ReviewDeleted"
+
+	RestaurantRatingChangedEvent = "reviewsapi.RestaurantRatingChanged"
+)
+
+func Registrations(reg registry.Registry) error {
+	serde := serdes.NewProtoSerde(reg)
+
+	// Review events
+	if err := serde.Register(&ReviewSubmitted{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&ReviewEdited{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&ReviewDeleted{}); err != nil {
+		return err
+	}
+
+	// Rating events
+	if err := serde.Register(&RestaurantRatingChanged{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (*ReviewSubmitted) Key() string { return ReviewSubmittedEvent }
+func (*ReviewEdited) Key() string    { return ReviewEditedEvent }
+func (*ReviewDeleted) Key() string   { return ReviewDeletedEvent }
+
+func (*RestaurantRatingChanged) Key() string { return RestaurantRatingChangedEvent }
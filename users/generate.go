@@ -0,0 +1,3 @@
+package users
+
+//go:generate buf generate
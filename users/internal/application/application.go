@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/application/commands"
+	"github.com/jongyunha/lunchbox/users/internal/application/queries"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	App interface {
+		Commands
+		Queries
+	}
+
+	Commands interface {
+		RegisterUser(ctx context.Context, cmd commands.RegisterUser) error
+		ChangeDietaryProfile(ctx context.Context, cmd commands.ChangeDietaryProfile) error
+		CreateTeam(ctx context.Context, cmd commands.CreateTeam) error
+		InviteMember(ctx context.Context, cmd commands.InviteMember) error
+		AcceptInvitation(ctx context.Context, cmd commands.AcceptInvitation) error
+		DeclineInvitation(ctx context.Context, cmd commands.DeclineInvitation) error
+		RemoveMember(ctx context.Context, cmd commands.RemoveMember) error
+		ChangeTeamHomeLocation(ctx context.Context, cmd commands.ChangeTeamHomeLocation) error
+	}
+
+	Queries interface {
+		GetUser(ctx context.Context, query queries.GetUser) (*domain.UserProfile, error)
+		GetTeam(ctx context.Context, query queries.GetTeam) (*domain.TeamProfile, error)
+		ListUserTeams(ctx context.Context, query queries.ListUserTeams) ([]*domain.UserTeam, error)
+	}
+
+	Application struct {
+		appCommands
+		appQueries
+	}
+
+	appCommands struct {
+		commands.RegisterUserHandler
+		commands.ChangeDietaryProfileHandler
+		commands.CreateTeamHandler
+		commands.InviteMemberHandler
+		commands.AcceptInvitationHandler
+		commands.DeclineInvitationHandler
+		commands.RemoveMemberHandler
+		commands.ChangeTeamHomeLocationHandler
+	}
+
+	appQueries struct {
+		queries.GetUserHandler
+		queries.GetTeamHandler
+		queries.ListUserTeamsHandler
+	}
+)
+
+var _ App = (*Application)(nil)
+
+func New(
+	users domain.UserRepository,
+	teams domain.TeamRepository,
+	userProfiles domain.UserProfileRepository,
+	teamProfiles domain.TeamProfileRepository,
+	publisher ddd.EventPublisher[ddd.Event],
+) *Application {
+	return &Application{
+		appCommands: appCommands{
+			RegisterUserHandler:           commands.NewRegisterUserHandler(users, userProfiles, publisher),
+			ChangeDietaryProfileHandler:   commands.NewChangeDietaryProfileHandler(users, publisher),
+			CreateTeamHandler:             commands.NewCreateTeamHandler(teams, users, publisher),
+			InviteMemberHandler:           commands.NewInviteMemberHandler(teams, users, publisher),
+			AcceptInvitationHandler:       commands.NewAcceptInvitationHandler(teams, publisher),
+			DeclineInvitationHandler:      commands.NewDeclineInvitationHandler(teams, publisher),
+			RemoveMemberHandler:           commands.NewRemoveMemberHandler(teams, publisher),
+			ChangeTeamHomeLocationHandler: commands.NewChangeTeamHomeLocationHandler(teams, publisher),
+		},
+		appQueries: appQueries{
+			GetUserHandler:       queries.NewGetUserHandler(userProfiles),
+			GetTeamHandler:       queries.NewGetTeamHandler(teamProfiles),
+			ListUserTeamsHandler: queries.NewListUserTeamsHandler(teamProfiles),
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	AcceptInvitation struct {
+		TeamID string
+		UserID string
+	}
+
+	AcceptInvitationHandler struct {
+		teams     domain.TeamRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewAcceptInvitationHandler(teams domain.TeamRepository, publisher ddd.EventPublisher[ddd.Event]) AcceptInvitationHandler {
+	return AcceptInvitationHandler{
+		teams:     teams,
+		publisher: publisher,
+	}
+}
+
+func (h AcceptInvitationHandler) AcceptInvitation(ctx context.Context, cmd AcceptInvitation) error {
+	team, err := h.teams.Load(ctx, cmd.TeamID)
+	if err != nil {
+		return err
+	}
+
+	event, err := team.AcceptInvitation(cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = h.teams.Save(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	ChangeDietaryProfile struct {
+		ID                 string
+		DietaryPreferences []string
+		Allergies          []string
+	}
+
+	ChangeDietaryProfileHandler struct {
+		users     domain.UserRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewChangeDietaryProfileHandler(users domain.UserRepository, publisher ddd.EventPublisher[ddd.Event]) ChangeDietaryProfileHandler {
+	return ChangeDietaryProfileHandler{
+		users:     users,
+		publisher: publisher,
+	}
+}
+
+func (h ChangeDietaryProfileHandler) ChangeDietaryProfile(ctx context.Context, cmd ChangeDietaryProfile) error {
+	user, err := h.users.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := user.ChangeDietaryProfile(cmd.DietaryPreferences, cmd.Allergies)
+	if err != nil {
+		return err
+	}
+
+	err = h.users.Save(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
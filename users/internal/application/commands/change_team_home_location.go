@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	ChangeTeamHomeLocation struct {
+		TeamID       string
+		RequesterID  string
+		HomeLocation domain.Location
+	}
+
+	ChangeTeamHomeLocationHandler struct {
+		teams     domain.TeamRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewChangeTeamHomeLocationHandler(teams domain.TeamRepository, publisher ddd.EventPublisher[ddd.Event]) ChangeTeamHomeLocationHandler {
+	return ChangeTeamHomeLocationHandler{
+		teams:     teams,
+		publisher: publisher,
+	}
+}
+
+func (h ChangeTeamHomeLocationHandler) ChangeTeamHomeLocation(ctx context.Context, cmd ChangeTeamHomeLocation) error {
+	team, err := h.teams.Load(ctx, cmd.TeamID)
+	if err != nil {
+		return err
+	}
+
+	event, err := team.ChangeHomeLocation(cmd.RequesterID, cmd.HomeLocation)
+	if err != nil {
+		return err
+	}
+
+	err = h.teams.Save(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
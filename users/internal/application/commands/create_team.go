@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	CreateTeam struct {
+		ID           string
+		Name         string
+		OwnerID      string
+		HomeLocation domain.Location
+	}
+
+	CreateTeamHandler struct {
+		teams     domain.TeamRepository
+		users     domain.UserRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewCreateTeamHandler(teams domain.TeamRepository, users domain.UserRepository, publisher ddd.EventPublisher[ddd.Event]) CreateTeamHandler {
+	return CreateTeamHandler{
+		teams:     teams,
+		users:     users,
+		publisher: publisher,
+	}
+}
+
+func (h CreateTeamHandler) CreateTeam(ctx context.Context, cmd CreateTeam) error {
+	if err := checkUserExists(ctx, h.users, cmd.OwnerID); err != nil {
+		return err
+	}
+
+	team, err := h.teams.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := team.CreateTeam(cmd.Name, cmd.OwnerID, cmd.HomeLocation)
+	if err != nil {
+		return err
+	}
+
+	err = h.teams.Save(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
+
+func checkUserExists(ctx context.Context, users domain.UserRepository, userID string) error {
+	if userID == "" {
+		return domain.ErrUserIDIsBlank
+	}
+
+	user, err := users.Load(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	DeclineInvitation struct {
+		TeamID string
+		UserID string
+	}
+
+	DeclineInvitationHandler struct {
+		teams     domain.TeamRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewDeclineInvitationHandler(teams domain.TeamRepository, publisher ddd.EventPublisher[ddd.Event]) DeclineInvitationHandler {
+	return DeclineInvitationHandler{
+		teams:     teams,
+		publisher: publisher,
+	}
+}
+
+func (h DeclineInvitationHandler) DeclineInvitation(ctx context.Context, cmd DeclineInvitation) error {
+	team, err := h.teams.Load(ctx, cmd.TeamID)
+	if err != nil {
+		return err
+	}
+
+	event, err := team.DeclineInvitation(cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = h.teams.Save(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
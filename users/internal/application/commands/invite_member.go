@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	InviteMember struct {
+		TeamID    string
+		InviterID string
+		UserID    string
+	}
+
+	InviteMemberHandler struct {
+		teams     domain.TeamRepository
+		users     domain.UserRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewInviteMemberHandler(teams domain.TeamRepository, users domain.UserRepository, publisher ddd.EventPublisher[ddd.Event]) InviteMemberHandler {
+	return InviteMemberHandler{
+		teams:     teams,
+		users:     users,
+		publisher: publisher,
+	}
+}
+
+func (h InviteMemberHandler) InviteMember(ctx context.Context, cmd InviteMember) error {
+	if err := checkUserExists(ctx, h.users, cmd.UserID); err != nil {
+		return err
+	}
+
+	team, err := h.teams.Load(ctx, cmd.TeamID)
+	if err != nil {
+		return err
+	}
+
+	event, err := team.InviteMember(cmd.InviterID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = h.teams.Save(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type (
+	RegisterUser struct {
+		ID    string
+		Name  string
+		Email string
+	}
+
+	RegisterUserHandler struct {
+		users     domain.UserRepository
+		profiles  domain.UserProfileRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewRegisterUserHandler(users domain.UserRepository, profiles domain.UserProfileRepository, publisher ddd.EventPublisher[ddd.Event]) RegisterUserHandler {
+	return RegisterUserHandler{
+		users:     users,
+		profiles:  profiles,
+		publisher: publisher,
+	}
+}
+
+func (h RegisterUserHandler) RegisterUser(ctx context.Context, cmd RegisterUser) error {
+	_, err := h.profiles.FindByEmail(ctx, cmd.Email)
+	if err == nil {
+		return domain.ErrEmailAlreadyInUse
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+
+	user, err := h.users.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := user.RegisterUser(cmd.Name, cmd.Email)
+	if err != nil {
+		return err
+	}
+
+	err = h.users.Save(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
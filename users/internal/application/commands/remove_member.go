@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	RemoveMember struct {
+		TeamID      string
+		RequesterID string
+		UserID      string
+	}
+
+	RemoveMemberHandler struct {
+		teams     domain.TeamRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewRemoveMemberHandler(teams domain.TeamRepository, publisher ddd.EventPublisher[ddd.Event]) RemoveMemberHandler {
+	return RemoveMemberHandler{
+		teams:     teams,
+		publisher: publisher,
+	}
+}
+
+func (h RemoveMemberHandler) RemoveMember(ctx context.Context, cmd RemoveMember) error {
+	team, err := h.teams.Load(ctx, cmd.TeamID)
+	if err != nil {
+		return err
+	}
+
+	event, err := team.RemoveMember(cmd.RequesterID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = h.teams.Save(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
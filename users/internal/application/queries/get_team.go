@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	GetTeam struct {
+		ID string
+	}
+
+	GetTeamHandler struct {
+		teams domain.TeamProfileRepository
+	}
+)
+
+func NewGetTeamHandler(teams domain.TeamProfileRepository) GetTeamHandler {
+	return GetTeamHandler{
+		teams: teams,
+	}
+}
+
+func (h GetTeamHandler) GetTeam(ctx context.Context, query GetTeam) (*domain.TeamProfile, error) {
+	return h.teams.Find(ctx, query.ID)
+}
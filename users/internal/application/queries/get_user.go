@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	GetUser struct {
+		ID string
+	}
+
+	GetUserHandler struct {
+		profiles domain.UserProfileRepository
+	}
+)
+
+func NewGetUserHandler(profiles domain.UserProfileRepository) GetUserHandler {
+	return GetUserHandler{
+		profiles: profiles,
+	}
+}
+
+func (h GetUserHandler) GetUser(ctx context.Context, query GetUser) (*domain.UserProfile, error) {
+	return h.profiles.Find(ctx, query.ID)
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+)
+
+type (
+	ListUserTeams struct {
+		UserID string
+	}
+
+	ListUserTeamsHandler struct {
+		teams domain.TeamProfileRepository
+	}
+)
+
+func NewListUserTeamsHandler(teams domain.TeamProfileRepository) ListUserTeamsHandler {
+	return ListUserTeamsHandler{
+		teams: teams,
+	}
+}
+
+func (h ListUserTeamsHandler) ListUserTeams(ctx context.Context, query ListUserTeams) ([]*domain.UserTeam, error) {
+	return h.teams.FindByUser(ctx, query.UserID)
+}
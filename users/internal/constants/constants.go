@@ -0,0 +1,30 @@
+package constants
+
+// ServiceName The name of this module/service
+const ServiceName = "users"
+
+// GRPC Service Names
+const (
+	UsersServiceName = "USERS"
+)
+
+// Dependency Injection Keys
+const (
+	RegistryKey            = "registry"
+	DomainDispatcherKey    = "domainDispatcher"
+	DatabaseTransactionKey = "tx"
+	IdempotencyStoreKey    = "idempotencyStore"
+	MessagePublisherKey    = "messagePublisher"
+	EventPublisherKey      = "eventPublisher"
+	AggregateStoreKey      = "aggregateStore"
+	ApplicationKey         = "app"
+	DomainEventHandlersKey = "domainEventHandlers"
+
+	UserProfileHandlersKey = "userProfileHandlers"
+	TeamProfileHandlersKey = "teamProfileHandlers"
+
+	UsersRepoKey        = "usersRepo"
+	TeamsRepoKey        = "teamsRepo"
+	UserProfilesRepoKey = "userProfilesRepo"
+	TeamProfilesRepoKey = "teamProfilesRepo"
+)
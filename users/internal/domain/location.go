@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"github.com/stackus/errors"
+)
+
+var (
+	ErrInvalidLocation = errors.Wrap(errors.ErrBadRequest, "the location is not a valid latitude and longitude")
+)
+
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (l Location) IsZero() bool {
+	return l.Latitude == 0 && l.Longitude == 0
+}
+
+func (l Location) validate() error {
+	if l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180 {
+		return ErrInvalidLocation
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/stackus/errors"
+)
+
+const (
+	TeamAggregate = "users.Team"
+)
+
+// MemberIDKey is the event metadata key holding the user a membership event is about
+const MemberIDKey = "MemberID"
+
+var (
+	ErrOwnerIDIsBlank     = errors.Wrap(errors.ErrBadRequest, "the team owner id cannot be blank")
+	ErrUserIDIsBlank      = errors.Wrap(errors.ErrBadRequest, "the user id cannot be blank")
+	ErrTeamAlreadyCreated = errors.Wrap(errors.ErrAlreadyExists, "the team has already been created")
+	ErrTeamNotFound       = errors.Wrap(errors.ErrNotFound, "the team does not exist")
+	ErrAlreadyAMember     = errors.Wrap(errors.ErrAlreadyExists, "the user is already a member of the team")
+	ErrAlreadyInvited     = errors.Wrap(errors.ErrAlreadyExists, "the user has already been invited to the team")
+	ErrInvitationNotFound = errors.Wrap(errors.ErrNotFound, "the user has not been invited to the team")
+	ErrNotAMember         = errors.Wrap(errors.ErrPermissionDenied, "only team members can do that")
+	ErrNotTheOwner        = errors.Wrap(errors.ErrPermissionDenied, "only the team owner can do that")
+	ErrOwnerCannotLeave   = errors.Wrap(errors.ErrFailedPrecondition, "the team owner cannot leave the team")
+)
+
+type Team struct {
+	es.Aggregate
+	Name         string
+	OwnerID      string
+	HomeLocation Location
+	Members      map[string]struct{}
+	Invitations  map[string]struct{}
+}
+
+var _ interface {
+	es.EventApplier
+	es.Snapshotter
+} = (*Team)(nil)
+
+func (t *Team) ApplyEvent(event ddd.Event) error {
+	if t.Members == nil {
+		t.Members = make(map[string]struct{})
+	}
+	if t.Invitations == nil {
+		t.Invitations = make(map[string]struct{})
+	}
+
+	switch payload := event.Payload().(type) {
+	case *TeamCreated:
+		t.Name = payload.Name
+		t.OwnerID = payload.OwnerID
+		t.HomeLocation = payload.HomeLocation
+		t.Members[payload.OwnerID] = struct{}{}
+	case *TeamMemberInvited:
+		t.Invitations[payload.UserID] = struct{}{}
+	case *TeamInvitationDeclined:
+		delete(t.Invitations, payload.UserID)
+	case *TeamMemberJoined:
+		delete(t.Invitations, payload.UserID)
+		t.Members[payload.UserID] = struct{}{}
+	case *TeamMemberLeft:
+		delete(t.Members, payload.UserID)
+	case *TeamHomeLocationChanged:
+		t.HomeLocation = payload.HomeLocation
+	default:
+		return errors.ErrInternal.Msgf("%T received the event %s with unexpected payload %T", t, event.EventName(), payload)
+	}
+
+	return nil
+}
+
+func (t *Team) ApplySnapshot(snapshot es.Snapshot) error {
+	switch ss := snapshot.(type) {
+	case *TeamV1:
+		t.Name = ss.Name
+		t.OwnerID = ss.OwnerID
+		t.HomeLocation = ss.HomeLocation
+		t.Members = toSet(ss.Members)
+		t.Invitations = toSet(ss.Invitations)
+	default:
+		return errors.ErrInternal.Msgf("%T received the unexpected snapshot %T", t, snapshot)
+	}
+
+	return nil
+}
+
+func (t *Team) ToSnapshot() es.Snapshot {
+	return TeamV1{
+		Name:         t.Name,
+		OwnerID:      t.OwnerID,
+		HomeLocation: t.HomeLocation,
+		Members:      fromSet(t.Members),
+		Invitations:  fromSet(t.Invitations),
+	}
+}
+
+func (t *Team) CreateTeam(name, ownerID string, homeLocation Location) (ddd.Event, error) {
+	if t.OwnerID != "" {
+		return nil, ErrTeamAlreadyCreated
+	}
+	if name == "" {
+		return nil, ErrNameIsBlank
+	}
+	if ownerID == "" {
+		return nil, ErrOwnerIDIsBlank
+	}
+	if err := homeLocation.validate(); err != nil {
+		return nil, err
+	}
+
+	t.AddEvent(TeamCreatedEvent, &TeamCreated{
+		Name:         name,
+		OwnerID:      ownerID,
+		HomeLocation: homeLocation,
+	})
+
+	return ddd.NewEvent(TeamCreatedEvent, t), nil
+}
+
+// InviteMember lets any member invite another user to the team
+func (t *Team) InviteMember(inviterID, userID string) (ddd.Event, error) {
+	if err := t.checkMember(inviterID); err != nil {
+		return nil, err
+	}
+	if userID == "" {
+		return nil, ErrUserIDIsBlank
+	}
+	if t.IsMember(userID) {
+		return nil, ErrAlreadyAMember
+	}
+	if _, exists := t.Invitations[userID]; exists {
+		return nil, ErrAlreadyInvited
+	}
+
+	t.AddEvent(TeamMemberInvitedEvent, &TeamMemberInvited{
+		InviterID: inviterID,
+		UserID:    userID,
+	})
+
+	return ddd.NewEvent(TeamMemberInvitedEvent, t, ddd.Metadata{MemberIDKey: userID}), nil
+}
+
+func (t *Team) AcceptInvitation(userID string) (ddd.Event, error) {
+	if err := t.checkInvitation(userID); err != nil {
+		return nil, err
+	}
+
+	t.AddEvent(TeamMemberJoinedEvent, &TeamMemberJoined{
+		UserID: userID,
+	})
+
+	return ddd.NewEvent(TeamMemberJoinedEvent, t, ddd.Metadata{MemberIDKey: userID}), nil
+}
+
+func (t *Team) DeclineInvitation(userID string) (ddd.Event, error) {
+	if err := t.checkInvitation(userID); err != nil {
+		return nil, err
+	}
+
+	t.AddEvent(TeamInvitationDeclinedEvent, &TeamInvitationDeclined{
+		UserID: userID,
+	})
+
+	return ddd.NewEvent(TeamInvitationDeclinedEvent, t, ddd.Metadata{MemberIDKey: userID}), nil
+}
+
+// RemoveMember lets a member leave the team or the owner remove a member
+func (t *Team) RemoveMember(requesterID, userID string) (ddd.Event, error) {
+	if err := t.checkMember(requesterID); err != nil {
+		return nil, err
+	}
+	if requesterID != userID && requesterID != t.OwnerID {
+		return nil, ErrNotTheOwner
+	}
+	if !t.IsMember(userID) {
+		return nil, ErrNotAMember
+	}
+	if userID == t.OwnerID {
+		return nil, ErrOwnerCannotLeave
+	}
+
+	t.AddEvent(TeamMemberLeftEvent, &TeamMemberLeft{
+		UserID: userID,
+	})
+
+	return ddd.NewEvent(TeamMemberLeftEvent, t, ddd.Metadata{MemberIDKey: userID}), nil
+}
+
+func (t *Team) ChangeHomeLocation(requesterID string, homeLocation Location) (ddd.Event, error) {
+	if err := t.checkMember(requesterID); err != nil {
+		return nil, err
+	}
+	if requesterID != t.OwnerID {
+		return nil, ErrNotTheOwner
+	}
+	if err := homeLocation.validate(); err != nil {
+		return nil, err
+	}
+
+	t.AddEvent(TeamHomeLocationChangedEvent, &TeamHomeLocationChanged{
+		HomeLocation: homeLocation,
+	})
+
+	return ddd.NewEvent(TeamHomeLocationChangedEvent, t), nil
+}
+
+func (t *Team) IsMember(userID string) bool {
+	_, exists := t.Members[userID]
+	return exists
+}
+
+func (t *Team) checkMember(userID string) error {
+	if t.OwnerID == "" {
+		return ErrTeamNotFound
+	}
+	if !t.IsMember(userID) {
+		return ErrNotAMember
+	}
+
+	return nil
+}
+
+func (t *Team) checkInvitation(userID string) error {
+	if t.OwnerID == "" {
+		return ErrTeamNotFound
+	}
+	if _, exists := t.Invitations[userID]; !exists {
+		return ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+func (Team) Key() string {
+	return TeamAggregate
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
+
+func fromSet(set map[string]struct{}) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	return values
+}
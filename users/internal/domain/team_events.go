@@ -0,0 +1,49 @@
+package domain
+
+const (
+	TeamCreatedEvent             = "users.TeamCreated"
+	TeamMemberInvitedEvent       = "users.TeamMemberInvited"
+	TeamInvitationDeclinedEvent  = "users.TeamInvitationDeclined"
+	TeamMemberJoinedEvent        = "users.TeamMemberJoined"
+	TeamMemberLeftEvent          = "users.TeamMemberLeft"
+	TeamHomeLocationChangedEvent = "users.TeamHomeLocationChanged"
+)
+
+type TeamCreated struct {
+	Name         string
+	OwnerID      string
+	HomeLocation Location
+}
+
+func (TeamCreated) Key() string { return TeamCreatedEvent }
+
+type TeamMemberInvited struct {
+	InviterID string
+	UserID    string
+}
+
+func (TeamMemberInvited) Key() string { return TeamMemberInvitedEvent }
+
+type TeamInvitationDeclined struct {
+	UserID string
+}
+
+func (TeamInvitationDeclined) Key() string { return TeamInvitationDeclinedEvent }
+
+type TeamMemberJoined struct {
+	UserID string
+}
+
+func (TeamMemberJoined) Key() string { return TeamMemberJoinedEvent }
+
+type TeamMemberLeft struct {
+	UserID string
+}
+
+func (TeamMemberLeft) Key() string { return TeamMemberLeftEvent }
+
+type TeamHomeLocationChanged struct {
+	HomeLocation Location
+}
+
+func (TeamHomeLocationChanged) Key() string { return TeamHomeLocationChangedEvent }
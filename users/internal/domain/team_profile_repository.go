@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+)
+
+type MembershipStatus string
+
+const (
+	MembershipInvited MembershipStatus = "invited"
+	MembershipMember  MembershipStatus = "member"
+)
+
+type TeamMember struct {
+	UserID string
+	Status MembershipStatus
+}
+
+// TeamProfile is the read model of a team and its members
+type TeamProfile struct {
+	ID           string
+	Name         string
+	OwnerID      string
+	HomeLocation Location
+	Members      []TeamMember
+}
+
+// UserTeam is a team seen from one of its members or invitees
+type UserTeam struct {
+	TeamID string
+	Name   string
+	Status MembershipStatus
+}
+
+type TeamProfileRepository interface {
+	Add(ctx context.Context, profile *TeamProfile) error
+	UpdateHomeLocation(ctx context.Context, teamID string, homeLocation Location) error
+	SaveMember(ctx context.Context, teamID, userID string, status MembershipStatus) error
+	RemoveMember(ctx context.Context, teamID, userID string) error
+	Find(ctx context.Context, teamID string) (*TeamProfile, error)
+	FindByUser(ctx context.Context, userID string) ([]*UserTeam, error)
+}
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"context"
+)
+
+type TeamRepository interface {
+	Load(ctx context.Context, teamID string) (*Team, error)
+	Save(ctx context.Context, team *Team) error
+}
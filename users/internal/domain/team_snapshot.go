@@ -0,0 +1,11 @@
+package domain
+
+type TeamV1 struct {
+	Name         string
+	OwnerID      string
+	HomeLocation Location
+	Members      []string
+	Invitations  []string
+}
+
+func (TeamV1) SnapshotName() string { return "users.TeamV1" }
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/stackus/errors"
+)
+
+const (
+	UserAggregate = "users.User"
+)
+
+var (
+	ErrNameIsBlank           = errors.Wrap(errors.ErrBadRequest, "the name cannot be blank")
+	ErrEmailIsBlank          = errors.Wrap(errors.ErrBadRequest, "the email cannot be blank")
+	ErrUserAlreadyRegistered = errors.Wrap(errors.ErrAlreadyExists, "the user has already been registered")
+	ErrEmailAlreadyInUse     = errors.Wrap(errors.ErrAlreadyExists, "the email is already in use")
+	ErrUserNotFound          = errors.Wrap(errors.ErrNotFound, "the user does not exist")
+)
+
+type User struct {
+	es.Aggregate
+	Name               string
+	Email              string
+	DietaryPreferences []string
+	Allergies          []string
+}
+
+var _ interface {
+	es.EventApplier
+	es.Snapshotter
+} = (*User)(nil)
+
+func (u *User) ApplyEvent(event ddd.Event) error {
+	switch payload := event.Payload().(type) {
+	case *UserRegistered:
+		u.Name = payload.Name
+		u.Email = payload.Email
+	case *UserDietaryProfileChanged:
+		u.DietaryPreferences = payload.DietaryPreferences
+		u.Allergies = payload.Allergies
+	default:
+		return errors.ErrInternal.Msgf("%T received the event %s with unexpected payload %T", u, event.EventName(), payload)
+	}
+
+	return nil
+}
+
+func (u *User) ApplySnapshot(snapshot es.Snapshot) error {
+	switch ss := snapshot.(type) {
+	case *UserV1:
+		u.Name = ss.Name
+		u.Email = ss.Email
+		u.DietaryPreferences = ss.DietaryPreferences
+		u.Allergies = ss.Allergies
+	default:
+		return errors.ErrInternal.Msgf("%T received the unexpected snapshot %T", u, snapshot)
+	}
+
+	return nil
+}
+
+func (u *User) ToSnapshot() es.Snapshot {
+	return UserV1{
+		Name:               u.Name,
+		Email:              u.Email,
+		DietaryPreferences: u.DietaryPreferences,
+		Allergies:          u.Allergies,
+	}
+}
+
+func (u *User) RegisterUser(name, email string) (ddd.Event, error) {
+	if u.Email != "" {
+		return nil, ErrUserAlreadyRegistered
+	}
+	if name == "" {
+		return nil, ErrNameIsBlank
+	}
+	if email == "" {
+		return nil, ErrEmailIsBlank
+	}
+
+	u.AddEvent(UserRegisteredEvent, &UserRegistered{
+		Name:  name,
+		Email: email,
+	})
+
+	return ddd.NewEvent(UserRegisteredEvent, u), nil
+}
+
+// ChangeDietaryProfile replaces the dietary preferences and allergies
+func (u *User) ChangeDietaryProfile(dietaryPreferences, allergies []string) (ddd.Event, error) {
+	if u.Email == "" {
+		return nil, ErrUserNotFound
+	}
+
+	u.AddEvent(UserDietaryProfileChangedEvent, &UserDietaryProfileChanged{
+		DietaryPreferences: dietaryPreferences,
+		Allergies:          allergies,
+	})
+
+	return ddd.NewEvent(UserDietaryProfileChangedEvent, u), nil
+}
+
+func (User) Key() string {
+	return UserAggregate
+}
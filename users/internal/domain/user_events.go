@@ -0,0 +1,20 @@
+package domain
+
+const (
+	UserRegisteredEvent            = "users.UserRegistered"
+	UserDietaryProfileChangedEvent = "users.UserDietaryProfileChanged"
+)
+
+type UserRegistered struct {
+	Name  string
+	Email string
+}
+
+func (UserRegistered) Key() string { return UserRegisteredEvent }
+
+type UserDietaryProfileChanged struct {
+	DietaryPreferences []string
+	Allergies          []string
+}
+
+func (UserDietaryProfileChanged) Key() string { return UserDietaryProfileChangedEvent }
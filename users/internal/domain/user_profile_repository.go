@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+)
+
+// UserProfile is the read model of a user
+type UserProfile struct {
+	ID                 string
+	Name               string
+	Email              string
+	DietaryPreferences []string
+	Allergies          []string
+}
+
+type UserProfileRepository interface {
+	Add(ctx context.Context, profile *UserProfile) error
+	UpdateDietaryProfile(ctx context.Context, userID string, dietaryPreferences, allergies []string) error
+	Find(ctx context.Context, userID string) (*UserProfile, error)
+	FindByEmail(ctx context.Context, email string) (*UserProfile, error)
+}
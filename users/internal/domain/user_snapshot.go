@@ -0,0 +1,10 @@
+package domain
+
+type UserV1 struct {
+	Name               string
+	Email              string
+	DietaryPreferences []string
+	Allergies          []string
+}
+
+func (UserV1) SnapshotName() string { return "users.UserV1" }
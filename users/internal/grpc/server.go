@@ -0,0 +1,283 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/internal/rpc"
+	"github.com/jongyunha/lunchbox/users/internal/application"
+	"github.com/jongyunha/lunchbox/users/internal/application/commands"
+	"github.com/jongyunha/lunchbox/users/internal/application/queries"
+	"github.com/jongyunha/lunchbox/users/internal/constants"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"github.com/jongyunha/lunchbox/users/userspb"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+type server struct {
+	c di.Container
+	userspb.UnimplementedUsersServiceServer
+}
+
+var _ userspb.UsersServiceServer = (*server)(nil)
+
+func RegisterServer(c di.Container, registrar grpc.ServiceRegistrar, logger zerolog.Logger) error {
+	userspb.RegisterUsersServiceServer(
+		rpc.NewTransactionalRegistrar(registrar, c, logger,
+			idempotency.UnaryServerInterceptor(constants.IdempotencyStoreKey,
+				userspb.UsersService_RegisterUser_FullMethodName,
+				userspb.UsersService_ChangeDietaryProfile_FullMethodName,
+				userspb.UsersService_CreateTeam_FullMethodName,
+				userspb.UsersService_InviteMember_FullMethodName,
+				userspb.UsersService_AcceptInvitation_FullMethodName,
+				userspb.UsersService_DeclineInvitation_FullMethodName,
+				userspb.UsersService_RemoveMember_FullMethodName,
+				userspb.UsersService_ChangeTeamHomeLocation_FullMethodName,
+			),
+		),
+		server{c: c},
+	)
+
+	return nil
+}
+
+// app returns the application of the scope attached to the context
+func (s server) app(ctx context.Context) application.App {
+	return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey)
+}
+
+func (s server) RegisterUser(ctx context.Context, request *userspb.RegisterUserRequest) (*userspb.RegisterUserResponse, error) {
+	userID := uuid.New().String()
+
+	err := s.app(ctx).RegisterUser(ctx, commands.RegisterUser{
+		ID:    userID,
+		Name:  request.GetName(),
+		Email: request.GetEmail(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.RegisterUserResponse{
+		Id: userID,
+	}, nil
+}
+
+func (s server) ChangeDietaryProfile(ctx context.Context, request *userspb.ChangeDietaryProfileRequest) (*userspb.ChangeDietaryProfileResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).ChangeDietaryProfile(ctx, commands.ChangeDietaryProfile{
+		ID:                 userID,
+		DietaryPreferences: request.GetDietaryPreferences(),
+		Allergies:          request.GetAllergies(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.ChangeDietaryProfileResponse{}, nil
+}
+
+func (s server) GetUser(ctx context.Context, request *userspb.GetUserRequest) (*userspb.GetUserResponse, error) {
+	user, err := s.app(ctx).GetUser(ctx, queries.GetUser{
+		ID: request.GetId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.GetUserResponse{
+		User: s.userFromDomain(user),
+	}, nil
+}
+
+func (s server) ListUserTeams(ctx context.Context, request *userspb.ListUserTeamsRequest) (*userspb.ListUserTeamsResponse, error) {
+	teams, err := s.app(ctx).ListUserTeams(ctx, queries.ListUserTeams{
+		UserID: request.GetId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userspb.ListUserTeamsResponse{
+		Teams: make([]*userspb.UserTeam, len(teams)),
+	}
+	for i, team := range teams {
+		resp.Teams[i] = &userspb.UserTeam{
+			TeamId: team.TeamID,
+			Name:   team.Name,
+			Status: string(team.Status),
+		}
+	}
+
+	return resp, nil
+}
+
+func (s server) CreateTeam(ctx context.Context, request *userspb.CreateTeamRequest) (*userspb.CreateTeamResponse, error) {
+	ownerID, err := auth.ActingUser(ctx, request.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+
+	teamID := uuid.New().String()
+
+	err = s.app(ctx).CreateTeam(ctx, commands.CreateTeam{
+		ID:           teamID,
+		Name:         request.GetName(),
+		OwnerID:      ownerID,
+		HomeLocation: s.locationToDomain(request.GetHomeLocation()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.CreateTeamResponse{
+		Id: teamID,
+	}, nil
+}
+
+func (s server) InviteMember(ctx context.Context, request *userspb.InviteMemberRequest) (*userspb.InviteMemberResponse, error) {
+	inviterID, err := auth.ActingUser(ctx, request.GetInviterId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).InviteMember(ctx, commands.InviteMember{
+		TeamID:    request.GetId(),
+		InviterID: inviterID,
+		UserID:    request.GetUserId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.InviteMemberResponse{}, nil
+}
+
+func (s server) AcceptInvitation(ctx context.Context, request *userspb.AcceptInvitationRequest) (*userspb.AcceptInvitationResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).AcceptInvitation(ctx, commands.AcceptInvitation{
+		TeamID: request.GetId(),
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.AcceptInvitationResponse{}, nil
+}
+
+func (s server) DeclineInvitation(ctx context.Context, request *userspb.DeclineInvitationRequest) (*userspb.DeclineInvitationResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).DeclineInvitation(ctx, commands.DeclineInvitation{
+		TeamID: request.GetId(),
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.DeclineInvitationResponse{}, nil
+}
+
+func (s server) RemoveMember(ctx context.Context, request *userspb.RemoveMemberRequest) (*userspb.RemoveMemberResponse, error) {
+	requesterID, err := auth.ActingUser(ctx, request.GetRequesterId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).RemoveMember(ctx, commands.RemoveMember{
+		TeamID:      request.GetId(),
+		RequesterID: requesterID,
+		UserID:      request.GetUserId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.RemoveMemberResponse{}, nil
+}
+
+func (s server) ChangeTeamHomeLocation(ctx context.Context, request *userspb.ChangeTeamHomeLocationRequest) (*userspb.ChangeTeamHomeLocationResponse, error) {
+	requesterID, err := auth.ActingUser(ctx, request.GetRequesterId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).ChangeTeamHomeLocation(ctx, commands.ChangeTeamHomeLocation{
+		TeamID:       request.GetId(),
+		RequesterID:  requesterID,
+		HomeLocation: s.locationToDomain(request.GetHomeLocation()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.ChangeTeamHomeLocationResponse{}, nil
+}
+
+func (s server) GetTeam(ctx context.Context, request *userspb.GetTeamRequest) (*userspb.GetTeamResponse, error) {
+	team, err := s.app(ctx).GetTeam(ctx, queries.GetTeam{
+		ID: request.GetId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userspb.GetTeamResponse{
+		Team: s.teamFromDomain(team),
+	}, nil
+}
+
+func (s server) userFromDomain(user *domain.UserProfile) *userspb.User {
+	return &userspb.User{
+		Id:                 user.ID,
+		Name:               user.Name,
+		Email:              user.Email,
+		DietaryPreferences: user.DietaryPreferences,
+		Allergies:          user.Allergies,
+	}
+}
+
+func (s server) teamFromDomain(team *domain.TeamProfile) *userspb.Team {
+	protoTeam := &userspb.Team{
+		Id:      team.ID,
+		Name:    team.Name,
+		OwnerId: team.OwnerID,
+		HomeLocation: &userspb.Location{
+			Latitude:  team.HomeLocation.Latitude,
+			Longitude: team.HomeLocation.Longitude,
+		},
+		Members: make([]*userspb.TeamMember, len(team.Members)),
+	}
+	for i, member := range team.Members {
+		protoTeam.Members[i] = &userspb.TeamMember{
+			UserId: member.UserID,
+			Status: string(member.Status),
+		}
+	}
+
+	return protoTeam
+}
+
+func (s server) locationToDomain(location *userspb.Location) domain.Location {
+	return domain.Location{
+		Latitude:  location.GetLatitude(),
+		Longitude: location.GetLongitude(),
+	}
+}
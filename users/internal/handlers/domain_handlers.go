@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/users/internal/constants"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"github.com/jongyunha/lunchbox/users/userspb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type domainHandlers[T ddd.Event] struct {
+	publisher am.EventPublisher
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*domainHandlers[ddd.Event])(nil)
+
+func NewDomainEventHandlers(publisher am.EventPublisher) ddd.EventHandler[ddd.Event] {
+	return &domainHandlers[ddd.Event]{
+		publisher: publisher,
+	}
+}
+
+func RegisterDomainEventHandlers(subscriber ddd.EventSubscriber[ddd.Event], handlers ddd.EventHandler[ddd.Event]) {
+	subscriber.Subscribe(handlers,
+		domain.UserRegisteredEvent,
+		domain.UserDietaryProfileChangedEvent,
+		domain.TeamCreatedEvent,
+		domain.TeamMemberInvitedEvent,
+		domain.TeamMemberJoinedEvent,
+		domain.TeamMemberLeftEvent,
+		domain.TeamHomeLocationChangedEvent,
+	)
+}
+
+func RegisterDomainEventHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		domainHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.DomainEventHandlersKey)
+
+		return domainHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterDomainEventHandlers(subscriber, handlers)
+}
+
+func (d domainHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling domain event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled domain event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	span.AddEvent("Handling domain event", trace.WithAttributes(
+		attribute.String("Event", event.EventName()),
+	))
+
+	switch event.EventName() {
+	case domain.UserRegisteredEvent:
+		return d.onUserRegistered(ctx, event)
+	case domain.UserDietaryProfileChangedEvent:
+		return d.onUserDietaryProfileChanged(ctx, event)
+	case domain.TeamCreatedEvent:
+		return d.onTeamCreated(ctx, event)
+	case domain.TeamMemberInvitedEvent:
+		return d.onTeamMemberInvited(ctx, event)
+	case domain.TeamMemberJoinedEvent:
+		return d.onTeamMemberJoined(ctx, event)
+	case domain.TeamMemberLeftEvent:
+		return d.onTeamMemberLeft(ctx, event)
+	case domain.TeamHomeLocationChangedEvent:
+		return d.onTeamHomeLocationChanged(ctx, event)
+	}
+
+	return nil
+}
+
+func (d domainHandlers[T]) onUserRegistered(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.User)
+	return d.publisher.Publish(ctx, userspb.UserAggregateChannel, ddd.NewEvent(
+		userspb.UserRegisteredEvent,
+		&userspb.UserRegistered{
+			Id:    payload.ID(),
+			Name:  payload.Name,
+			Email: payload.Email,
+		},
+	))
+}
+
+func (d domainHandlers[T]) onUserDietaryProfileChanged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.User)
+	return d.publisher.Publish(ctx, userspb.UserAggregateChannel, ddd.NewEvent(
+		userspb.UserDietaryProfileChangedEvent,
+		&userspb.UserDietaryProfileChanged{
+			Id:                 payload.ID(),
+			DietaryPreferences: payload.DietaryPreferences,
+			Allergies:          payload.Allergies,
+		},
+	))
+}
+
+func (d domainHandlers[T]) onTeamCreated(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	return d.publisher.Publish(ctx, userspb.TeamAggregateChannel, ddd.NewEvent(
+		userspb.TeamCreatedEvent,
+		&userspb.TeamCreated{
+			Id:        payload.ID(),
+			Name:      payload.Name,
+			OwnerId:   payload.OwnerID,
+			Latitude:  payload.HomeLocation.Latitude,
+			Longitude: payload.HomeLocation.Longitude,
+		},
+	))
+}
+
+func (d domainHandlers[T]) onTeamMemberInvited(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	return d.publisher.Publish(ctx, userspb.TeamAggregateChannel, ddd.NewEvent(
+		userspb.TeamMemberInvitedEvent,
+		&userspb.TeamMemberInvited{
+			Id:     payload.ID(),
+			UserId: event.Metadata().Get(domain.MemberIDKey).(string),
+		},
+	))
+}
+
+func (d domainHandlers[T]) onTeamMemberJoined(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	return d.publisher.Publish(ctx, userspb.TeamAggregateChannel, ddd.NewEvent(
+		userspb.TeamMemberJoinedEvent,
+		&userspb.TeamMemberJoined{
+			Id:     payload.ID(),
+			UserId: event.Metadata().Get(domain.MemberIDKey).(string),
+		},
+	))
+}
+
+func (d domainHandlers[T]) onTeamMemberLeft(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	return d.publisher.Publish(ctx, userspb.TeamAggregateChannel, ddd.NewEvent(
+		userspb.TeamMemberLeftEvent,
+		&userspb.TeamMemberLeft{
+			Id:     payload.ID(),
+			UserId: event.Metadata().Get(domain.MemberIDKey).(string),
+		},
+	))
+}
+
+func (d domainHandlers[T]) onTeamHomeLocationChanged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	return d.publisher.Publish(ctx, userspb.TeamAggregateChannel, ddd.NewEvent(
+		userspb.TeamHomeLocationChangedEvent,
+		&userspb.TeamHomeLocationChanged{
+			Id:        payload.ID(),
+			Latitude:  payload.HomeLocation.Latitude,
+			Longitude: payload.HomeLocation.Longitude,
+		},
+	))
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/users/internal/constants"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TeamProfileHandlers[T ddd.Event] struct {
+	profiles domain.TeamProfileRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*TeamProfileHandlers[ddd.Event])(nil)
+
+func NewTeamProfileHandlers(profiles domain.TeamProfileRepository) *TeamProfileHandlers[ddd.Event] {
+	return &TeamProfileHandlers[ddd.Event]{
+		profiles: profiles,
+	}
+}
+
+func (h TeamProfileHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling team event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled team event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.TeamCreatedEvent:
+		return h.onTeamCreated(ctx, event)
+	case domain.TeamMemberInvitedEvent:
+		return h.onMembershipChanged(ctx, event, domain.MembershipInvited)
+	case domain.TeamMemberJoinedEvent:
+		return h.onMembershipChanged(ctx, event, domain.MembershipMember)
+	case domain.TeamInvitationDeclinedEvent, domain.TeamMemberLeftEvent:
+		return h.onMembershipEnded(ctx, event)
+	case domain.TeamHomeLocationChangedEvent:
+		return h.onTeamHomeLocationChanged(ctx, event)
+	}
+	return nil
+}
+
+func (h TeamProfileHandlers[T]) onTeamCreated(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	return h.profiles.Add(ctx, &domain.TeamProfile{
+		ID:           payload.ID(),
+		Name:         payload.Name,
+		OwnerID:      payload.OwnerID,
+		HomeLocation: payload.HomeLocation,
+		Members: []domain.TeamMember{
+			{UserID: payload.OwnerID, Status: domain.MembershipMember},
+		},
+	})
+}
+
+func (h TeamProfileHandlers[T]) onMembershipChanged(ctx context.Context, event ddd.Event, status domain.MembershipStatus) error {
+	payload := event.Payload().(*domain.Team)
+	userID := event.Metadata().Get(domain.MemberIDKey).(string)
+	return h.profiles.SaveMember(ctx, payload.ID(), userID, status)
+}
+
+func (h TeamProfileHandlers[T]) onMembershipEnded(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	userID := event.Metadata().Get(domain.MemberIDKey).(string)
+	return h.profiles.RemoveMember(ctx, payload.ID(), userID)
+}
+
+func (h TeamProfileHandlers[T]) onTeamHomeLocationChanged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Team)
+	return h.profiles.UpdateHomeLocation(ctx, payload.ID(), payload.HomeLocation)
+}
+
+func RegisterTeamProfileHandlers(profileHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(profileHandlers,
+		domain.TeamCreatedEvent,
+		domain.TeamMemberInvitedEvent,
+		domain.TeamMemberJoinedEvent,
+		domain.TeamInvitationDeclinedEvent,
+		domain.TeamMemberLeftEvent,
+		domain.TeamHomeLocationChangedEvent,
+	)
+}
+
+func RegisterTeamProfileHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		profileHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.TeamProfileHandlersKey)
+
+		return profileHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterTeamProfileHandlers(handlers, subscriber)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/users/internal/constants"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type UserProfileHandlers[T ddd.Event] struct {
+	profiles domain.UserProfileRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*UserProfileHandlers[ddd.Event])(nil)
+
+func NewUserProfileHandlers(profiles domain.UserProfileRepository) *UserProfileHandlers[ddd.Event] {
+	return &UserProfileHandlers[ddd.Event]{
+		profiles: profiles,
+	}
+}
+
+func (h UserProfileHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling user event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled user event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.UserRegisteredEvent:
+		return h.onUserRegistered(ctx, event)
+	case domain.UserDietaryProfileChangedEvent:
+		return h.onUserDietaryProfileChanged(ctx, event)
+	}
+	return nil
+}
+
+func (h UserProfileHandlers[T]) onUserRegistered(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.User)
+	return h.profiles.Add(ctx, &domain.UserProfile{
+		ID:                 payload.ID(),
+		Name:               payload.Name,
+		Email:              payload.Email,
+		DietaryPreferences: payload.DietaryPreferences,
+		Allergies:          payload.Allergies,
+	})
+}
+
+func (h UserProfileHandlers[T]) onUserDietaryProfileChanged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.User)
+	return h.profiles.UpdateDietaryProfile(ctx, payload.ID(), payload.DietaryPreferences, payload.Allergies)
+}
+
+func RegisterUserProfileHandlers(profileHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(profileHandlers,
+		domain.UserRegisteredEvent,
+		domain.UserDietaryProfileChangedEvent,
+	)
+}
+
+func RegisterUserProfileHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		profileHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.UserProfileHandlersKey)
+
+		return profileHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterUserProfileHandlers(handlers, subscriber)
+}
@@ -0,0 +1,100 @@
+-- +goose Up
+CREATE TABLE users.users (
+  id                  text        NOT NULL,
+  name                text        NOT NULL,
+  email               text        NOT NULL,
+  dietary_preferences text[]      NOT NULL DEFAULT '{}',
+  allergies           text[]      NOT NULL DEFAULT '{}',
+  created_at          timestamptz NOT NULL DEFAULT NOW(),
+  updated_at          timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX users_email_idx ON users.users (lower(email));
+
+CREATE TRIGGER created_at_users_trgr
+  BEFORE UPDATE
+  ON users.users
+  FOR EACH ROW EXECUTE PROCEDURE created_at_trigger();
+CREATE TRIGGER updated_at_users_trgr
+  BEFORE UPDATE
+  ON users.users
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE users.teams (
+  id         text             NOT NULL,
+  name       text             NOT NULL,
+  owner_id   text             NOT NULL,
+  latitude   double precision NOT NULL DEFAULT 0,
+  longitude  double precision NOT NULL DEFAULT 0,
+  created_at timestamptz      NOT NULL DEFAULT NOW(),
+  updated_at timestamptz      NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+CREATE TRIGGER created_at_teams_trgr
+  BEFORE UPDATE
+  ON users.teams
+  FOR EACH ROW EXECUTE PROCEDURE created_at_trigger();
+CREATE TRIGGER updated_at_teams_trgr
+  BEFORE UPDATE
+  ON users.teams
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE users.team_members (
+  team_id    text        NOT NULL,
+  user_id    text        NOT NULL,
+  status     text        NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  updated_at timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX team_members_user_idx ON users.team_members (user_id);
+
+CREATE TRIGGER updated_at_team_members_trgr
+  BEFORE UPDATE
+  ON users.team_members
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE users.events (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  event_id       text        NOT NULL,
+  event_name     text        NOT NULL,
+  event_data     bytea       NOT NULL,
+  occurred_at    timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name, stream_version)
+);
+
+CREATE TABLE users.snapshots (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  snapshot_name  text        NOT NULL,
+  snapshot_data  bytea       NOT NULL,
+  updated_at     timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name)
+);
+
+CREATE TRIGGER updated_at_snapshots_trgr
+  BEFORE UPDATE
+  ON users.snapshots
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE users.outbox (
+  id           text        NOT NULL,
+  name         text        NOT NULL,
+  subject      text        NOT NULL,
+  data         bytea       NOT NULL,
+  metadata     bytea       NOT NULL,
+  sent_at      timestamptz NOT NULL,
+  published_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX users_unpublished_idx ON users.outbox (published_at) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE users.outbox, users.snapshots, users.events, users.team_members, users.teams, users.users;
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"github.com/stackus/errors"
+)
+
+type TeamProfileRepository struct {
+	tableName        string
+	membersTableName string
+	db               postgres.DBTX
+}
+
+var _ domain.TeamProfileRepository = (*TeamProfileRepository)(nil)
+
+func NewTeamProfileRepository(tableName, membersTableName string, db postgres.DBTX) TeamProfileRepository {
+	return TeamProfileRepository{
+		tableName:        tableName,
+		membersTableName: membersTableName,
+		db:               db,
+	}
+}
+
+func (r TeamProfileRepository) Add(ctx context.Context, profile *domain.TeamProfile) error {
+	const query = "INSERT INTO %s (id, name, owner_id, latitude, longitude) VALUES ($1, $2, $3, $4, $5)"
+
+	_, err := r.db.Exec(ctx, r.table(query),
+		profile.ID, profile.Name, profile.OwnerID, profile.HomeLocation.Latitude, profile.HomeLocation.Longitude,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range profile.Members {
+		if err = r.SaveMember(ctx, profile.ID, member.UserID, member.Status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r TeamProfileRepository) UpdateHomeLocation(ctx context.Context, teamID string, homeLocation domain.Location) error {
+	const query = "UPDATE %s SET latitude = $2, longitude = $3 WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), teamID, homeLocation.Latitude, homeLocation.Longitude)
+
+	return err
+}
+
+func (r TeamProfileRepository) SaveMember(ctx context.Context, teamID, userID string, status domain.MembershipStatus) error {
+	const query = `INSERT INTO %s (team_id, user_id, status) VALUES ($1, $2, $3)
+ON CONFLICT (team_id, user_id) DO UPDATE SET status = EXCLUDED.status`
+
+	_, err := r.db.Exec(ctx, r.membersTable(query), teamID, userID, string(status))
+
+	return err
+}
+
+func (r TeamProfileRepository) RemoveMember(ctx context.Context, teamID, userID string) error {
+	const query = "DELETE FROM %s WHERE team_id = $1 AND user_id = $2"
+
+	_, err := r.db.Exec(ctx, r.membersTable(query), teamID, userID)
+
+	return err
+}
+
+func (r TeamProfileRepository) Find(ctx context.Context, teamID string) (*domain.TeamProfile, error) {
+	const query = "SELECT id, name, owner_id, latitude, longitude FROM %s WHERE id = $1 LIMIT 1"
+
+	profile := &domain.TeamProfile{}
+
+	err := r.db.QueryRow(ctx, r.table(query), teamID).Scan(
+		&profile.ID, &profile.Name, &profile.OwnerID, &profile.HomeLocation.Latitude, &profile.HomeLocation.Longitude,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	profile.Members, err = r.members(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (r TeamProfileRepository) FindByUser(ctx context.Context, userID string) ([]*domain.UserTeam, error) {
+	const query = `SELECT t.id, t.name, m.status
+FROM %s m JOIN %s t ON t.id = m.team_id
+WHERE m.user_id = $1 ORDER BY t.name`
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(query, r.membersTableName, r.tableName), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []*domain.UserTeam
+	for rows.Next() {
+		team := &domain.UserTeam{}
+		var status string
+		if err := rows.Scan(&team.TeamID, &team.Name, &status); err != nil {
+			return nil, err
+		}
+		team.Status = domain.MembershipStatus(status)
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+func (r TeamProfileRepository) members(ctx context.Context, teamID string) ([]domain.TeamMember, error) {
+	const query = "SELECT user_id, status FROM %s WHERE team_id = $1 ORDER BY created_at"
+
+	rows, err := r.db.Query(ctx, r.membersTable(query), teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []domain.TeamMember
+	for rows.Next() {
+		var member domain.TeamMember
+		var status string
+		if err := rows.Scan(&member.UserID, &status); err != nil {
+			return nil, err
+		}
+		member.Status = domain.MembershipStatus(status)
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+func (r TeamProfileRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
+
+func (r TeamProfileRepository) membersTable(query string) string {
+	return fmt.Sprintf(query, r.membersTableName)
+}
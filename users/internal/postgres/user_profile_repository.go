@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"github.com/stackus/errors"
+)
+
+// uniqueViolation is the Postgres error code raised when a unique constraint fails
+const uniqueViolation = "23505"
+
+type UserProfileRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.UserProfileRepository = (*UserProfileRepository)(nil)
+
+func NewUserProfileRepository(tableName string, db postgres.DBTX) UserProfileRepository {
+	return UserProfileRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r UserProfileRepository) Add(ctx context.Context, profile *domain.UserProfile) error {
+	const query = `INSERT INTO %s (id, name, email, dietary_preferences, allergies)
+VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.Exec(ctx, r.table(query),
+		profile.ID, profile.Name, profile.Email, nonNil(profile.DietaryPreferences), nonNil(profile.Allergies),
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrEmailAlreadyInUse
+	}
+
+	return err
+}
+
+func (r UserProfileRepository) UpdateDietaryProfile(ctx context.Context, userID string, dietaryPreferences, allergies []string) error {
+	const query = "UPDATE %s SET dietary_preferences = $2, allergies = $3 WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), userID, nonNil(dietaryPreferences), nonNil(allergies))
+
+	return err
+}
+
+func (r UserProfileRepository) Find(ctx context.Context, userID string) (*domain.UserProfile, error) {
+	const query = "SELECT id, name, email, dietary_preferences, allergies FROM %s WHERE id = $1 LIMIT 1"
+
+	return r.scan(r.db.QueryRow(ctx, r.table(query), userID))
+}
+
+func (r UserProfileRepository) FindByEmail(ctx context.Context, email string) (*domain.UserProfile, error) {
+	const query = "SELECT id, name, email, dietary_preferences, allergies FROM %s WHERE lower(email) = lower($1) LIMIT 1"
+
+	return r.scan(r.db.QueryRow(ctx, r.table(query), email))
+}
+
+func (r UserProfileRepository) scan(row pgx.Row) (*domain.UserProfile, error) {
+	profile := &domain.UserProfile{}
+
+	err := row.Scan(&profile.ID, &profile.Name, &profile.Email, &profile.DietaryPreferences, &profile.Allergies)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (r UserProfileRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,32 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/users/userspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func RegisterGateway(ctx context.Context, mux *chi.Mux, grpcAddr string) error {
+	const usersRoot = "/api/v1/users"
+	const teamsRoot = "/api/v1/teams"
+
+	gateway := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(idempotency.GatewayHeaderMatcher(auth.GatewayHeaderMatcher)))
+	err := userspb.RegisterUsersServiceHandlerFromEndpoint(ctx, gateway, grpcAddr, []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	})
+	if err != nil {
+		return err
+	}
+
+	// mount the GRPC gateway; the one service serves both roots
+	mux.Mount(usersRoot, gateway)
+	mux.Mount(teamsRoot, gateway)
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package users
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/amotel"
+	"github.com/jongyunha/lunchbox/internal/amprom"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	pg "github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/internal/postgresotel"
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+	"github.com/jongyunha/lunchbox/internal/system"
+	"github.com/jongyunha/lunchbox/internal/tm"
+	"github.com/jongyunha/lunchbox/users/internal/application"
+	"github.com/jongyunha/lunchbox/users/internal/constants"
+	"github.com/jongyunha/lunchbox/users/internal/domain"
+	"github.com/jongyunha/lunchbox/users/internal/grpc"
+	"github.com/jongyunha/lunchbox/users/internal/handlers"
+	"github.com/jongyunha/lunchbox/users/internal/migrations"
+	"github.com/jongyunha/lunchbox/users/internal/postgres"
+	"github.com/jongyunha/lunchbox/users/internal/rest"
+	"github.com/jongyunha/lunchbox/users/userspb"
+	"github.com/rs/zerolog"
+)
+
+type Module struct{}
+
+func (m *Module) Startup(ctx context.Context, svc system.Service) (err error) {
+	return Root(ctx, svc)
+}
+
+// Migrations returns the migrations of the module schema
+func (m *Module) Migrations() pg.Migrations {
+	return pg.Migrations{
+		Schema: constants.ServiceName,
+		FS:     migrations.FS,
+	}
+}
+
+// Registry returns a registry with everything the module serializes
+func (m *Module) Registry() (registry.Registry, error) {
+	return newRegistry()
+}
+
+// Projections returns the read models that can be rebuilt from the event store
+func (m *Module) Projections() []pg.Projection {
+	return []pg.Projection{
+		{
+			Name:          "users",
+			AggregateName: domain.UserAggregate,
+			Tables:        []string{constants.ServiceName + ".users"},
+			Handlers: func(db pg.DBTX) []ddd.EventHandler[ddd.Event] {
+				return []ddd.EventHandler[ddd.Event]{
+					handlers.NewUserProfileHandlers(postgres.NewUserProfileRepository(constants.ServiceName+".users", db)),
+				}
+			},
+		},
+		{
+			Name:          "teams",
+			AggregateName: domain.TeamAggregate,
+			Tables: []string{
+				constants.ServiceName + ".teams",
+				constants.ServiceName + ".team_members",
+			},
+			Handlers: func(db pg.DBTX) []ddd.EventHandler[ddd.Event] {
+				return []ddd.EventHandler[ddd.Event]{
+					handlers.NewTeamProfileHandlers(postgres.NewTeamProfileRepository(
+						constants.ServiceName+".teams",
+						constants.ServiceName+".team_members",
+						db,
+					)),
+				}
+			},
+		},
+	}
+}
+
+func Root(ctx context.Context, svc system.Service) (err error) {
+	container := di.New()
+
+	// setup Driven adapters
+	di.Register(container, di.Singleton, constants.RegistryKey, func(c di.Container) (registry.Registry, error) {
+		return newRegistry()
+	})
+
+	stream := svc.Stream()
+
+	di.Register(container, di.Singleton, constants.DomainDispatcherKey, func(c di.Container) (*ddd.EventDispatcher[ddd.Event], error) {
+		return ddd.NewEventDispatcher[ddd.Event](), nil
+	})
+
+	di.Register(container, di.Scoped, constants.DatabaseTransactionKey, func(c di.Container) (pgx.Tx, error) {
+		return svc.DB().Begin(context.Background())
+	}, di.Finalizer(pg.EndTransaction(svc.Logger())))
+	di.Register(container, di.Scoped, constants.IdempotencyStoreKey, func(c di.Container) (idempotency.Store, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		return pg.NewIdempotencyStore(constants.ServiceName+".idempotency_keys", tx), nil
+	})
+	sentCounter := amprom.SentMessagesCounter(constants.ServiceName)
+	di.Register(container, di.Scoped, constants.MessagePublisherKey, func(c di.Container) (am.MessagePublisher, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		outboxStore := pg.NewOutboxStore(constants.ServiceName+".outbox", tx)
+		return am.NewMessagePublisher(
+			stream,
+			amotel.OtelMessageContextInjector(),
+			sentCounter,
+			tm.OutboxPublisher(outboxStore),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.EventPublisherKey, func(c di.Container) (am.EventPublisher, error) {
+		return am.NewEventPublisher(
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[am.MessagePublisher](c, constants.MessagePublisherKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.AggregateStoreKey, func(c di.Container) (es.AggregateStore, error) {
+		tx := postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey))
+		reg := di.MustGet[registry.Registry](c, constants.RegistryKey)
+		return es.AggregateStoreWithMiddleware(
+			pg.NewEventStore(constants.ServiceName+".events", tx, reg),
+			pg.NewSnapshotStore(constants.ServiceName+".snapshots", tx, reg),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.UsersRepoKey, func(c di.Container) (es.AggregateRepository[*domain.User], error) {
+		return es.NewAggregateRepository[*domain.User](
+			domain.UserAggregate,
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[es.AggregateStore](c, constants.AggregateStoreKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.TeamsRepoKey, func(c di.Container) (es.AggregateRepository[*domain.Team], error) {
+		return es.NewAggregateRepository[*domain.Team](
+			domain.TeamAggregate,
+			di.MustGet[registry.Registry](c, constants.RegistryKey),
+			di.MustGet[es.AggregateStore](c, constants.AggregateStoreKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.UserProfilesRepoKey, func(c di.Container) (domain.UserProfileRepository, error) {
+		return postgres.NewUserProfileRepository(
+			constants.ServiceName+".users",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.TeamProfilesRepoKey, func(c di.Container) (domain.TeamProfileRepository, error) {
+		return postgres.NewTeamProfileRepository(
+			constants.ServiceName+".teams",
+			constants.ServiceName+".team_members",
+			postgresotel.Trace(di.MustGet[pgx.Tx](c, constants.DatabaseTransactionKey)),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.ApplicationKey, func(c di.Container) (application.App, error) {
+		return application.New(
+			di.MustGet[es.AggregateRepository[*domain.User]](c, constants.UsersRepoKey),
+			di.MustGet[es.AggregateRepository[*domain.Team]](c, constants.TeamsRepoKey),
+			di.MustGet[domain.UserProfileRepository](c, constants.UserProfilesRepoKey),
+			di.MustGet[domain.TeamProfileRepository](c, constants.TeamProfilesRepoKey),
+			di.MustGet[ddd.EventPublisher[ddd.Event]](c, constants.DomainDispatcherKey),
+		), nil
+	})
+
+	di.Register(container, di.Scoped, constants.UserProfileHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewUserProfileHandlers(di.MustGet[domain.UserProfileRepository](c, constants.UserProfilesRepoKey)), nil
+	})
+	di.Register(container, di.Scoped, constants.TeamProfileHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewTeamProfileHandlers(di.MustGet[domain.TeamProfileRepository](c, constants.TeamProfilesRepoKey)), nil
+	})
+	di.Register(container, di.Scoped, constants.DomainEventHandlersKey, func(c di.Container) (ddd.EventHandler[ddd.Event], error) {
+		return handlers.NewDomainEventHandlers(di.MustGet[am.EventPublisher](c, constants.EventPublisherKey)), nil
+	})
+
+	outboxProcessor := tm.NewOutboxProcessor(
+		stream,
+		pg.NewOutboxStore(constants.ServiceName+".outbox", svc.DB()),
+	)
+
+	if err = container.Validate(ctx); err != nil {
+		return err
+	}
+	svc.Waiter().Cleanup(func() {
+		_ = container.Close(context.Background())
+	})
+	svc.Health().Register(constants.ServiceName+".outbox", pg.OutboxBacklogCheck(
+		constants.ServiceName+".outbox", svc.DB(), svc.Config().Health.OutboxBacklog,
+	))
+
+	// setup Driver adapters
+	if err = grpc.RegisterServer(container, svc.RPC(), svc.Logger()); err != nil {
+		return err
+	}
+	if err = rest.RegisterGateway(ctx, svc.Mux(), svc.Config().Rpc.Address()); err != nil {
+		return err
+	}
+	if err = rest.RegisterSwagger(svc.Mux()); err != nil {
+		return err
+	}
+	handlers.RegisterUserProfileHandlersTx(container)
+	handlers.RegisterTeamProfileHandlersTx(container)
+	handlers.RegisterDomainEventHandlersTx(container)
+	startOutboxProcessor(ctx, outboxProcessor, svc.Logger())
+	return nil
+}
+
+// newRegistry registers everything the module serializes
+func newRegistry() (reg registry.Registry, err error) {
+	reg = registry.New()
+	if err = registrations(reg); err != nil {
+		return nil, err
+	}
+	if err = userspb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+func registrations(reg registry.Registry) (err error) {
+	serde := serdes.NewJsonSerde(reg)
+
+	// User
+	if err = serde.Register(domain.User{}, func(v any) error {
+		user := v.(*domain.User)
+		user.Aggregate = es.NewAggregate("", domain.UserAggregate)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// User events
+	if err = serde.Register(domain.UserRegistered{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.UserDietaryProfileChanged{}); err != nil {
+		return
+	}
+
+	// User snapshot
+	if err = serde.RegisterKey(domain.UserV1{}.SnapshotName(), domain.UserV1{}); err != nil {
+		return
+	}
+
+	// Team
+	if err = serde.Register(domain.Team{}, func(v any) error {
+		team := v.(*domain.Team)
+		team.Aggregate = es.NewAggregate("", domain.TeamAggregate)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// Team events
+	if err = serde.Register(domain.TeamCreated{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.TeamMemberInvited{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.TeamInvitationDeclined{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.TeamMemberJoined{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.TeamMemberLeft{}); err != nil {
+		return
+	}
+	if err = serde.Register(domain.TeamHomeLocationChanged{}); err != nil {
+		return
+	}
+
+	// Team snapshot
+	if err = serde.RegisterKey(domain.TeamV1{}.SnapshotName(), domain.TeamV1{}); err != nil {
+		return
+	}
+	return nil
+}
+
+func startOutboxProcessor(ctx context.Context, outboxProcessor tm.OutboxProcessor, logger zerolog.Logger) {
+	go func() {
+		err := outboxProcessor.Start(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msg("users outbox processor encountered an error")
+		}
+	}()
+}
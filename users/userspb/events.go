@@ -0,0 +1,61 @@
+package userspb
+
+import (
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+)
+
+const (
+	UserAggregateChannel = "lunchbox.users.events.User"
+
+	UserRegisteredEvent            = "usersapi.UserRegistered"
+	UserDietaryProfileChangedEvent = "usersapi.UserDietaryProfileChanged"
+
+	TeamAggregateChannel = "lunchbox.users.events.Team"
+
+	TeamCreatedEvent             = "usersapi.TeamCreated"
+	TeamMemberInvitedEvent       = "usersapi.TeamMemberInvited"
+	TeamMemberJoinedEvent        = "usersapi.TeamMemberJoined"
+	TeamMemberLeftEvent          = "usersapi.TeamMemberLeft"
+	TeamHomeLocationChangedEvent = "usersapi.TeamHomeLocationChanged"
+)
+
+func Registrations(reg registry.Registry) error {
+	serde := serdes.NewProtoSerde(reg)
+
+	// User events
+	if err := serde.Register(&UserRegistered{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&UserDietaryProfileChanged{}); err != nil {
+		return err
+	}
+
+	// Team events
+	if err := serde.Register(&TeamCreated{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&TeamMemberInvited{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&TeamMemberJoined{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&TeamMemberLeft{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&TeamHomeLocationChanged{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (*UserRegistered) Key() string            { return UserRegisteredEvent }
+func (*UserDietaryProfileChanged) Key() string { return UserDietaryProfileChangedEvent }
+
+func (*TeamCreated) Key() string             { return TeamCreatedEvent }
+func (*TeamMemberInvited) Key() string       { return TeamMemberInvitedEvent }
+func (*TeamMemberJoined) Key() string        { return TeamMemberJoinedEvent }
+func (*TeamMemberLeft) Key() string          { return TeamMemberLeftEvent }
+func (*TeamHomeLocationChanged) Key() string { return TeamHomeLocationChangedEvent }
@@ -0,0 +1,3 @@
+package visits
+
+//go:generate buf generate
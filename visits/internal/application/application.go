@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/visits/internal/application/commands"
+	"github.com/jongyunha/lunchbox/visits/internal/application/queries"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+type (
+	App interface {
+		Commands
+		Queries
+	}
+
+	Commands interface {
+		LogVisit(ctx context.Context, cmd commands.LogVisit) error
+		RemoveVisit(ctx context.Context, cmd commands.RemoveVisit) error
+		MoveRestaurantVisits(ctx context.Context, cmd commands.MoveRestaurantVisits) error
+	}
+
+	Queries interface {
+		ListVisits(ctx context.Context, query queries.ListVisits) ([]*domain.VisitEntry, error)
+		GetVisitStats(ctx context.Context, query queries.GetVisitStats) ([]*domain.RestaurantVisitStats, error)
+	}
+
+	Application struct {
+		appCommands
+		appQueries
+	}
+
+	appCommands struct {
+		commands.LogVisitHandler
+		commands.RemoveVisitHandler
+		commands.MoveRestaurantVisitsHandler
+	}
+
+	appQueries struct {
+		queries.ListVisitsHandler
+		queries.GetVisitStatsHandler
+	}
+)
+
+var _ App = (*Application)(nil)
+
+func New(
+	visits domain.VisitRepository,
+	history domain.VisitHistoryRepository,
+	stats domain.VisitStatsRepository,
+	publisher ddd.EventPublisher[ddd.Event],
+) *Application {
+	return &Application{
+		appCommands: appCommands{
+			LogVisitHandler:             commands.NewLogVisitHandler(visits, publisher),
+			RemoveVisitHandler:          commands.NewRemoveVisitHandler(visits, publisher),
+			MoveRestaurantVisitsHandler: commands.NewMoveRestaurantVisitsHandler(visits, history, publisher),
+		},
+		appQueries: appQueries{
+			ListVisitsHandler:    queries.NewListVisitsHandler(history),
+			GetVisitStatsHandler: queries.NewGetVisitStatsHandler(stats),
+		},
+	}
+}
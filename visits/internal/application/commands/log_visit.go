@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+type (
+	LogVisit struct {
+		ID           string
+		RestaurantID string
+		UserID       string
+		TeamID       string
+		VisitedAt    time.Time
+		Headcount    int
+		Spend        int
+	}
+
+	LogVisitHandler struct {
+		visits    domain.VisitRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewLogVisitHandler(visits domain.VisitRepository, publisher ddd.EventPublisher[ddd.Event]) LogVisitHandler {
+	return LogVisitHandler{
+		visits:    visits,
+		publisher: publisher,
+	}
+}
+
+func (h LogVisitHandler) LogVisit(ctx context.Context, cmd LogVisit) error {
+	visit, err := h.visits.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := visit.LogVisit(cmd.RestaurantID, cmd.UserID, cmd.TeamID, cmd.VisitedAt, cmd.Headcount, cmd.Spend)
+	if err != nil {
+		return err
+	}
+
+	err = h.visits.Save(ctx, visit)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+type (
+	// MoveRestaurantVisits moves the visits to a restaurant that was merged
+	// into another one over to the survivor
+	MoveRestaurantVisits struct {
+		RestaurantID string
+		SurvivorID   string
+	}
+
+	MoveRestaurantVisitsHandler struct {
+		visits    domain.VisitRepository
+		history   domain.VisitHistoryRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewMoveRestaurantVisitsHandler(visits domain.VisitRepository, history domain.VisitHistoryRepository, publisher ddd.EventPublisher[ddd.Event]) MoveRestaurantVisitsHandler {
+	return MoveRestaurantVisitsHandler{
+		visits:    visits,
+		history:   history,
+		publisher: publisher,
+	}
+}
+
+func (h MoveRestaurantVisitsHandler) MoveRestaurantVisits(ctx context.Context, cmd MoveRestaurantVisits) error {
+	entries, err := h.history.FindByRestaurant(ctx, cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		visit, err := h.visits.Load(ctx, entry.ID)
+		if err != nil {
+			return err
+		}
+
+		event, err := visit.MoveToRestaurant(cmd.SurvivorID)
+		if err != nil {
+			return err
+		}
+
+		if err = h.visits.Save(ctx, visit); err != nil {
+			return err
+		}
+
+		if err = h.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
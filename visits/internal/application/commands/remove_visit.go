@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+type (
+	RemoveVisit struct {
+		ID     string
+		UserID string
+	}
+
+	RemoveVisitHandler struct {
+		visits    domain.VisitRepository
+		publisher ddd.EventPublisher[ddd.Event]
+	}
+)
+
+func NewRemoveVisitHandler(visits domain.VisitRepository, publisher ddd.EventPublisher[ddd.Event]) RemoveVisitHandler {
+	return RemoveVisitHandler{
+		visits:    visits,
+		publisher: publisher,
+	}
+}
+
+func (h RemoveVisitHandler) RemoveVisit(ctx context.Context, cmd RemoveVisit) error {
+	visit, err := h.visits.Load(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := visit.RemoveVisit(cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = h.visits.Save(ctx, visit)
+	if err != nil {
+		return err
+	}
+
+	return h.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,33 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+type (
+	GetVisitStats struct {
+		UserID string
+		TeamID string
+	}
+
+	GetVisitStatsHandler struct {
+		stats domain.VisitStatsRepository
+	}
+)
+
+func NewGetVisitStatsHandler(stats domain.VisitStatsRepository) GetVisitStatsHandler {
+	return GetVisitStatsHandler{
+		stats: stats,
+	}
+}
+
+func (h GetVisitStatsHandler) GetVisitStats(ctx context.Context, query GetVisitStats) ([]*domain.RestaurantVisitStats, error) {
+	subject, err := domain.NewSubject(query.UserID, query.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.stats.FindBySubject(ctx, subject)
+}
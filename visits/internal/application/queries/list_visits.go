@@ -0,0 +1,41 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+const defaultVisitsLimit = 50
+
+type (
+	ListVisits struct {
+		UserID string
+		TeamID string
+		Limit  int
+	}
+
+	ListVisitsHandler struct {
+		history domain.VisitHistoryRepository
+	}
+)
+
+func NewListVisitsHandler(history domain.VisitHistoryRepository) ListVisitsHandler {
+	return ListVisitsHandler{
+		history: history,
+	}
+}
+
+func (h ListVisitsHandler) ListVisits(ctx context.Context, query ListVisits) ([]*domain.VisitEntry, error) {
+	subject, err := domain.NewSubject(query.UserID, query.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultVisitsLimit
+	}
+
+	return h.history.FindBySubject(ctx, subject, limit)
+}
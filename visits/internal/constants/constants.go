@@ -0,0 +1,33 @@
+package constants
+
+// ServiceName The name of this module/service
+const ServiceName = "visits"
+
+// GRPC Service Names
+const (
+	VisitsServiceName = "VISITS"
+)
+
+// Dependency Injection Keys
+const (
+	RegistryKey            = "registry"
+	DomainDispatcherKey    = "domainDispatcher"
+	DatabaseTransactionKey = "tx"
+	IdempotencyStoreKey    = "idempotencyStore"
+	MessagePublisherKey    = "messagePublisher"
+	MessageSubscriberKey   = "messageSubscriber"
+	EventPublisherKey      = "eventPublisher"
+	AggregateStoreKey      = "aggregateStore"
+	ApplicationKey         = "app"
+	DomainEventHandlersKey = "domainEventHandlers"
+	InboxStoreKey          = "inboxStore"
+
+	IntegrationEventHandlersKey = "integrationEventHandlers"
+
+	HistoryHandlersKey = "historyHandlers"
+	StatsHandlersKey   = "statsHandlers"
+
+	VisitsRepoKey       = "visitsRepo"
+	VisitHistoryRepoKey = "visitHistoryRepo"
+	VisitStatsRepoKey   = "visitStatsRepo"
+)
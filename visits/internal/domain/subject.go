@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/stackus/errors"
+)
+
+var (
+	ErrSubjectIsBlank = errors.Wrap(errors.ErrBadRequest, "either a user id or a team id is required")
+)
+
+type SubjectType string
+
+const (
+	UserSubject SubjectType = "user"
+	TeamSubject SubjectType = "team"
+)
+
+// Subject is who the visit history is kept for; a person or a team
+type Subject struct {
+	Type SubjectType
+	ID   string
+}
+
+// NewSubject prefers the team when both ids are given
+func NewSubject(userID, teamID string) (Subject, error) {
+	switch {
+	case teamID != "":
+		return Subject{Type: TeamSubject, ID: teamID}, nil
+	case userID != "":
+		return Subject{Type: UserSubject, ID: userID}, nil
+	default:
+		return Subject{}, ErrSubjectIsBlank
+	}
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/es"
+	"github.com/stackus/errors"
+)
+
+const (
+	VisitAggregate = "visits.Visit"
+)
+
+var (
+	ErrRestaurantIDIsBlank = errors.Wrap(errors.ErrBadRequest, "the restaurant id cannot be blank")
+	ErrUserIDIsBlank       = errors.Wrap(errors.ErrBadRequest, "the user id cannot be blank")
+	ErrInvalidHeadcount    = errors.Wrap(errors.ErrBadRequest, "the headcount must be at least one")
+	ErrInvalidSpend        = errors.Wrap(errors.ErrBadRequest, "the spend cannot be negative")
+	ErrVisitInFuture       = errors.Wrap(errors.ErrBadRequest, "a visit cannot be logged for the future")
+	ErrVisitAlreadyLogged  = errors.Wrap(errors.ErrAlreadyExists, "the visit has already been logged")
+	ErrVisitNotFound       = errors.Wrap(errors.ErrNotFound, "the visit does not exist")
+	ErrVisitNotOwned       = errors.Wrap(errors.ErrPermissionDenied, "only the person who logged a visit can remove it")
+)
+
+type Visit struct {
+	es.Aggregate
+	RestaurantID string
+	UserID       string
+	// TeamID is blank when the person ate on their own
+	TeamID    string
+	VisitedAt time.Time
+	Headcount int
+	// Spend is the total spent by the whole party
+	Spend   int
+	Removed bool
+}
+
+var _ interface {
+	es.EventApplier
+	es.Snapshotter
+} = (*Visit)(nil)
+
+func (v *Visit) ApplyEvent(event ddd.Event) error {
+	switch payload := event.Payload().(type) {
+	case *VisitLogged:
+		v.RestaurantID = payload.RestaurantID
+		v.UserID = payload.UserID
+		v.TeamID = payload.TeamID
+		v.VisitedAt = payload.VisitedAt
+		v.Headcount = payload.Headcount
+		v.Spend = payload.Spend
+	case *VisitRemoved:
+		v.Removed = true
+	case *VisitMoved:
+		v.RestaurantID = payload.RestaurantID
+	default:
+		return errors.ErrInternal.Msgf("%T received the event %s with unexpected payload %T", v, event.EventName(), payload)
+	}
+
+	return nil
+}
+
+func (v *Visit) ApplySnapshot(snapshot es.Snapshot) error {
+	switch ss := snapshot.(type) {
+	case *VisitV1:
+		v.RestaurantID = ss.RestaurantID
+		v.UserID = ss.UserID
+		v.TeamID = ss.TeamID
+		v.VisitedAt = ss.VisitedAt
+		v.Headcount = ss.Headcount
+		v.Spend = ss.Spend
+		v.Removed = ss.Removed
+	default:
+		return errors.ErrInternal.Msgf("%T received the unexpected snapshot %T", v, snapshot)
+	}
+
+	return nil
+}
+
+func (v *Visit) ToSnapshot() es.Snapshot {
+	return VisitV1{
+		RestaurantID: v.RestaurantID,
+		UserID:       v.UserID,
+		TeamID:       v.TeamID,
+		VisitedAt:    v.VisitedAt,
+		Headcount:    v.Headcount,
+		Spend:        v.Spend,
+		Removed:      v.Removed,
+	}
+}
+
+func (v *Visit) LogVisit(restaurantID, userID, teamID string, visitedAt time.Time, headcount, spend int) (ddd.Event, error) {
+	if v.RestaurantID != "" {
+		return nil, ErrVisitAlreadyLogged
+	}
+	if restaurantID == "" {
+		return nil, ErrRestaurantIDIsBlank
+	}
+	if userID == "" {
+		return nil, ErrUserIDIsBlank
+	}
+	if headcount < 1 {
+		return nil, ErrInvalidHeadcount
+	}
+	if spend < 0 {
+		return nil, ErrInvalidSpend
+	}
+	if visitedAt.After(time.Now()) {
+		return nil, ErrVisitInFuture
+	}
+
+	v.AddEvent(VisitLoggedEvent, &VisitLogged{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		TeamID:       teamID,
+		VisitedAt:    visitedAt,
+		Headcount:    headcount,
+		Spend:        spend,
+	})
+
+	return ddd.NewEvent(VisitLoggedEvent, v), nil
+}
+
+func (v *Visit) RemoveVisit(userID string) (ddd.Event, error) {
+	if v.RestaurantID == "" || v.Removed {
+		return nil, ErrVisitNotFound
+	}
+	if v.UserID != userID {
+		return nil, ErrVisitNotOwned
+	}
+
+	v.AddEvent(VisitRemovedEvent, &VisitRemoved{})
+
+	return ddd.NewEvent(VisitRemovedEvent, v), nil
+}
+
+// MoveToRestaurant moves the visit over to the restaurant its restaurant was
+// merged into
+func (v *Visit) MoveToRestaurant(restaurantID string) (ddd.Event, error) {
+	if restaurantID == "" {
+		return nil, ErrRestaurantIDIsBlank
+	}
+	if v.RestaurantID == "" || v.Removed {
+		return nil, ErrVisitNotFound
+	}
+
+	previousRestaurantID := v.RestaurantID
+
+	v.AddEvent(VisitMovedEvent, &VisitMoved{
+		RestaurantID: restaurantID,
+	})
+
+	return ddd.NewEvent(VisitMovedEvent, v, ddd.Metadata{PreviousRestaurantIDKey: previousRestaurantID}), nil
+}
+
+func (Visit) Key() string {
+	return VisitAggregate
+}
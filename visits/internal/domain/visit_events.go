@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	VisitLoggedEvent  = "visits.VisitLogged"
+	VisitRemovedEvent = "visits.VisitRemoved"
+	VisitMovedEvent   = "visits.VisitMoved"
+)
+
+// PreviousRestaurantIDKey is the event metadata key holding the restaurant a
+// moved visit was taken from
+const PreviousRestaurantIDKey = "PreviousRestaurantID"
+
+type VisitLogged struct {
+	RestaurantID string
+	UserID       string
+	TeamID       string
+	VisitedAt    time.Time
+	Headcount    int
+	Spend        int
+}
+
+func (VisitLogged) Key() string { return VisitLoggedEvent }
+
+type VisitRemoved struct{}
+
+func (VisitRemoved) Key() string { return VisitRemovedEvent }
+
+type VisitMoved struct {
+	RestaurantID string
+}
+
+func (VisitMoved) Key() string { return VisitMovedEvent }
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// VisitEntry is a single visit in the history read model
+type VisitEntry struct {
+	ID           string
+	RestaurantID string
+	UserID       string
+	TeamID       string
+	VisitedAt    time.Time
+	Headcount    int
+	Spend        int
+}
+
+type VisitHistoryRepository interface {
+	Add(ctx context.Context, visit *VisitEntry) error
+	Remove(ctx context.Context, visitID string) error
+	Move(ctx context.Context, visitID, restaurantID string) error
+	FindByRestaurant(ctx context.Context, restaurantID string) ([]*VisitEntry, error)
+	// FindBySubject returns the most recent visits first
+	FindBySubject(ctx context.Context, subject Subject, limit int) ([]*VisitEntry, error)
+}
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"context"
+)
+
+type VisitRepository interface {
+	Load(ctx context.Context, visitID string) (*Visit, error)
+	Save(ctx context.Context, visit *Visit) error
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+)
+
+type VisitV1 struct {
+	RestaurantID string
+	UserID       string
+	TeamID       string
+	VisitedAt    time.Time
+	Headcount    int
+	Spend        int
+	Removed      bool
+}
+
+func (VisitV1) SnapshotName() string { return "visits.VisitV1" }
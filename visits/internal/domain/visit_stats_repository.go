@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RestaurantVisitStats is how often a person or team has eaten at a restaurant
+type RestaurantVisitStats struct {
+	RestaurantID  string
+	Count         int
+	LastVisitedAt time.Time
+}
+
+type VisitStatsRepository interface {
+	// Refresh recalculates the stats of the subject at a restaurant from the
+	// visit history
+	Refresh(ctx context.Context, subject Subject, restaurantID string) error
+	// FindBySubject returns the most recently visited restaurants first
+	FindBySubject(ctx context.Context, subject Subject) ([]*RestaurantVisitStats, error)
+}
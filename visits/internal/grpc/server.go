@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jongyunha/lunchbox/internal/auth"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/idempotency"
+	"github.com/jongyunha/lunchbox/internal/rpc"
+	"github.com/jongyunha/lunchbox/visits/internal/application"
+	"github.com/jongyunha/lunchbox/visits/internal/application/commands"
+	"github.com/jongyunha/lunchbox/visits/internal/application/queries"
+	"github.com/jongyunha/lunchbox/visits/internal/constants"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+	"github.com/jongyunha/lunchbox/visits/visitspb"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type server struct {
+	c di.Container
+	visitspb.UnimplementedVisitsServiceServer
+}
+
+var _ visitspb.VisitsServiceServer = (*server)(nil)
+
+func RegisterServer(c di.Container, registrar grpc.ServiceRegistrar, logger zerolog.Logger) error {
+	visitspb.RegisterVisitsServiceServer(
+		rpc.NewTransactionalRegistrar(registrar, c, logger,
+			idempotency.UnaryServerInterceptor(constants.IdempotencyStoreKey,
+				visitspb.VisitsService_LogVisit_FullMethodName,
+				visitspb.VisitsService_RemoveVisit_FullMethodName,
+			),
+		),
+		server{c: c},
+	)
+
+	return nil
+}
+
+// app returns the application of the scope attached to the context
+func (s server) app(ctx context.Context) application.App {
+	return di.MustGet[application.App](di.FromContext(ctx), constants.ApplicationKey)
+}
+
+func (s server) LogVisit(ctx context.Context, request *visitspb.LogVisitRequest) (*visitspb.LogVisitResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	visitID := uuid.New().String()
+
+	visitedAt := time.Now()
+	if request.GetVisitedAt() != nil {
+		visitedAt = request.GetVisitedAt().AsTime()
+	}
+
+	err = s.app(ctx).LogVisit(ctx, commands.LogVisit{
+		ID:           visitID,
+		RestaurantID: request.GetRestaurantId(),
+		UserID:       userID,
+		TeamID:       request.GetTeamId(),
+		VisitedAt:    visitedAt,
+		Headcount:    int(request.GetHeadcount()),
+		Spend:        int(request.GetSpend()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &visitspb.LogVisitResponse{
+		Id: visitID,
+	}, nil
+}
+
+func (s server) RemoveVisit(ctx context.Context, request *visitspb.RemoveVisitRequest) (*visitspb.RemoveVisitResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app(ctx).RemoveVisit(ctx, commands.RemoveVisit{
+		ID:     request.GetId(),
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &visitspb.RemoveVisitResponse{}, nil
+}
+
+func (s server) ListVisits(ctx context.Context, request *visitspb.ListVisitsRequest) (*visitspb.ListVisitsResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	visits, err := s.app(ctx).ListVisits(ctx, queries.ListVisits{
+		UserID: userID,
+		TeamID: request.GetTeamId(),
+		Limit:  int(request.GetLimit()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &visitspb.ListVisitsResponse{
+		Visits: make([]*visitspb.Visit, len(visits)),
+	}
+	for i, visit := range visits {
+		resp.Visits[i] = s.visitFromDomain(visit)
+	}
+
+	return resp, nil
+}
+
+func (s server) GetVisitStats(ctx context.Context, request *visitspb.GetVisitStatsRequest) (*visitspb.GetVisitStatsResponse, error) {
+	userID, err := auth.ActingUser(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.app(ctx).GetVisitStats(ctx, queries.GetVisitStats{
+		UserID: userID,
+		TeamID: request.GetTeamId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &visitspb.GetVisitStatsResponse{
+		Stats: make([]*visitspb.RestaurantVisitStats, len(stats)),
+	}
+	for i, stat := range stats {
+		resp.Stats[i] = s.statsFromDomain(stat)
+	}
+
+	return resp, nil
+}
+
+func (s server) visitFromDomain(visit *domain.VisitEntry) *visitspb.Visit {
+	return &visitspb.Visit{
+		Id:           visit.ID,
+		RestaurantId: visit.RestaurantID,
+		UserId:       visit.UserID,
+		TeamId:       visit.TeamID,
+		VisitedAt:    timestamppb.New(visit.VisitedAt),
+		Headcount:    int32(visit.Headcount),
+		Spend:        int64(visit.Spend),
+	}
+}
+
+func (s server) statsFromDomain(stats *domain.RestaurantVisitStats) *visitspb.RestaurantVisitStats {
+	return &visitspb.RestaurantVisitStats{
+		RestaurantId:  stats.RestaurantID,
+		Count:         int32(stats.Count),
+		LastVisitedAt: timestamppb.New(stats.LastVisitedAt),
+	}
+}
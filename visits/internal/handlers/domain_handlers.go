@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/am"
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/visits/internal/constants"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+	"github.com/jongyunha/lunchbox/visits/visitspb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type domainHandlers[T ddd.Event] struct {
+	publisher am.EventPublisher
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*domainHandlers[ddd.Event])(nil)
+
+func NewDomainEventHandlers(publisher am.EventPublisher) ddd.EventHandler[ddd.Event] {
+	return &domainHandlers[ddd.Event]{
+		publisher: publisher,
+	}
+}
+
+func RegisterDomainEventHandlers(subscriber ddd.EventSubscriber[ddd.Event], handlers ddd.EventHandler[ddd.Event]) {
+	subscriber.Subscribe(handlers,
+		domain.VisitLoggedEvent,
+		domain.VisitRemovedEvent,
+	)
+}
+
+func RegisterDomainEventHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		domainHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.DomainEventHandlersKey)
+
+		return domainHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterDomainEventHandlers(subscriber, handlers)
+}
+
+func (d domainHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling domain event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled domain event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	span.AddEvent("Handling domain event", trace.WithAttributes(
+		attribute.String("Event", event.EventName()),
+	))
+
+	switch event.EventName() {
+	case domain.VisitLoggedEvent:
+		return d.onVisitLogged(ctx, event)
+	case domain.VisitRemovedEvent:
+		return d.onVisitRemoved(ctx, event)
+	}
+
+	return nil
+}
+
+func (d domainHandlers[T]) onVisitLogged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Visit)
+	return d.publisher.Publish(ctx, visitspb.VisitAggregateChannel, ddd.NewEvent(
+		visitspb.VisitLoggedEvent,
+		&visitspb.VisitLogged{
+			Id:           payload.ID(),
+			RestaurantId: payload.RestaurantID,
+			UserId:       payload.UserID,
+			TeamId:       payload.TeamID,
+			VisitedAt:    timestamppb.New(payload.VisitedAt),
+			Headcount:    int32(payload.Headcount),
+			Spend:        int64(payload.Spend),
+		},
+	))
+}
+
+func (d domainHandlers[T]) onVisitRemoved(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Visit)
+	return d.publisher.Publish(ctx, visitspb.VisitAggregateChannel, ddd.NewEvent(
+		visitspb.VisitRemovedEvent,
+		&visitspb.VisitRemoved{
+			Id:           payload.ID(),
+			RestaurantId: payload.RestaurantID,
+			UserId:       payload.UserID,
+			TeamId:       payload.TeamID,
+		},
+	))
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/visits/internal/constants"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type HistoryHandlers[T ddd.Event] struct {
+	history domain.VisitHistoryRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*HistoryHandlers[ddd.Event])(nil)
+
+func NewHistoryHandlers(history domain.VisitHistoryRepository) *HistoryHandlers[ddd.Event] {
+	return &HistoryHandlers[ddd.Event]{
+		history: history,
+	}
+}
+
+func (h HistoryHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling visit event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled visit event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.VisitLoggedEvent:
+		return h.onVisitLogged(ctx, event)
+	case domain.VisitRemovedEvent:
+		return h.onVisitRemoved(ctx, event)
+	case domain.VisitMovedEvent:
+		return h.onVisitMoved(ctx, event)
+	}
+	return nil
+}
+
+func (h HistoryHandlers[T]) onVisitLogged(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Visit)
+	return h.history.Add(ctx, &domain.VisitEntry{
+		ID:           payload.ID(),
+		RestaurantID: payload.RestaurantID,
+		UserID:       payload.UserID,
+		TeamID:       payload.TeamID,
+		VisitedAt:    payload.VisitedAt,
+		Headcount:    payload.Headcount,
+		Spend:        payload.Spend,
+	})
+}
+
+func (h HistoryHandlers[T]) onVisitRemoved(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Visit)
+	return h.history.Remove(ctx, payload.ID())
+}
+
+func (h HistoryHandlers[T]) onVisitMoved(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Visit)
+	return h.history.Move(ctx, payload.ID(), payload.RestaurantID)
+}
+
+func RegisterHistoryHandlers(historyHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(historyHandlers,
+		domain.VisitLoggedEvent,
+		domain.VisitRemovedEvent,
+		domain.VisitMovedEvent,
+	)
+}
+
+func RegisterHistoryHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		historyHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.HistoryHandlersKey)
+
+		return historyHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterHistoryHandlers(handlers, subscriber)
+}
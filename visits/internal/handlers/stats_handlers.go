@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jongyunha/lunchbox/internal/ddd"
+	"github.com/jongyunha/lunchbox/internal/di"
+	"github.com/jongyunha/lunchbox/internal/errorsotel"
+	"github.com/jongyunha/lunchbox/visits/internal/constants"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type StatsHandlers[T ddd.Event] struct {
+	stats domain.VisitStatsRepository
+}
+
+var _ ddd.EventHandler[ddd.Event] = (*StatsHandlers[ddd.Event])(nil)
+
+func NewStatsHandlers(stats domain.VisitStatsRepository) *StatsHandlers[ddd.Event] {
+	return &StatsHandlers[ddd.Event]{
+		stats: stats,
+	}
+}
+
+func (h StatsHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
+	span := trace.SpanFromContext(ctx)
+	defer func(started time.Time) {
+		if err != nil {
+			span.AddEvent(
+				"Encountered an error handling visit stats event",
+				trace.WithAttributes(errorsotel.ErrAttrs(err)...),
+			)
+		}
+		span.AddEvent("Handled visit stats event", trace.WithAttributes(
+			attribute.Int64("TookMS", time.Since(started).Milliseconds()),
+		))
+	}(time.Now())
+
+	switch event.EventName() {
+	case domain.VisitLoggedEvent, domain.VisitRemovedEvent:
+		payload := event.Payload().(*domain.Visit)
+		return h.refreshStats(ctx, payload, payload.RestaurantID)
+	case domain.VisitMovedEvent:
+		return h.onVisitMoved(ctx, event)
+	}
+	return nil
+}
+
+// refreshStats relies on the history handlers having updated the visit
+// history earlier in the same transaction
+func (h StatsHandlers[T]) refreshStats(ctx context.Context, visit *domain.Visit, restaurantID string) error {
+	err := h.stats.Refresh(ctx, domain.Subject{Type: domain.UserSubject, ID: visit.UserID}, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	if visit.TeamID == "" {
+		return nil
+	}
+
+	return h.stats.Refresh(ctx, domain.Subject{Type: domain.TeamSubject, ID: visit.TeamID}, restaurantID)
+}
+
+// onVisitMoved refreshes the stats at both the restaurant the visit was taken
+// from and the one it moved to
+func (h StatsHandlers[T]) onVisitMoved(ctx context.Context, event ddd.Event) error {
+	payload := event.Payload().(*domain.Visit)
+
+	err := h.refreshStats(ctx, payload, event.Metadata().Get(domain.PreviousRestaurantIDKey).(string))
+	if err != nil {
+		return err
+	}
+
+	return h.refreshStats(ctx, payload, payload.RestaurantID)
+}
+
+func RegisterStatsHandlers(statsHandlers ddd.EventHandler[ddd.Event], subscriber ddd.EventSubscriber[ddd.Event]) {
+	subscriber.Subscribe(statsHandlers,
+		domain.VisitLoggedEvent,
+		domain.VisitRemovedEvent,
+		domain.VisitMovedEvent,
+	)
+}
+
+func RegisterStatsHandlersTx(container di.Container) {
+	handlers := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		statsHandlers := di.MustGet[ddd.EventHandler[ddd.Event]](di.FromContext(ctx), constants.StatsHandlersKey)
+
+		return statsHandlers.HandleEvent(ctx, event)
+	})
+
+	subscriber := di.MustGet[*ddd.EventDispatcher[ddd.Event]](container, constants.DomainDispatcherKey)
+	RegisterStatsHandlers(handlers, subscriber)
+}
@@ -0,0 +1,72 @@
+-- +goose Up
+CREATE TABLE visits.visits (
+  id            text        NOT NULL,
+  restaurant_id text        NOT NULL,
+  user_id       text        NOT NULL,
+  team_id       text        NOT NULL DEFAULT '',
+  visited_at    timestamptz NOT NULL,
+  headcount     int         NOT NULL,
+  spend         bigint      NOT NULL DEFAULT 0,
+  created_at    timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX visits_user_visited_at_idx ON visits.visits (user_id, visited_at DESC);
+CREATE INDEX visits_team_visited_at_idx ON visits.visits (team_id, visited_at DESC) WHERE team_id <> '';
+
+CREATE TABLE visits.restaurant_visit_stats (
+  subject_type    text        NOT NULL,
+  subject_id      text        NOT NULL,
+  restaurant_id   text        NOT NULL,
+  visit_count     int         NOT NULL DEFAULT 0,
+  last_visited_at timestamptz NOT NULL,
+  updated_at      timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (subject_type, subject_id, restaurant_id)
+);
+
+CREATE TRIGGER updated_at_restaurant_visit_stats_trgr
+  BEFORE UPDATE
+  ON visits.restaurant_visit_stats
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE visits.events (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  event_id       text        NOT NULL,
+  event_name     text        NOT NULL,
+  event_data     bytea       NOT NULL,
+  occurred_at    timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name, stream_version)
+);
+
+CREATE TABLE visits.snapshots (
+  stream_id      text        NOT NULL,
+  stream_name    text        NOT NULL,
+  stream_version int         NOT NULL,
+  snapshot_name  text        NOT NULL,
+  snapshot_data  bytea       NOT NULL,
+  updated_at     timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (stream_id, stream_name)
+);
+
+CREATE TRIGGER updated_at_snapshots_trgr
+  BEFORE UPDATE
+  ON visits.snapshots
+  FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
+CREATE TABLE visits.outbox (
+  id           text        NOT NULL,
+  name         text        NOT NULL,
+  subject      text        NOT NULL,
+  data         bytea       NOT NULL,
+  metadata     bytea       NOT NULL,
+  sent_at      timestamptz NOT NULL,
+  published_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX visits_unpublished_idx ON visits.outbox (published_at) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE visits.outbox, visits.snapshots, visits.events, visits.restaurant_visit_stats, visits.visits;
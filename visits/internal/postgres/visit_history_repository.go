@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+type VisitHistoryRepository struct {
+	tableName string
+	db        postgres.DBTX
+}
+
+var _ domain.VisitHistoryRepository = (*VisitHistoryRepository)(nil)
+
+func NewVisitHistoryRepository(tableName string, db postgres.DBTX) VisitHistoryRepository {
+	return VisitHistoryRepository{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+func (r VisitHistoryRepository) Add(ctx context.Context, visit *domain.VisitEntry) error {
+	const query = `INSERT INTO %s (id, restaurant_id, user_id, team_id, visited_at, headcount, spend)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(ctx, r.table(query),
+		visit.ID, visit.RestaurantID, visit.UserID, visit.TeamID, visit.VisitedAt, visit.Headcount, visit.Spend,
+	)
+
+	return err
+}
+
+func (r VisitHistoryRepository) Remove(ctx context.Context, visitID string) error {
+	const query = "DELETE FROM %s WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), visitID)
+
+	return err
+}
+
+func (r VisitHistoryRepository) Move(ctx context.Context, visitID, restaurantID string) error {
+	const query = "UPDATE %s SET restaurant_id = $2 WHERE id = $1"
+
+	_, err := r.db.Exec(ctx, r.table(query), visitID, restaurantID)
+
+	return err
+}
+
+func (r VisitHistoryRepository) FindByRestaurant(ctx context.Context, restaurantID string) ([]*domain.VisitEntry, error) {
+	const query = `SELECT id, restaurant_id, user_id, team_id, visited_at, headcount, spend
+FROM %s WHERE restaurant_id = $1 ORDER BY visited_at DESC`
+
+	rows, err := r.db.Query(ctx, r.table(query), restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanVisits(rows)
+}
+
+func (r VisitHistoryRepository) FindBySubject(ctx context.Context, subject domain.Subject, limit int) ([]*domain.VisitEntry, error) {
+	const query = `SELECT id, restaurant_id, user_id, team_id, visited_at, headcount, spend
+FROM %s WHERE %s = $1 ORDER BY visited_at DESC LIMIT $2`
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(query, r.tableName, subjectColumn(subject)), subject.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanVisits(rows)
+}
+
+func (r VisitHistoryRepository) scanVisits(rows pgx.Rows) ([]*domain.VisitEntry, error) {
+	var visits []*domain.VisitEntry
+	for rows.Next() {
+		visit := &domain.VisitEntry{}
+		err := rows.Scan(
+			&visit.ID, &visit.RestaurantID, &visit.UserID, &visit.TeamID, &visit.VisitedAt, &visit.Headcount, &visit.Spend,
+		)
+		if err != nil {
+			return nil, err
+		}
+		visits = append(visits, visit)
+	}
+
+	return visits, rows.Err()
+}
+
+func (r VisitHistoryRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
+
+func subjectColumn(subject domain.Subject) string {
+	if subject.Type == domain.TeamSubject {
+		return "team_id"
+	}
+	return "user_id"
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jongyunha/lunchbox/internal/postgres"
+	"github.com/jongyunha/lunchbox/visits/internal/domain"
+)
+
+type VisitStatsRepository struct {
+	tableName        string
+	historyTableName string
+	db               postgres.DBTX
+}
+
+var _ domain.VisitStatsRepository = (*VisitStatsRepository)(nil)
+
+func NewVisitStatsRepository(tableName, historyTableName string, db postgres.DBTX) VisitStatsRepository {
+	return VisitStatsRepository{
+		tableName:        tableName,
+		historyTableName: historyTableName,
+		db:               db,
+	}
+}
+
+func (r VisitStatsRepository) Refresh(ctx context.Context, subject domain.Subject, restaurantID string) error {
+	// the stats row is removed once the last visit has been removed
+	query := fmt.Sprintf(`WITH stats AS (
+  SELECT count(*) AS visit_count, max(visited_at) AS last_visited_at
+  FROM %[2]s WHERE %[3]s = $2 AND restaurant_id = $3
+), deleted AS (
+  DELETE FROM %[1]s WHERE subject_type = $1 AND subject_id = $2 AND restaurant_id = $3
+    AND (SELECT visit_count FROM stats) = 0
+)
+INSERT INTO %[1]s (subject_type, subject_id, restaurant_id, visit_count, last_visited_at)
+SELECT $1, $2, $3, visit_count, last_visited_at FROM stats WHERE visit_count > 0
+ON CONFLICT (subject_type, subject_id, restaurant_id) DO UPDATE
+  SET visit_count = EXCLUDED.visit_count,
+      last_visited_at = EXCLUDED.last_visited_at`, r.tableName, r.historyTableName, subjectColumn(subject))
+
+	_, err := r.db.Exec(ctx, query, string(subject.Type), subject.ID, restaurantID)
+
+	return err
+}
+
+func (r VisitStatsRepository) FindBySubject(ctx context.Context, subject domain.Subject) ([]*domain.RestaurantVisitStats, error) {
+	const query = `SELECT restaurant_id, visit_count, last_visited_at
+FROM %s WHERE subject_type = $1 AND subject_id = $2 ORDER BY last_visited_at DESC`
+
+	rows, err := r.db.Query(ctx, r.table(query), string(subject.Type), subject.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*domain.RestaurantVisitStats
+	for rows.Next() {
+		stat := &domain.RestaurantVisitStats{}
+		if err := rows.Scan(&stat.RestaurantID, &stat.Count, &stat.LastVisitedAt); err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r VisitStatsRepository) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: visitspb/api.proto
+
+package visitspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VisitsService_LogVisit_FullMethodName      = "/visitspb.VisitsService/LogVisit"
+	VisitsService_RemoveVisit_FullMethodName   = "/visitspb.VisitsService/RemoveVisit"
+	VisitsService_ListVisits_FullMethodName    = "/visitspb.VisitsService/ListVisits"
+	VisitsService_GetVisitStats_FullMethodName = "/visitspb.VisitsService/GetVisitStats"
+)
+
+// VisitsServiceClient is the client API for VisitsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VisitsServiceClient interface {
+	LogVisit(ctx context.Context, in *LogVisitRequest, opts ...grpc.CallOption) (*LogVisitResponse, error)
+	RemoveVisit(ctx context.Context, in *RemoveVisitRequest, opts ...grpc.CallOption) (*RemoveVisitResponse, error)
+	ListVisits(ctx context.Context, in *ListVisitsRequest, opts ...grpc.CallOption) (*ListVisitsResponse, error)
+	GetVisitStats(ctx context.Context, in *GetVisitStatsRequest, opts ...grpc.CallOption) (*GetVisitStatsResponse, error)
+}
+
+type visitsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVisitsServiceClient(cc grpc.ClientConnInterface) VisitsServiceClient {
+	return &visitsServiceClient{cc}
+}
+
+func (c *visitsServiceClient) LogVisit(ctx context.Context, in *LogVisitRequest, opts ...grpc.CallOption) (*LogVisitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogVisitResponse)
+	err := c.cc.Invoke(ctx, VisitsService_LogVisit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visitsServiceClient) RemoveVisit(ctx context.Context, in *RemoveVisitRequest, opts ...grpc.CallOption) (*RemoveVisitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveVisitResponse)
+	err := c.cc.Invoke(ctx, VisitsService_RemoveVisit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visitsServiceClient) ListVisits(ctx context.Context, in *ListVisitsRequest, opts ...grpc.CallOption) (*ListVisitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVisitsResponse)
+	err := c.cc.Invoke(ctx, VisitsService_ListVisits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visitsServiceClient) GetVisitStats(ctx context.Context, in *GetVisitStatsRequest, opts ...grpc.CallOption) (*GetVisitStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVisitStatsResponse)
+	err := c.cc.Invoke(ctx, VisitsService_GetVisitStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VisitsServiceServer is the server API for VisitsService service.
+// All implementations must embed UnimplementedVisitsServiceServer
+// for forward compatibility.
+type VisitsServiceServer interface {
+	LogVisit(context.Context, *LogVisitRequest) (*LogVisitResponse, error)
+	RemoveVisit(context.Context, *RemoveVisitRequest) (*RemoveVisitResponse, error)
+	ListVisits(context.Context, *ListVisitsRequest) (*ListVisitsResponse, error)
+	GetVisitStats(context.Context, *GetVisitStatsRequest) (*GetVisitStatsResponse, error)
+	mustEmbedUnimplementedVisitsServiceServer()
+}
+
+// UnimplementedVisitsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVisitsServiceServer struct{}
+
+func (UnimplementedVisitsServiceServer) LogVisit(context.Context, *LogVisitRequest) (*LogVisitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogVisit not implemented")
+}
+func (UnimplementedVisitsServiceServer) RemoveVisit(context.Context, *RemoveVisitRequest) (*RemoveVisitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVisit not implemented")
+}
+func (UnimplementedVisitsServiceServer) ListVisits(context.Context, *ListVisitsRequest) (*ListVisitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVisits not implemented")
+}
+func (UnimplementedVisitsServiceServer) GetVisitStats(context.Context, *GetVisitStatsRequest) (*GetVisitStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVisitStats not implemented")
+}
+func (UnimplementedVisitsServiceServer) mustEmbedUnimplementedVisitsServiceServer() {}
+func (UnimplementedVisitsServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeVisitsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VisitsServiceServer will
+// result in compilation errors.
+type UnsafeVisitsServiceServer interface {
+	mustEmbedUnimplementedVisitsServiceServer()
+}
+
+func RegisterVisitsServiceServer(s grpc.ServiceRegistrar, srv VisitsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedVisitsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VisitsService_ServiceDesc, srv)
+}
+
+func _VisitsService_LogVisit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogVisitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisitsServiceServer).LogVisit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisitsService_LogVisit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisitsServiceServer).LogVisit(ctx, req.(*LogVisitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisitsService_RemoveVisit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveVisitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisitsServiceServer).RemoveVisit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisitsService_RemoveVisit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisitsServiceServer).RemoveVisit(ctx, req.(*RemoveVisitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisitsService_ListVisits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVisitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisitsServiceServer).ListVisits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisitsService_ListVisits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisitsServiceServer).ListVisits(ctx, req.(*ListVisitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisitsService_GetVisitStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVisitStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisitsServiceServer).GetVisitStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisitsService_GetVisitStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisitsServiceServer).GetVisitStats(ctx, req.(*GetVisitStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VisitsService_ServiceDesc is the grpc.ServiceDesc for VisitsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VisitsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "visitspb.VisitsService",
+	HandlerType: (*VisitsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LogVisit",
+			Handler:    _VisitsService_LogVisit_Handler,
+		},
+		{
+			MethodName: "RemoveVisit",
+			Handler:    _VisitsService_RemoveVisit_Handler,
+		},
+		{
+			MethodName: "ListVisits",
+			Handler:    _VisitsService_ListVisits_Handler,
+		},
+		{
+			MethodName: "GetVisitStats",
+			Handler:    _VisitsService_GetVisitStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "visitspb/api.proto",
+}
@@ -0,0 +1,30 @@
+package visitspb
+
+import (
+	"github.com/jongyunha/lunchbox/internal/registry"
+	"github.com/jongyunha/lunchbox/internal/registry/serdes"
+)
+
+const (
+	VisitAggregateChannel = "lunchbox.visits.events.Visit"
+
+	VisitLoggedEvent  = "visitsapi.VisitLogged"
+	VisitRemovedEvent = "visitsapi.VisitRemoved"
+)
+
+func Registrations(reg registry.Registry) error {
+	serde := serdes.NewProtoSerde(reg)
+
+	// Visit events
+	if err := serde.Register(&VisitLogged{}); err != nil {
+		return err
+	}
+	if err := serde.Register(&VisitRemoved{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (*VisitLogged) Key() string  { return VisitLoggedEvent }
+func (*VisitRemoved) Key() string { return VisitRemovedEvent }